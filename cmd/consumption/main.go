@@ -0,0 +1,177 @@
+// Command consumption imports an hourly consumption CSV export of a distributor or Datadis
+// and prints the cost of the imported consumption of every household at the stored PVPC prices.
+//
+// It reads the same PVPC_* environment configuration as the HTTP server. Example:
+//
+//	go run ./cmd/consumption -file consumo.csv -zone PEN
+//	go run ./cmd/consumption -file consumo.csv -zone PEN -report hour
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/joho/godotenv"
+	"github.com/kelseyhightower/envconfig"
+
+	"pvpc-backend/internal/domain"
+	server "pvpc-backend/internal/platform/http"
+	"pvpc-backend/internal/platform/importers/consumptioncsv"
+	"pvpc-backend/internal/platform/storage/postgresql"
+	"pvpc-backend/internal/platform/storage/sqlite"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+type config struct {
+	// Database configuration
+	StorageDriver string        `split_words:"true" default:"postgresql"` // postgresql or sqlite
+	SqlitePath    string        `split_words:"true" default:"pvpc.db"`
+	DbUser        string        `split_words:"true" default:"test_db_user"`
+	DbPass        string        `split_words:"true" default:"test_db_pass"`
+	DbHost        string        `split_words:"true" default:"localhost"`
+	DbPort        uint          `split_words:"true" default:"5432"`
+	DbName        string        `split_words:"true" default:"test_db_name"`
+	DbTimeout     time.Duration `split_words:"true" default:"5s"`
+}
+
+var (
+	flags  = flag.NewFlagSet("consumption", flag.ExitOnError)
+	file   = flags.String("file", "", "consumption CSV export to import")
+	zone   = flags.String("zone", "PEN", "zone the households of the export are billed in")
+	report = flags.String("report", "day", "costs report to print: day, hour or none")
+)
+
+func main() {
+	flags.Parse(os.Args[1:])
+
+	if *file == "" {
+		logger.Fatal("Invalid arguments", "err", "-file is required")
+	}
+	if *report != "day" && *report != "hour" && *report != "none" {
+		logger.Fatal("Invalid arguments", "err", fmt.Sprintf("invalid -report: %s", *report))
+	}
+	zoneID, err := domain.NewZoneID(*zone)
+	if err != nil {
+		logger.Fatal("Invalid arguments", "err", err)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		logger.Fatal("Error opening consumption file", "file", *file, "err", err)
+	}
+	defer f.Close()
+	readings, err := consumptioncsv.Parse(f)
+	if err != nil {
+		logger.Fatal("Invalid consumption file", "file", *file, "err", err)
+	}
+
+	cfg := loadConfig()
+	db, err := databaseConnection(cfg)
+	if err != nil {
+		logger.Fatal("Error connecting to database", "err", err)
+	}
+	defer db.Close()
+
+	var householdsRepository domain.HouseholdsRepository
+	var pricesRepository domain.PricesRepository
+	var zonesRepository domain.ZonesRepository
+	switch cfg.StorageDriver {
+	case server.StorageDriverSQLite:
+		householdsRepository = sqlite.NewHouseholdsRepository(db, cfg.DbTimeout)
+		pricesRepository = sqlite.NewPricesRepository(db, cfg.DbTimeout)
+		zonesRepository = sqlite.NewZonesRepository(db, cfg.DbTimeout)
+	default:
+		householdsRepository = postgresql.NewHouseholdsRepository(db, cfg.DbTimeout)
+		pricesRepository = postgresql.NewPricesRepository(db, cfg.DbTimeout)
+		zonesRepository = postgresql.NewZonesRepository(db, cfg.DbTimeout)
+	}
+
+	ctx := context.Background()
+	householdsService := services.NewHouseholdsService(householdsRepository, zonesRepository, pricesRepository)
+	imported, err := householdsService.ImportConsumption(ctx, zoneID, readings)
+	if err != nil {
+		logger.Fatal("Consumption import failed", "err", err)
+	}
+
+	for _, household := range imported {
+		logger.Info("Consumption imported", "cups", household.CUPS.String(), "hours", household.Hours, "from", household.From, "to", household.To)
+		from, to := household.From.UTC(), household.To.UTC()
+		// Costs are reported by the household's local days, which may start the UTC day before.
+		from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+		to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+		switch *report {
+		case "day":
+			costs, err := householdsService.DailyCosts(ctx, household.CUPS, from, to)
+			if err != nil {
+				logger.Fatal("Costs report failed", "cups", household.CUPS.String(), "err", err)
+			}
+			printDailyCosts(household.CUPS, costs)
+		case "hour":
+			costs, err := householdsService.HourlyCosts(ctx, household.CUPS, from, to)
+			if err != nil {
+				logger.Fatal("Costs report failed", "cups", household.CUPS.String(), "err", err)
+			}
+			printHourlyCosts(household.CUPS, costs)
+		}
+	}
+}
+
+func printDailyCosts(cups domain.CUPS, costs []services.DailyCost) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "CUPS\tDATE\tKWH\tCOST\tMISSING PRICES\n")
+	for _, c := range costs {
+		fmt.Fprintf(w, "%s\t%s\t%.3f\t%.2f\t%d\n", cups.String(), c.Date.Format(time.DateOnly), c.Kwh, c.Cost, c.MissingPricesHours)
+	}
+	w.Flush()
+}
+
+func printHourlyCosts(cups domain.CUPS, costs []services.HourlyCost) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "CUPS\tDATETIME\tKWH\tPRICE\tCOST\n")
+	for _, c := range costs {
+		price, cost := "-", "-"
+		if c.Price != nil {
+			price, cost = fmt.Sprintf("%.2f", *c.Price), fmt.Sprintf("%.6f", *c.Cost)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%.3f\t%s\t%s\n", cups.String(), c.Datetime.Format(time.RFC3339), c.Kwh, price, cost)
+	}
+	w.Flush()
+}
+
+func loadConfig() config {
+	var cfg config
+	if err := godotenv.Load(); err != nil {
+		logger.Warn("Error loading .env file", "err", err)
+	}
+	if err := envconfig.Process("PVPC", &cfg); err != nil {
+		logger.Fatal("Error processing env config", "err", err)
+	}
+	return cfg
+}
+
+func databaseConnection(cfg config) (*sql.DB, error) {
+	var db *sql.DB
+	var err error
+
+	switch cfg.StorageDriver {
+	case server.StorageDriverPostgreSQL:
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?connect_timeout=%d", cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName, int(cfg.DbTimeout.Seconds()))
+		db, err = sql.Open("pgx", connStr)
+	case server.StorageDriverSQLite:
+		db, err = sqlite.Open(cfg.SqlitePath)
+	default:
+		err = fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return db, db.Ping()
+}
@@ -0,0 +1,42 @@
+// fakeree serves a fake of the REE APIs, so the backend can run offline.
+// Point both PVPC_ESIOS_API_URL and PVPC_REDATA_API_URL to it.
+//
+// Faults can be injected at runtime, e.g.:
+//
+//	curl -X PUT localhost:8081/faults/esios -d '{"status_code": 502, "times": 2}'
+//	curl -X PUT localhost:8081/faults/redata -d '{"delay": "30s"}'
+//	curl -X PUT localhost:8081/faults/esios -d '{"missing_geo_ids": ["8742", "8745"]}'
+//	curl -X DELETE localhost:8081/faults
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"pvpc-backend/internal/platform/providers/fakeree"
+	"pvpc-backend/pkg/logger"
+)
+
+var (
+	flags = flag.NewFlagSet("fakeree", flag.ExitOnError)
+	addr  = flags.String("addr", "localhost:8081", "address to listen on")
+	docs  = flags.String("docs", "docs", "directory with the REE response examples")
+	token = flags.String("token", "", "Esios API token to require in the x-api-key header (none if empty)")
+)
+
+func main() {
+	flags.Parse(os.Args[1:])
+	logger.SetDefaultLoggerText(&slog.HandlerOptions{Level: slog.LevelInfo})
+
+	srv, err := fakeree.NewServer(*docs, *token)
+	if err != nil {
+		logger.Fatal("Error loading fixtures", "err", err, "docs", *docs)
+	}
+
+	logger.Info("Fake REE server running", "address", *addr)
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		logger.Fatal("Unexpected server shutdown", "err", err)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/kelseyhightower/envconfig"
 
 	server "pvpc-backend/internal/platform/http"
+	"pvpc-backend/internal/platform/providers/resilient"
+	"pvpc-backend/internal/platform/storage/sqlite"
 	"pvpc-backend/pkg/logger"
 )
 
@@ -22,16 +24,35 @@ type config struct {
 	Env             string        `split_words:"true" required:"true"`
 	LogLevel        string        `split_words:"true" default:"info"`
 	// Database configuration
-	DbUser    string        `split_words:"true" default:"test_db_user"`
-	DbPass    string        `split_words:"true" default:"test_db_pass"`
-	DbHost    string        `split_words:"true" default:"localhost"`
-	DbPort    uint          `split_words:"true" default:"5432"`
-	DbName    string        `split_words:"true" default:"test_db_name"`
-	DbTimeout time.Duration `split_words:"true" default:"5s"`
+	StorageDriver string        `split_words:"true" default:"postgresql"` // postgresql or sqlite
+	SqlitePath    string        `split_words:"true" default:"pvpc.db"`
+	DbUser        string        `split_words:"true" default:"test_db_user"`
+	DbPass        string        `split_words:"true" default:"test_db_pass"`
+	DbHost        string        `split_words:"true" default:"localhost"`
+	DbPort        uint          `split_words:"true" default:"5432"`
+	DbName        string        `split_words:"true" default:"test_db_name"`
+	DbTimeout     time.Duration `split_words:"true" default:"5s"`
 	// REE API configuration
 	RedataApiUrl  string `split_words:"true" required:"true"`
 	EsiosApiUrl   string `split_words:"true" required:"true"`
 	EsiosApiToken string `split_words:"true" required:"true"`
+	// Ordered chain of prices providers, each one used as fallback of the previous ones
+	PricesProviders []string `split_words:"true" default:"esios,redata"` // esios and/or redata
+	// Esios indicators fetched along with prices, each one as ID[:GEO_ID[|GEO_ID...]]
+	Indicators []string `split_words:"true"`
+	// JSON file with national holidays, loaded into the holidays calendar at startup
+	HolidaysFile string `split_words:"true"`
+	// JSON file with the bill rates per period of validity, used to simulate bills
+	BillRatesFile string `split_words:"true"`
+	// Token required as "Authorization: Bearer <token>" by the admin endpoints, which are disabled if empty
+	AdminToken string `split_words:"true"`
+	// REE API client configuration
+	ProvidersTimeout          time.Duration `split_words:"true" default:"10s"`
+	ProvidersMaxRetries       uint          `split_words:"true" default:"3"`
+	ProvidersBaseBackoff      time.Duration `split_words:"true" default:"500ms"`
+	ProvidersMaxBackoff       time.Duration `split_words:"true" default:"10s"`
+	ProvidersBreakerThreshold uint          `split_words:"true" default:"5"`
+	ProvidersBreakerCooldown  time.Duration `split_words:"true" default:"1m"`
 }
 
 func main() {
@@ -40,17 +61,28 @@ func main() {
 	cfg := loadConfig()
 	configureLogger(cfg.LogLevel)
 
-	db, err := databaseConnection(cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName, cfg.DbTimeout)
+	db, err := databaseConnection(cfg)
 	if err != nil {
 		logger.Fatal("Error connecting to database", "err", err)
 	}
 	logger.Debug("Database connection established")
 	defer db.Close()
 
-	srv := server.NewHttpServer(cfg.Host, cfg.Port, cfg.Env, cfg.ShutdownTimeout, db, cfg.DbTimeout, cfg.RedataApiUrl, cfg.EsiosApiUrl, cfg.EsiosApiToken)
+	srv := server.NewHttpServer(cfg.Host, cfg.Port, cfg.Env, cfg.ShutdownTimeout, cfg.StorageDriver, db, cfg.DbTimeout, cfg.RedataApiUrl, cfg.EsiosApiUrl, cfg.EsiosApiToken, cfg.PricesProviders, cfg.Indicators, cfg.HolidaysFile, cfg.BillRatesFile, cfg.AdminToken, providersConfig(cfg))
 	srv.Run()
 }
 
+func providersConfig(cfg config) resilient.Config {
+	return resilient.Config{
+		Timeout:          cfg.ProvidersTimeout,
+		MaxRetries:       cfg.ProvidersMaxRetries,
+		BaseBackoff:      cfg.ProvidersBaseBackoff,
+		MaxBackoff:       cfg.ProvidersMaxBackoff,
+		BreakerThreshold: cfg.ProvidersBreakerThreshold,
+		BreakerCooldown:  cfg.ProvidersBreakerCooldown,
+	}
+}
+
 func configureLogger(level string) {
 	loggerOpts := &slog.HandlerOptions{Level: logger.ParseLevel(level)}
 	logger.SetDefaultLoggerJSON(loggerOpts)
@@ -69,10 +101,20 @@ func loadConfig() config {
 	return cfg
 }
 
-func databaseConnection(user, pass, host string, port uint, name string, timeout time.Duration) (*sql.DB, error) {
-	logger.Debug("Connecting to database...")
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?connect_timeout=%d", user, pass, host, port, name, timeout)
-	db, err := sql.Open("pgx", connStr)
+func databaseConnection(cfg config) (*sql.DB, error) {
+	var db *sql.DB
+	var err error
+
+	logger.Debug("Connecting to database...", "driver", cfg.StorageDriver)
+	switch cfg.StorageDriver {
+	case server.StorageDriverPostgreSQL:
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?connect_timeout=%d", cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName, cfg.DbTimeout)
+		db, err = sql.Open("pgx", connStr)
+	case server.StorageDriverSQLite:
+		db, err = sqlite.Open(cfg.SqlitePath)
+	default:
+		err = fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
 	"fmt"
 	"os"
@@ -10,20 +11,23 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/pressly/goose/v3"
 
+	"pvpc-backend/internal/platform/storage/sqlite"
 	"pvpc-backend/pkg/logger"
 )
 
 type config struct {
-	DbUser string `split_words:"true" default:"test_db_user"`
-	DbPass string `split_words:"true" default:"test_db_pass"`
-	DbHost string `split_words:"true" default:"localhost"`
-	DbPort uint   `split_words:"true" default:"5432"`
-	DbName string `split_words:"true" default:"test_db_name"`
+	StorageDriver string `split_words:"true" default:"postgresql"` // postgresql or sqlite
+	SqlitePath    string `split_words:"true" default:"pvpc.db"`
+	DbUser        string `split_words:"true" default:"test_db_user"`
+	DbPass        string `split_words:"true" default:"test_db_pass"`
+	DbHost        string `split_words:"true" default:"localhost"`
+	DbPort        uint   `split_words:"true" default:"5432"`
+	DbName        string `split_words:"true" default:"test_db_name"`
 }
 
 var (
 	flags = flag.NewFlagSet("migrate", flag.ExitOnError)
-	dir   = flags.String("dir", "internal/platform/storage/postgresql/migrations", "directory with migration files")
+	dir   = flags.String("dir", "", "directory with migration files (default internal/platform/storage/<storage driver>/migrations)")
 )
 
 func main() {
@@ -41,13 +45,16 @@ func main() {
 
 	cfg := loadConfig()
 
-	logger.Info("Opening DB connection...")
-	connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName)
-	db, err := goose.OpenDBWithDriver("pgx", connStr)
+	logger.Info("Opening DB connection...", "driver", cfg.StorageDriver)
+	db, err := openDB(cfg)
 	if err != nil {
 		logger.Fatal("Error opening DB", "err", err)
 	}
 
+	if *dir == "" {
+		*dir = fmt.Sprintf("internal/platform/storage/%s/migrations", cfg.StorageDriver)
+	}
+
 	defer func() {
 		if err := db.Close(); err != nil {
 			logger.Fatal("Error closing DB", "err", err)
@@ -75,6 +82,21 @@ func loadConfig() config {
 	return cfg
 }
 
+func openDB(cfg config) (*sql.DB, error) {
+	switch cfg.StorageDriver {
+	case "postgresql":
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s", cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName)
+		return goose.OpenDBWithDriver("pgx", connStr)
+	case "sqlite":
+		if err := goose.SetDialect("sqlite3"); err != nil {
+			return nil, err
+		}
+		return sqlite.Open(cfg.SqlitePath)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+	}
+}
+
 func usage() {
 	fmt.Println(usagePrefix)
 	flags.PrintDefaults()
@@ -0,0 +1,187 @@
+// Command reconcile compares, for a date range, the prices stored with the ones published
+// by Esios and REData, reports the hours where they disagree and optionally repairs the
+// stored prices from the preferred provider.
+//
+// It reads the same PVPC_* environment configuration as the HTTP server. Example:
+//
+//	go run ./cmd/reconcile -from 2023-09-01 -to 2023-09-08 -tolerance 0.01
+//	go run ./cmd/reconcile -from 2023-09-08 -prefer esios -repair
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/joho/godotenv"
+	"github.com/kelseyhightower/envconfig"
+
+	"pvpc-backend/internal/domain"
+	server "pvpc-backend/internal/platform/http"
+	"pvpc-backend/internal/platform/providers/esios"
+	"pvpc-backend/internal/platform/providers/redataapi"
+	"pvpc-backend/internal/platform/providers/resilient"
+	"pvpc-backend/internal/platform/storage/postgresql"
+	"pvpc-backend/internal/platform/storage/sqlite"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+type config struct {
+	// Database configuration
+	StorageDriver string        `split_words:"true" default:"postgresql"` // postgresql or sqlite
+	SqlitePath    string        `split_words:"true" default:"pvpc.db"`
+	DbUser        string        `split_words:"true" default:"test_db_user"`
+	DbPass        string        `split_words:"true" default:"test_db_pass"`
+	DbHost        string        `split_words:"true" default:"localhost"`
+	DbPort        uint          `split_words:"true" default:"5432"`
+	DbName        string        `split_words:"true" default:"test_db_name"`
+	DbTimeout     time.Duration `split_words:"true" default:"5s"`
+	// REE API configuration
+	RedataApiUrl  string `split_words:"true" required:"true"`
+	EsiosApiUrl   string `split_words:"true" required:"true"`
+	EsiosApiToken string `split_words:"true" required:"true"`
+	// REE API client configuration
+	ProvidersTimeout    time.Duration `split_words:"true" default:"10s"`
+	ProvidersMaxRetries uint          `split_words:"true" default:"3"`
+}
+
+var (
+	flags     = flag.NewFlagSet("reconcile", flag.ExitOnError)
+	from      = flags.String("from", "", "first day to reconcile, YYYY-MM-DD (default yesterday)")
+	to        = flags.String("to", "", "last day to reconcile, YYYY-MM-DD (default same as -from)")
+	tolerance = flags.Float64("tolerance", 0.01, "maximum difference allowed between sources for the same hour")
+	prefer    = flags.String("prefer", server.PricesProviderEsios, "provider trusted when sources disagree")
+	repair    = flags.Bool("repair", false, "replace the stored prices that disagree with the preferred provider ones")
+)
+
+func main() {
+	flags.Parse(os.Args[1:])
+
+	opts, err := reconciliationOptions()
+	if err != nil {
+		logger.Fatal("Invalid arguments", "err", err)
+	}
+
+	cfg := loadConfig()
+	db, err := databaseConnection(cfg)
+	if err != nil {
+		logger.Fatal("Error connecting to database", "err", err)
+	}
+	defer db.Close()
+
+	var pricesRepository domain.PricesRepository
+	var zonesRepository domain.ZonesRepository
+	switch cfg.StorageDriver {
+	case server.StorageDriverSQLite:
+		pricesRepository = sqlite.NewPricesRepository(db, cfg.DbTimeout)
+		zonesRepository = sqlite.NewZonesRepository(db, cfg.DbTimeout)
+	default:
+		pricesRepository = postgresql.NewPricesRepository(db, cfg.DbTimeout)
+		zonesRepository = postgresql.NewZonesRepository(db, cfg.DbTimeout)
+	}
+
+	clientConfig := resilient.Config{Timeout: cfg.ProvidersTimeout, MaxRetries: cfg.ProvidersMaxRetries, BaseBackoff: 500 * time.Millisecond, MaxBackoff: 10 * time.Second}
+	providers := map[string]domain.PricesProvider{
+		server.PricesProviderEsios:  esios.NewEsiosAPI(cfg.EsiosApiUrl, cfg.EsiosApiToken, resilient.NewClient(server.PricesProviderEsios, clientConfig)),
+		server.PricesProviderREData: redataapi.NewREDataAPI(cfg.RedataApiUrl, resilient.NewClient(server.PricesProviderREData, clientConfig)),
+	}
+
+	reconciliationService := services.NewReconciliationService(providers, pricesRepository, zonesRepository)
+	report, err := reconciliationService.Reconcile(context.Background(), opts)
+	if err != nil {
+		logger.Fatal("Reconciliation failed", "err", err)
+	}
+
+	printReport(report)
+	logger.Info("Reconciliation completed", "comparedHours", report.ComparedHours, "discrepancies", len(report.Discrepancies), "repaired", len(report.Repaired))
+}
+
+func reconciliationOptions() (services.ReconciliationOptions, error) {
+	opts := services.ReconciliationOptions{Tolerance: *tolerance, PreferredProvider: *prefer, Repair: *repair}
+
+	if *from == "" {
+		yesterday := time.Now().AddDate(0, 0, -1)
+		opts.From = time.Date(yesterday.Year(), yesterday.Month(), yesterday.Day(), 0, 0, 0, 0, time.UTC)
+	} else {
+		date, err := time.Parse(time.DateOnly, *from)
+		if err != nil {
+			return opts, fmt.Errorf("invalid -from date: %w", err)
+		}
+		opts.From = date
+	}
+
+	opts.To = opts.From
+	if *to != "" {
+		date, err := time.Parse(time.DateOnly, *to)
+		if err != nil {
+			return opts, fmt.Errorf("invalid -to date: %w", err)
+		}
+		opts.To = date
+	}
+
+	return opts, nil
+}
+
+func printReport(report services.ReconciliationReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ZONE\tDATETIME\t%s\n", strings.ToUpper(strings.Join(report.Sources, "\t")))
+	for _, d := range report.Discrepancies {
+		values := make([]string, len(report.Sources))
+		for i, source := range report.Sources {
+			if value, ok := d.Values[source]; ok {
+				values[i] = fmt.Sprintf("%.2f", value)
+			} else {
+				values[i] = "-"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", d.ZoneID.String(), d.Datetime.Format(time.RFC3339), strings.Join(values, "\t"))
+	}
+	w.Flush()
+
+	if len(report.Repaired) > 0 {
+		repaired := make([]string, len(report.Repaired))
+		for i, id := range report.Repaired {
+			repaired[i] = id.String()
+		}
+		sort.Strings(repaired)
+		fmt.Printf("\nRepaired: %s\n", strings.Join(repaired, ", "))
+	}
+}
+
+func loadConfig() config {
+	var cfg config
+	if err := godotenv.Load(); err != nil {
+		logger.Warn("Error loading .env file", "err", err)
+	}
+	if err := envconfig.Process("PVPC", &cfg); err != nil {
+		logger.Fatal("Error processing env config", "err", err)
+	}
+	return cfg
+}
+
+func databaseConnection(cfg config) (*sql.DB, error) {
+	var db *sql.DB
+	var err error
+
+	switch cfg.StorageDriver {
+	case server.StorageDriverPostgreSQL:
+		connStr := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?connect_timeout=%d", cfg.DbUser, cfg.DbPass, cfg.DbHost, cfg.DbPort, cfg.DbName, int(cfg.DbTimeout.Seconds()))
+		db, err = sql.Open("pgx", connStr)
+	case server.StorageDriverSQLite:
+		db, err = sqlite.Open(cfg.SqlitePath)
+	default:
+		err = fmt.Errorf("unknown storage driver: %s", cfg.StorageDriver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return db, db.Ping()
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"pvpc-backend/internal/domain/errors"
+)
+
+// BatteryDto is the DTO struct used to build a Battery value object by calling domain.NewBattery().
+type BatteryDto struct {
+	CapacityKwh         float64
+	ChargePowerKw       float64
+	DischargePowerKw    float64
+	RoundTripEfficiency float64
+}
+
+// Battery is the value object that represents a home battery: its usable capacity (kWh), the maximum
+// power (kW) it charges from and discharges to the grid at, and the share of the charged energy
+// that can be discharged back (round-trip efficiency, from 0 to 1).
+type Battery struct {
+	capacityKwh         float64
+	chargePowerKw       float64
+	dischargePowerKw    float64
+	roundTripEfficiency float64
+}
+
+// NewBattery creates a new Battery struct.
+func NewBattery(batteryDto BatteryDto) (Battery, error) {
+	if batteryDto.CapacityKwh <= 0 || batteryDto.ChargePowerKw <= 0 || batteryDto.DischargePowerKw <= 0 {
+		return Battery{}, errors.NewDomainError(errors.InvalidBattery, "battery capacity and powers must be positive: %v kWh, charge %v kW, discharge %v kW",
+			batteryDto.CapacityKwh, batteryDto.ChargePowerKw, batteryDto.DischargePowerKw)
+	}
+	if batteryDto.RoundTripEfficiency <= 0 || batteryDto.RoundTripEfficiency > 1 {
+		return Battery{}, errors.NewDomainError(errors.InvalidBattery, "battery round-trip efficiency must be greater than 0 and up to 1: %v", batteryDto.RoundTripEfficiency)
+	}
+
+	return Battery{
+		capacityKwh:         batteryDto.CapacityKwh,
+		chargePowerKw:       batteryDto.ChargePowerKw,
+		dischargePowerKw:    batteryDto.DischargePowerKw,
+		roundTripEfficiency: batteryDto.RoundTripEfficiency,
+	}, nil
+}
+
+// CapacityKwh returns the Battery's usable capacity, in kWh.
+func (b Battery) CapacityKwh() float64 {
+	return b.capacityKwh
+}
+
+// ChargePowerKw returns the Battery's maximum charge power, in kW.
+func (b Battery) ChargePowerKw() float64 {
+	return b.chargePowerKw
+}
+
+// DischargePowerKw returns the Battery's maximum discharge power, in kW.
+func (b Battery) DischargePowerKw() float64 {
+	return b.dischargePowerKw
+}
+
+// RoundTripEfficiency returns the share of the charged energy that the Battery can discharge back.
+func (b Battery) RoundTripEfficiency() float64 {
+	return b.roundTripEfficiency
+}
+
+// Serialize returns the BatteryDto struct that represents the Battery.
+func (b Battery) Serialize() BatteryDto {
+	return BatteryDto{
+		CapacityKwh:         b.capacityKwh,
+		ChargePowerKw:       b.chargePowerKw,
+		DischargePowerKw:    b.dischargePowerKw,
+		RoundTripEfficiency: b.roundTripEfficiency,
+	}
+}
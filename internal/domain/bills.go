@@ -0,0 +1,320 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// BillRatesDto is the DTO struct used to build a BillRates domain entity by calling domain.NewBillRates().
+type BillRatesDto struct {
+	ValidFrom             string  // YYYY-MM-DD
+	ValidTo               string  // YYYY-MM-DD, included. Empty while the rates are in force
+	PowerP1               float64 // €/kW per day
+	PowerP2               float64 // €/kW per day
+	ElectricityTax        float64 // rate over the energy and power terms
+	ElectricityTaxMinimum float64 // €/MWh consumed
+	MeterRental           float64 // € per day
+	VAT                   float64 // rate over the rest of the bill
+}
+
+// BillRates is the domain entity that represents the regulated rates, other than the hourly energy prices,
+// that apply to a PVPC bill during a period of validity.
+type BillRates struct {
+	validFrom             time.Time
+	validTo               *time.Time
+	powerP1               Decimal
+	powerP2               Decimal
+	electricityTax        Decimal
+	electricityTaxMinimum Decimal
+	meterRental           Decimal
+	vat                   Decimal
+}
+
+// NewBillRates creates a new BillRates struct.
+func NewBillRates(ratesDto BillRatesDto) (BillRates, error) {
+	validFrom, err := time.Parse(time.DateOnly, ratesDto.ValidFrom)
+	if err != nil {
+		return BillRates{}, errors.WrapIntoDomainError(err, errors.InvalidBillRates, fmt.Sprintf("error parsing BillRates validFrom value: %s", ratesDto.ValidFrom))
+	}
+
+	var validTo *time.Time
+	if ratesDto.ValidTo != "" {
+		to, err := time.Parse(time.DateOnly, ratesDto.ValidTo)
+		if err != nil {
+			return BillRates{}, errors.WrapIntoDomainError(err, errors.InvalidBillRates, fmt.Sprintf("error parsing BillRates validTo value: %s", ratesDto.ValidTo))
+		}
+		if to.Before(validFrom) {
+			return BillRates{}, errors.NewDomainError(errors.InvalidBillRates, "BillRates validTo %s is before validFrom %s", ratesDto.ValidTo, ratesDto.ValidFrom)
+		}
+		validTo = &to
+	}
+
+	for name, value := range map[string]float64{
+		"powerP1":               ratesDto.PowerP1,
+		"powerP2":               ratesDto.PowerP2,
+		"electricityTax":        ratesDto.ElectricityTax,
+		"electricityTaxMinimum": ratesDto.ElectricityTaxMinimum,
+		"meterRental":           ratesDto.MeterRental,
+		"vat":                   ratesDto.VAT,
+	} {
+		if value < 0 {
+			return BillRates{}, errors.NewDomainError(errors.InvalidBillRates, "BillRates valid from %s has a negative %s: %v", ratesDto.ValidFrom, name, value)
+		}
+	}
+
+	return BillRates{
+		validFrom:             validFrom,
+		validTo:               validTo,
+		powerP1:               NewDecimalFromFloat(ratesDto.PowerP1),
+		powerP2:               NewDecimalFromFloat(ratesDto.PowerP2),
+		electricityTax:        NewDecimalFromFloat(ratesDto.ElectricityTax),
+		electricityTaxMinimum: NewDecimalFromFloat(ratesDto.ElectricityTaxMinimum),
+		meterRental:           NewDecimalFromFloat(ratesDto.MeterRental),
+		vat:                   NewDecimalFromFloat(ratesDto.VAT),
+	}, nil
+}
+
+// ValidFrom returns the first day the BillRates apply, as a UTC midnight.
+func (r BillRates) ValidFrom() time.Time {
+	return r.validFrom
+}
+
+// ValidTo returns the last day the BillRates apply, as a UTC midnight, or nil while they are in force.
+func (r BillRates) ValidTo() *time.Time {
+	return r.validTo
+}
+
+// PowerP1 returns the power term of the P1 period, in €/kW per day.
+func (r BillRates) PowerP1() Decimal {
+	return r.powerP1
+}
+
+// PowerP2 returns the power term of the P2 period, in €/kW per day.
+func (r BillRates) PowerP2() Decimal {
+	return r.powerP2
+}
+
+// ElectricityTax returns the electricity tax rate.
+func (r BillRates) ElectricityTax() Decimal {
+	return r.electricityTax
+}
+
+// ElectricityTaxMinimum returns the minimum electricity tax, in €/MWh consumed.
+func (r BillRates) ElectricityTaxMinimum() Decimal {
+	return r.electricityTaxMinimum
+}
+
+// MeterRental returns the meter rental, in € per day.
+func (r BillRates) MeterRental() Decimal {
+	return r.meterRental
+}
+
+// VAT returns the VAT rate.
+func (r BillRates) VAT() Decimal {
+	return r.vat
+}
+
+// AppliesOn reports whether the BillRates apply on the given day, taken in its location.
+func (r BillRates) AppliesOn(day time.Time) bool {
+	date := day.Format(time.DateOnly)
+	if date < r.validFrom.Format(time.DateOnly) {
+		return false
+	}
+	return r.validTo == nil || date <= r.validTo.Format(time.DateOnly)
+}
+
+// Serialize returns the BillRatesDto struct that represents the BillRates.
+func (r BillRates) Serialize() BillRatesDto {
+	ratesDto := BillRatesDto{
+		ValidFrom:             r.validFrom.Format(time.DateOnly),
+		PowerP1:               r.powerP1.Float64(),
+		PowerP2:               r.powerP2.Float64(),
+		ElectricityTax:        r.electricityTax.Float64(),
+		ElectricityTaxMinimum: r.electricityTaxMinimum.Float64(),
+		MeterRental:           r.meterRental.Float64(),
+		VAT:                   r.vat.Float64(),
+	}
+	if r.validTo != nil {
+		ratesDto.ValidTo = r.validTo.Format(time.DateOnly)
+	}
+	return ratesDto
+}
+
+// BillRatesSchedule is the set of BillRates over time, without overlapping periods of validity.
+type BillRatesSchedule struct {
+	rates []BillRates
+}
+
+// NewBillRatesSchedule creates a new BillRatesSchedule with the given rates, in any order.
+// It returns an InvalidBillRates error if their periods of validity overlap.
+func NewBillRatesSchedule(rates []BillRates) (BillRatesSchedule, error) {
+	sorted := make([]BillRates, len(rates))
+	copy(sorted, rates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].validFrom.Before(sorted[j].validFrom)
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		previous := sorted[i-1]
+		if previous.validTo == nil || !previous.validTo.Before(sorted[i].validFrom) {
+			return BillRatesSchedule{}, errors.NewDomainError(errors.InvalidBillRates, "BillRates valid from %s overlap with the ones valid from %s",
+				previous.validFrom.Format(time.DateOnly), sorted[i].validFrom.Format(time.DateOnly))
+		}
+	}
+
+	return BillRatesSchedule{rates: sorted}, nil
+}
+
+// RatesOn returns the BillRates that apply on the given day, taken in its location, if any.
+func (s BillRatesSchedule) RatesOn(day time.Time) (BillRates, bool) {
+	for _, rates := range s.rates {
+		if rates.AppliesOn(day) {
+			return rates, true
+		}
+	}
+	return BillRates{}, false
+}
+
+// ContractedPower is the value object that represents the power contracted by a 2.0TD supply
+// for each power period: P1 (peak and flat hours) and P2 (off-peak hours), in kW.
+type ContractedPower struct {
+	p1 float64
+	p2 float64
+}
+
+// NewContractedPower creates a new ContractedPower struct.
+func NewContractedPower(p1, p2 float64) (ContractedPower, error) {
+	if p1 <= 0 || p2 <= 0 {
+		return ContractedPower{}, errors.NewDomainError(errors.InvalidContractedPower, "contracted power must be positive: P1 %v kW, P2 %v kW", p1, p2)
+	}
+	return ContractedPower{p1: p1, p2: p2}, nil
+}
+
+// P1 returns the contracted power of the P1 period, in kW.
+func (p ContractedPower) P1() float64 {
+	return p.p1
+}
+
+// P2 returns the contracted power of the P2 period, in kW.
+func (p ContractedPower) P2() float64 {
+	return p.p2
+}
+
+// BillConcept identifies what a BillLine charges.
+type BillConcept string
+
+const (
+	// BillConceptEnergy is the energy term, the consumption of every hour at its PVPC price.
+	BillConceptEnergy BillConcept = "energy"
+	// BillConceptPowerP1 is the power term of the P1 period.
+	BillConceptPowerP1 BillConcept = "power_p1"
+	// BillConceptPowerP2 is the power term of the P2 period.
+	BillConceptPowerP2 BillConcept = "power_p2"
+	// BillConceptElectricityTax is the electricity tax, over the energy and power terms.
+	BillConceptElectricityTax BillConcept = "electricity_tax"
+	// BillConceptMeterRental is the meter rental.
+	BillConceptMeterRental BillConcept = "meter_rental"
+	// BillConceptVAT is the VAT, over the rest of the lines.
+	BillConceptVAT BillConcept = "vat"
+)
+
+// String converts the BillConcept into string.
+func (c BillConcept) String() string {
+	return string(c)
+}
+
+// BillDto is the DTO struct used to build a Bill domain entity by calling domain.NewBill().
+type BillDto struct {
+	ZoneID string
+	From   string // YYYY-MM-DD
+	To     string // YYYY-MM-DD, included
+	Lines  []BillLineDto
+}
+
+// BillLineDto is the DTO struct that represents a line item of a Bill.
+// Used as a part of BillDto and only to build a Bill domain entity.
+type BillLineDto struct {
+	Concept  string
+	From     string // YYYY-MM-DD
+	To       string // YYYY-MM-DD, included
+	Quantity float64
+	Unit     string
+	Price    float64 // € per unit
+	Amount   float64 // €
+}
+
+// Bill is the domain entity that represents a simulated PVPC bill: its line items and total.
+type Bill struct {
+	zoneID ZoneID
+	from   time.Time
+	to     time.Time
+	lines  []BillLineDto
+	total  float64
+}
+
+// NewBill creates a new Bill struct. Its total is the sum of the lines' amounts.
+func NewBill(billDto BillDto) (Bill, error) {
+	zoneID, err := NewZoneID(billDto.ZoneID)
+	if err != nil {
+		return Bill{}, err
+	}
+	from, err := time.Parse(time.DateOnly, billDto.From)
+	if err != nil {
+		return Bill{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Bill from value: %s", billDto.From))
+	}
+	to, err := time.Parse(time.DateOnly, billDto.To)
+	if err != nil {
+		return Bill{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Bill to value: %s", billDto.To))
+	}
+
+	var total Decimal
+	for _, line := range billDto.Lines {
+		total = total.Add(NewDecimalFromFloat(line.Amount))
+	}
+
+	return Bill{
+		zoneID: zoneID,
+		from:   from,
+		to:     to,
+		lines:  billDto.Lines,
+		total:  total.Round(2).Float64(),
+	}, nil
+}
+
+// ZoneID returns the ID of the zone whose prices the Bill was simulated with.
+func (b Bill) ZoneID() ZoneID {
+	return b.zoneID
+}
+
+// From returns the first day of the Bill, as a UTC midnight.
+func (b Bill) From() time.Time {
+	return b.from
+}
+
+// To returns the last day of the Bill, as a UTC midnight.
+func (b Bill) To() time.Time {
+	return b.to
+}
+
+// Lines returns the Bill's line items.
+func (b Bill) Lines() []BillLineDto {
+	return b.lines
+}
+
+// Total returns the Bill's total, in €.
+func (b Bill) Total() float64 {
+	return b.total
+}
+
+// Serialize returns the BillDto struct that represents the Bill.
+func (b Bill) Serialize() BillDto {
+	return BillDto{
+		ZoneID: b.zoneID.String(),
+		From:   b.from.Format(time.DateOnly),
+		To:     b.to.Format(time.DateOnly),
+		Lines:  b.lines,
+	}
+}
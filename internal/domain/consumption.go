@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// HourlyConsumptionDto is the DTO struct used to build an HourlyConsumption domain entity by calling domain.NewHourlyConsumption().
+type HourlyConsumptionDto struct {
+	Datetime string
+	Kwh      float64
+}
+
+// HourlyConsumption is the domain entity that represents the energy consumed by a supply during an hour.
+type HourlyConsumption struct {
+	datetime time.Time
+	kwh      float64
+}
+
+// NewHourlyConsumption creates a new HourlyConsumption struct.
+func NewHourlyConsumption(consumptionDto HourlyConsumptionDto) (HourlyConsumption, error) {
+	datetime, err := time.Parse(time.RFC3339, consumptionDto.Datetime)
+	if err != nil {
+		return HourlyConsumption{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing HourlyConsumption datetime value: %s", consumptionDto.Datetime))
+	}
+	if consumptionDto.Kwh < 0 {
+		return HourlyConsumption{}, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s can't be negative: %v", consumptionDto.Datetime, consumptionDto.Kwh)
+	}
+
+	return HourlyConsumption{
+		datetime: datetime,
+		kwh:      consumptionDto.Kwh,
+	}, nil
+}
+
+// Datetime returns the start of the HourlyConsumption's hour.
+func (c HourlyConsumption) Datetime() time.Time {
+	return c.datetime
+}
+
+// Kwh returns the HourlyConsumption's energy, in kWh.
+func (c HourlyConsumption) Kwh() float64 {
+	return c.kwh
+}
+
+// Serialize returns the HourlyConsumptionDto struct that represents the HourlyConsumption.
+func (c HourlyConsumption) Serialize() HourlyConsumptionDto {
+	return HourlyConsumptionDto{
+		Datetime: c.datetime.Format(time.RFC3339),
+		Kwh:      c.kwh,
+	}
+}
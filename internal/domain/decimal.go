@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// Decimal is an exact decimal number, coef × 10^-scale, so amounts like 0.1 are represented
+// without the binary rounding of float64. It holds up to 18 significant digits: results that
+// need more are rounded half away from zero. The zero value is 0.
+//
+// Decimals are always normalized, without trailing zeros, so equal numbers are equal structs.
+type Decimal struct {
+	coef  int64
+	scale int32
+}
+
+var (
+	bigTen     = big.NewInt(10)
+	bigMaxCoef = big.NewInt(math.MaxInt64)
+	bigMinCoef = big.NewInt(math.MinInt64)
+)
+
+// NewDecimal returns the Decimal coef × 10^-scale.
+func NewDecimal(coef int64, scale int32) Decimal {
+	return newDecimalFromBig(big.NewInt(coef), scale)
+}
+
+// NewDecimalFromString parses a decimal number such as "-123.45" or "1.2345e-3".
+func NewDecimalFromString(value string) (Decimal, error) {
+	mantissa, exponent := value, int64(0)
+	if i := strings.IndexAny(value, "eE"); i >= 0 {
+		var err error
+		mantissa = value[:i]
+		exponent, err = strconv.ParseInt(value[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, errors.WrapIntoDomainError(err, errors.InvalidDecimal, "invalid decimal exponent: "+value)
+		}
+	}
+
+	digits, fraction, _ := strings.Cut(mantissa, ".")
+	digits += fraction
+	unsigned := strings.TrimLeft(digits, "+-")
+	if unsigned == "" || strings.Trim(unsigned, "0123456789") != "" || len(digits)-len(unsigned) > 1 {
+		return Decimal{}, errors.NewDomainError(errors.InvalidDecimal, "invalid decimal: %s", value)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, errors.NewDomainError(errors.InvalidDecimal, "invalid decimal: %s", value)
+	}
+	return newDecimalFromBig(coef, int32(int64(len(fraction))-exponent)), nil
+}
+
+// NewDecimalFromFloat returns the shortest Decimal that converts back to value, so a float64 parsed
+// from a decimal literal (e.g. 0.1) becomes exactly that decimal. NaN and infinities become 0.
+func NewDecimalFromFloat(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}
+	}
+	d, _ := NewDecimalFromString(strconv.FormatFloat(value, 'g', -1, 64))
+	return d
+}
+
+// newDecimalFromBig returns the normalized Decimal coef × 10^-scale, rounding off the least significant
+// digits that don't fit in an int64.
+func newDecimalFromBig(coef *big.Int, scale int32) Decimal {
+	coef = new(big.Int).Set(coef)
+	for coef.Cmp(bigMaxCoef) > 0 || coef.Cmp(bigMinCoef) < 0 {
+		coef = roundBig(coef, 1)
+		scale--
+	}
+	if coef.Sign() == 0 {
+		return Decimal{}
+	}
+
+	d := Decimal{coef: coef.Int64(), scale: scale}
+	for d.coef%10 == 0 {
+		d.coef /= 10
+		d.scale--
+	}
+	return d
+}
+
+// roundBig divides coef by 10^digits, rounding half away from zero.
+func roundBig(coef *big.Int, digits int32) *big.Int {
+	divisor := new(big.Int).Exp(bigTen, big.NewInt(int64(digits)), nil)
+	quotient, remainder := new(big.Int).QuoRem(coef, divisor, new(big.Int))
+	if remainder.Abs(remainder).Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(coef.Sign())))
+	}
+	return quotient
+}
+
+// scaled returns the coefficient of d scaled to scale, which must be greater than or equal to d's scale.
+func (d Decimal) scaled(scale int32) *big.Int {
+	coef := big.NewInt(d.coef)
+	if scale > d.scale {
+		coef.Mul(coef, new(big.Int).Exp(bigTen, big.NewInt(int64(scale-d.scale)), nil))
+	}
+	return coef
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return newDecimalFromBig(new(big.Int).Add(d.scaled(scale), other.scaled(scale)), scale)
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return newDecimalFromBig(new(big.Int).Sub(d.scaled(scale), other.scaled(scale)), scale)
+}
+
+// Mul returns d × other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return newDecimalFromBig(new(big.Int).Mul(big.NewInt(d.coef), big.NewInt(other.coef)), d.scale+other.scale)
+}
+
+// Div returns d / other rounded half away from zero to the given number of decimals. Dividing by 0 returns 0.
+func (d Decimal) Div(other Decimal, decimals int32) Decimal {
+	if other.coef == 0 {
+		return Decimal{}
+	}
+	// d / other = (d.coef / other.coef) × 10^(other.scale - d.scale), computed with one extra decimal to round it.
+	shift := decimals + 1 - d.scale + other.scale
+	numerator := big.NewInt(d.coef)
+	denominator := big.NewInt(other.coef)
+	if shift >= 0 {
+		numerator.Mul(numerator, new(big.Int).Exp(bigTen, big.NewInt(int64(shift)), nil))
+	} else {
+		denominator.Mul(denominator, new(big.Int).Exp(bigTen, big.NewInt(int64(-shift)), nil))
+	}
+	quotient := new(big.Int).Quo(numerator, denominator)
+	return newDecimalFromBig(roundBig(quotient, 1), decimals)
+}
+
+// Shift returns d × 10^places, exactly.
+func (d Decimal) Shift(places int32) Decimal {
+	if d.coef == 0 {
+		return d
+	}
+	return Decimal{coef: d.coef, scale: d.scale - places}
+}
+
+// Round returns d rounded half away from zero to the given number of decimals.
+func (d Decimal) Round(decimals int32) Decimal {
+	if d.scale <= decimals {
+		return d
+	}
+	return newDecimalFromBig(roundBig(big.NewInt(d.coef), d.scale-decimals), decimals)
+}
+
+// Cmp compares d and other, returning -1 if d < other, 0 if they are equal and +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d, other)
+	return d.scaled(scale).Cmp(other.scaled(scale))
+}
+
+// IsZero returns whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.coef == 0
+}
+
+// Float64 returns the float64 closest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns d in plain decimal notation, e.g. "-0.00123".
+func (d Decimal) String() string {
+	if d.coef == 0 {
+		return "0"
+	}
+
+	digits := strconv.FormatInt(d.coef, 10)
+	sign := ""
+	if d.coef < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	if int(d.scale) >= len(digits) {
+		digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+}
+
+// MarshalJSON encodes d as a JSON number with all its digits.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes d from a JSON number or string, without going through float64.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	value := string(bytes.Trim(data, `"`))
+	if value == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := NewDecimalFromString(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
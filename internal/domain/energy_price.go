@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"strings"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// Currency is the ISO 4217 code of the currency prices are expressed in.
+type Currency string
+
+// EUR is the currency of every price published by REE.
+const EUR Currency = "EUR"
+
+// NewCurrency instantiate the VO for Currency.
+func NewCurrency(value string) (Currency, error) {
+	if Currency(strings.ToUpper(value)) != EUR {
+		return "", errors.NewDomainError(errors.InvalidPriceUnit, "invalid currency: %s. It must be %s", value, EUR)
+	}
+	return EUR, nil
+}
+
+// String converts the Currency into string.
+func (c Currency) String() string {
+	return string(c)
+}
+
+// EnergyUnit is the amount of energy prices are expressed per.
+type EnergyUnit string
+
+const (
+	// MWh is the unit REE publishes the prices in, so the one they are stored in.
+	MWh EnergyUnit = "MWh"
+	// KWh is the unit consumers are billed in.
+	KWh EnergyUnit = "kWh"
+)
+
+// NewEnergyUnit instantiate the VO for EnergyUnit. It's case insensitive.
+func NewEnergyUnit(value string) (EnergyUnit, error) {
+	switch strings.ToLower(value) {
+	case "mwh":
+		return MWh, nil
+	case "kwh":
+		return KWh, nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidPriceUnit, "invalid energy unit: %s. It must be one of: %s, %s", value, MWh, KWh)
+	}
+}
+
+// String converts the EnergyUnit into string.
+func (u EnergyUnit) String() string {
+	return string(u)
+}
+
+// kwhExponent returns the power of ten of kWh the unit is.
+func (u EnergyUnit) kwhExponent() int32 {
+	if u == MWh {
+		return 3
+	}
+	return 0
+}
+
+// EnergyPrice is the value object that represents an exact price of energy, as an amount
+// of a currency per energy unit (e.g. 123.45 EUR/MWh).
+type EnergyPrice struct {
+	amount   Decimal
+	currency Currency
+	unit     EnergyUnit
+}
+
+// NewEnergyPrice creates a new EnergyPrice struct.
+func NewEnergyPrice(amount Decimal, currency Currency, unit EnergyUnit) EnergyPrice {
+	return EnergyPrice{amount: amount, currency: currency, unit: unit}
+}
+
+// Amount returns the amount of currency paid per unit of energy.
+func (p EnergyPrice) Amount() Decimal {
+	return p.amount
+}
+
+// Currency returns the currency of the price.
+func (p EnergyPrice) Currency() Currency {
+	return p.currency
+}
+
+// Unit returns the energy unit of the price.
+func (p EnergyPrice) Unit() EnergyUnit {
+	return p.unit
+}
+
+// In returns the same price per unit, converted exactly (e.g. 123.45 EUR/MWh is 0.12345 EUR/kWh).
+func (p EnergyPrice) In(unit EnergyUnit) EnergyPrice {
+	return EnergyPrice{amount: p.amount.Shift(unit.kwhExponent() - p.unit.kwhExponent()), currency: p.currency, unit: unit}
+}
+
+// CostOf returns the exact cost of the given kWh at the price, in its currency.
+func (p EnergyPrice) CostOf(kwh Decimal) Decimal {
+	return p.In(KWh).amount.Mul(kwh)
+}
+
+// String returns the price as an amount and its units, e.g. "123.45 EUR/MWh".
+func (p EnergyPrice) String() string {
+	return p.amount.String() + " " + p.currency.String() + "/" + p.unit.String()
+}
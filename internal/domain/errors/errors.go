@@ -8,13 +8,42 @@ import (
 type ErrorCode string
 
 const (
-	InternalError    ErrorCode = "INTERNAL_ERROR"
-	InvalidPricesID  ErrorCode = "INVALID_PRICES_ID"
-	InvalidTime      ErrorCode = "INVALID_TIME"
-	InvalidZoneID    ErrorCode = "INVALID_ZONE_ID"
-	PersistenceError ErrorCode = "PERSISTENCE_ERROR"
-	ProviderError    ErrorCode = "PROVIDER_ERROR"
-	ZoneNotFound     ErrorCode = "ZONE_NOT_FOUND"
+	AppliancesPlanNotFound ErrorCode = "APPLIANCES_PLAN_NOT_FOUND"
+	BillRatesNotFound      ErrorCode = "BILL_RATES_NOT_FOUND"
+	HolidayNotFound        ErrorCode = "HOLIDAY_NOT_FOUND"
+	HouseholdNotFound      ErrorCode = "HOUSEHOLD_NOT_FOUND"
+	IndicatorNotFound      ErrorCode = "INDICATOR_NOT_FOUND"
+	InternalError          ErrorCode = "INTERNAL_ERROR"
+	InvalidAppliancesPlan  ErrorCode = "INVALID_APPLIANCES_PLAN"
+	InvalidBattery         ErrorCode = "INVALID_BATTERY"
+	InvalidBillRates       ErrorCode = "INVALID_BILL_RATES"
+	InvalidChargingPlan    ErrorCode = "INVALID_CHARGING_PLAN"
+	InvalidConsumption     ErrorCode = "INVALID_CONSUMPTION"
+	InvalidContractedPower ErrorCode = "INVALID_CONTRACTED_POWER"
+	InvalidCUPS            ErrorCode = "INVALID_CUPS"
+	InvalidDateRange       ErrorCode = "INVALID_DATE_RANGE"
+	InvalidDecimal         ErrorCode = "INVALID_DECIMAL"
+	InvalidForecastModel   ErrorCode = "INVALID_FORECAST_MODEL"
+	InvalidGranularity     ErrorCode = "INVALID_GRANULARITY"
+	InvalidHoliday         ErrorCode = "INVALID_HOLIDAY"
+	InvalidIndicatorID     ErrorCode = "INVALID_INDICATOR_ID"
+	InvalidPriceUnit       ErrorCode = "INVALID_PRICE_UNIT"
+	InvalidPricesID        ErrorCode = "INVALID_PRICES_ID"
+	InvalidPriceLevels     ErrorCode = "INVALID_PRICE_LEVELS"
+	InvalidPricesType      ErrorCode = "INVALID_PRICES_TYPE"
+	InvalidQuarantine      ErrorCode = "INVALID_QUARANTINE"
+	InvalidRequestBody     ErrorCode = "INVALID_REQUEST_BODY"
+	InvalidTime            ErrorCode = "INVALID_TIME"
+	InvalidTimeZone        ErrorCode = "INVALID_TIME_ZONE"
+	InvalidZoneID          ErrorCode = "INVALID_ZONE_ID"
+	PersistenceError       ErrorCode = "PERSISTENCE_ERROR"
+	PricesAlreadyStored    ErrorCode = "PRICES_ALREADY_STORED"
+	PricesNotFound         ErrorCode = "PRICES_NOT_FOUND"
+	ProviderError          ErrorCode = "PROVIDER_ERROR"
+	ProviderNotFound       ErrorCode = "PROVIDER_NOT_FOUND"
+	QuarantineNotFound     ErrorCode = "QUARANTINE_NOT_FOUND"
+	Unauthorized           ErrorCode = "UNAUTHORIZED"
+	ZoneNotFound           ErrorCode = "ZONE_NOT_FOUND"
 )
 
 type domainError struct {
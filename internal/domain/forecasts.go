@@ -0,0 +1,295 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// ForecastModel is the statistical model a Forecast was made with.
+type ForecastModel string
+
+const (
+	// SeasonalNaiveModel repeats the price of the same hour one week before.
+	SeasonalNaiveModel ForecastModel = "seasonal_naive"
+	// WeekdayProfileModel averages the prices of the same hour on the same day of the week.
+	WeekdayProfileModel ForecastModel = "weekday_profile"
+	// ExponentialSmoothingModel smooths the daily series of prices of each hour, weighting the recent days the most.
+	ExponentialSmoothingModel ForecastModel = "exponential_smoothing"
+)
+
+// ForecastModels are all the available forecast models.
+var ForecastModels = []ForecastModel{SeasonalNaiveModel, WeekdayProfileModel, ExponentialSmoothingModel}
+
+// NewForecastModel instantiate the VO for ForecastModel.
+func NewForecastModel(value string) (ForecastModel, error) {
+	for _, model := range ForecastModels {
+		if ForecastModel(value) == model {
+			return model, nil
+		}
+	}
+	return "", errors.NewDomainError(errors.InvalidForecastModel, "invalid forecast model: %s. It must be one of: %s, %s, %s",
+		value, SeasonalNaiveModel, WeekdayProfileModel, ExponentialSmoothingModel)
+}
+
+// String converts the ForecastModel into string.
+func (m ForecastModel) String() string {
+	return string(m)
+}
+
+// ForecastDto is the DTO struct used to build a Forecast domain entity by calling domain.NewForecast().
+type ForecastDto struct {
+	ZoneID    string
+	Date      string // YYYY-MM-DD
+	Model     string
+	CreatedAt string
+	Values    []ForecastValueDto
+}
+
+// ForecastValueDto is the DTO struct that represents the forecast price of an hour and its confidence band.
+// Used as a part of ForecastDto and only to build a Forecast domain entity.
+type ForecastValueDto struct {
+	Datetime string
+	Value    float64
+	Lower    float64
+	Upper    float64
+}
+
+// Forecast is the domain entity that represents the prices of a zone and day forecast by a model
+// before the real ones are published. Each hour comes with a confidence band.
+type Forecast struct {
+	zoneID    ZoneID
+	date      time.Time
+	model     ForecastModel
+	createdAt time.Time
+	values    []ForecastValue
+}
+
+// ForecastValue is the forecast price of an hour, in €/MWh, and the band the real price is expected to fall within.
+type ForecastValue struct {
+	datetime time.Time
+	value    float64
+	lower    float64
+	upper    float64
+}
+
+// NewForecast creates a new Forecast struct.
+func NewForecast(forecastDto ForecastDto) (Forecast, error) {
+	zoneID, err := NewZoneID(forecastDto.ZoneID)
+	if err != nil {
+		return Forecast{}, err
+	}
+	date, err := time.Parse(time.DateOnly, forecastDto.Date)
+	if err != nil {
+		return Forecast{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Forecast date value: %s", forecastDto.Date))
+	}
+	model, err := NewForecastModel(forecastDto.Model)
+	if err != nil {
+		return Forecast{}, err
+	}
+	createdAt, err := time.Parse(time.RFC3339, forecastDto.CreatedAt)
+	if err != nil {
+		return Forecast{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Forecast createdAt value: %s", forecastDto.CreatedAt))
+	}
+
+	values := make([]ForecastValue, len(forecastDto.Values))
+	for i, v := range forecastDto.Values {
+		datetime, err := time.Parse(time.RFC3339, v.Datetime)
+		if err != nil {
+			return Forecast{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing ForecastValue datetime value: %s", v.Datetime))
+		}
+		values[i] = ForecastValue{
+			datetime: datetime,
+			value:    v.Value,
+			lower:    v.Lower,
+			upper:    v.Upper,
+		}
+	}
+
+	return Forecast{
+		zoneID:    zoneID,
+		date:      date,
+		model:     model,
+		createdAt: createdAt,
+		values:    values,
+	}, nil
+}
+
+// ZoneID returns the ID of the Zone the Forecast is for.
+func (f Forecast) ZoneID() ZoneID {
+	return f.zoneID
+}
+
+// Date returns the forecast day, as a UTC midnight.
+func (f Forecast) Date() time.Time {
+	return f.date
+}
+
+// Model returns the model the Forecast was made with.
+func (f Forecast) Model() ForecastModel {
+	return f.model
+}
+
+// CreatedAt returns when the Forecast was made.
+func (f Forecast) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+// Values returns the Forecast's hourly values.
+func (f Forecast) Values() []ForecastValue {
+	return f.values
+}
+
+// Serialize returns the ForecastDto struct that represents the Forecast.
+func (f Forecast) Serialize() ForecastDto {
+	values := make([]ForecastValueDto, len(f.values))
+	for i, v := range f.values {
+		values[i] = v.Serialize()
+	}
+	return ForecastDto{
+		ZoneID:    f.zoneID.String(),
+		Date:      f.date.Format(time.DateOnly),
+		Model:     f.model.String(),
+		CreatedAt: f.createdAt.UTC().Format(time.RFC3339),
+		Values:    values,
+	}
+}
+
+// Datetime returns the start of the ForecastValue's hour.
+func (v ForecastValue) Datetime() time.Time {
+	return v.datetime
+}
+
+// Value returns the forecast price.
+func (v ForecastValue) Value() float64 {
+	return v.value
+}
+
+// Lower returns the lower bound of the confidence band.
+func (v ForecastValue) Lower() float64 {
+	return v.lower
+}
+
+// Upper returns the upper bound of the confidence band.
+func (v ForecastValue) Upper() float64 {
+	return v.upper
+}
+
+// Serialize returns the ForecastValueDto struct that represents the ForecastValue.
+func (v ForecastValue) Serialize() ForecastValueDto {
+	return ForecastValueDto{
+		Datetime: v.datetime.Format(time.RFC3339),
+		Value:    v.value,
+		Lower:    v.lower,
+		Upper:    v.upper,
+	}
+}
+
+// ForecastAccuracyDto is the DTO struct used to build a ForecastAccuracy domain entity by calling domain.NewForecastAccuracy().
+type ForecastAccuracyDto struct {
+	ZoneID     string
+	Date       string // YYYY-MM-DD
+	Model      string
+	Hours      int
+	MAE        float64
+	RMSE       float64
+	Bias       float64
+	RecordedAt string
+}
+
+// ForecastAccuracy is the domain entity that represents how close a Forecast was to the real prices,
+// once they were published: the mean absolute error, the root mean squared error and the mean error
+// (positive when the forecast was too high), in €/MWh, over the hours both have.
+type ForecastAccuracy struct {
+	zoneID     ZoneID
+	date       time.Time
+	model      ForecastModel
+	hours      int
+	mae        float64
+	rmse       float64
+	bias       float64
+	recordedAt time.Time
+}
+
+// NewForecastAccuracy creates a new ForecastAccuracy struct.
+func NewForecastAccuracy(accuracyDto ForecastAccuracyDto) (ForecastAccuracy, error) {
+	zoneID, err := NewZoneID(accuracyDto.ZoneID)
+	if err != nil {
+		return ForecastAccuracy{}, err
+	}
+	date, err := time.Parse(time.DateOnly, accuracyDto.Date)
+	if err != nil {
+		return ForecastAccuracy{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing ForecastAccuracy date value: %s", accuracyDto.Date))
+	}
+	model, err := NewForecastModel(accuracyDto.Model)
+	if err != nil {
+		return ForecastAccuracy{}, err
+	}
+	recordedAt, err := time.Parse(time.RFC3339, accuracyDto.RecordedAt)
+	if err != nil {
+		return ForecastAccuracy{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing ForecastAccuracy recordedAt value: %s", accuracyDto.RecordedAt))
+	}
+
+	return ForecastAccuracy{
+		zoneID:     zoneID,
+		date:       date,
+		model:      model,
+		hours:      accuracyDto.Hours,
+		mae:        accuracyDto.MAE,
+		rmse:       accuracyDto.RMSE,
+		bias:       accuracyDto.Bias,
+		recordedAt: recordedAt,
+	}, nil
+}
+
+// ZoneID returns the ID of the Zone of the measured Forecast.
+func (a ForecastAccuracy) ZoneID() ZoneID {
+	return a.zoneID
+}
+
+// Date returns the day of the measured Forecast, as a UTC midnight.
+func (a ForecastAccuracy) Date() time.Time {
+	return a.date
+}
+
+// Model returns the model of the measured Forecast.
+func (a ForecastAccuracy) Model() ForecastModel {
+	return a.model
+}
+
+// RecordedAt returns when the ForecastAccuracy was measured.
+func (a ForecastAccuracy) RecordedAt() time.Time {
+	return a.recordedAt
+}
+
+// Serialize returns the ForecastAccuracyDto struct that represents the ForecastAccuracy.
+func (a ForecastAccuracy) Serialize() ForecastAccuracyDto {
+	return ForecastAccuracyDto{
+		ZoneID:     a.zoneID.String(),
+		Date:       a.date.Format(time.DateOnly),
+		Model:      a.model.String(),
+		Hours:      a.hours,
+		MAE:        a.mae,
+		RMSE:       a.rmse,
+		Bias:       a.bias,
+		RecordedAt: a.recordedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ForecastsRepository defines the expected behavior from a price forecasts storage.
+type ForecastsRepository interface {
+	// Save persists the given forecast, replacing the stored one of the same zone, day and model.
+	Save(ctx context.Context, forecast Forecast) error
+
+	// Query returns the stored forecasts of the given zone and day (a UTC midnight), sorted by model.
+	Query(ctx context.Context, zoneID ZoneID, date time.Time) ([]Forecast, error)
+
+	// SaveAccuracy persists the given accuracy, replacing the stored one of the same zone, day and model.
+	SaveAccuracy(ctx context.Context, accuracy ForecastAccuracy) error
+
+	// ListAccuracy returns the stored accuracies of the given zone from from to to (both UTC midnights, included),
+	// sorted by day and model.
+	ListAccuracy(ctx context.Context, zoneID ZoneID, from, to time.Time) ([]ForecastAccuracy, error)
+}
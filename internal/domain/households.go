@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// cupsRegexp matches a CUPS: ES, 16 digits, 2 control letters and an optional 2 characters border point suffix (e.g. 0F).
+var cupsRegexp = regexp.MustCompile(`^ES[0-9]{16}[A-Z]{2}([0-9][A-Z])?$`)
+
+// CUPS (Código Universal del Punto de Suministro) represents the unique identifier of an electricity supply point.
+type CUPS struct {
+	value string
+}
+
+// NewCUPS instantiate the VO for CUPS. The value is uppercased and trimmed.
+func NewCUPS(value string) (CUPS, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	if !cupsRegexp.MatchString(normalized) {
+		return CUPS{}, errors.NewDomainError(errors.InvalidCUPS, "invalid CUPS: %s. It must be ES followed by 16 digits and 2 letters", value)
+	}
+
+	return CUPS{
+		value: normalized,
+	}, nil
+}
+
+// String converts the CUPS into string.
+func (c CUPS) String() string {
+	return c.value
+}
+
+// HouseholdDto is the DTO struct used to build a Household domain entity by calling domain.NewHousehold().
+type HouseholdDto struct {
+	CUPS   string
+	ZoneID string
+}
+
+// Household is the domain entity that represents a supply point whose consumption is stored,
+// along with the prices zone it is billed in.
+type Household struct {
+	cups   CUPS
+	zoneID ZoneID
+}
+
+// NewHousehold creates a new Household struct.
+func NewHousehold(householdDto HouseholdDto) (Household, error) {
+	cups, err := NewCUPS(householdDto.CUPS)
+	if err != nil {
+		return Household{}, err
+	}
+	zoneID, err := NewZoneID(householdDto.ZoneID)
+	if err != nil {
+		return Household{}, err
+	}
+
+	return Household{
+		cups:   cups,
+		zoneID: zoneID,
+	}, nil
+}
+
+// CUPS returns the Household's supply point identifier.
+func (h Household) CUPS() CUPS {
+	return h.cups
+}
+
+// ZoneID returns the ID of the Household's prices zone.
+func (h Household) ZoneID() ZoneID {
+	return h.zoneID
+}
+
+// Serialize returns the HouseholdDto struct that represents the Household.
+func (h Household) Serialize() HouseholdDto {
+	return HouseholdDto{
+		CUPS:   h.cups.String(),
+		ZoneID: h.zoneID.String(),
+	}
+}
+
+// ConsumptionReadingDto is an hourly consumption reading as exported by distributors. Hours are numbered
+// from 1 to 24 within the local day, the first one being 00:00-01:00. On DST change days there are 23 or 25 hours.
+type ConsumptionReadingDto struct {
+	CUPS string
+	Date string // YYYY-MM-DD
+	Hour int
+	Kwh  float64
+}
+
+// HouseholdsRepository defines the expected behavior from a households consumption storage.
+type HouseholdsRepository interface {
+	// Save persists the household and the given consumption, replacing the stored household's zone
+	// and the stored consumption of the same hours.
+	Save(ctx context.Context, household Household, consumption []HourlyConsumption) error
+
+	// Get returns the household with the given CUPS.
+	// It returns a HouseholdNotFound error if there is no such household.
+	Get(ctx context.Context, cups CUPS) (Household, error)
+
+	// QueryConsumption returns the household's consumption from from (included) to to (excluded), sorted by datetime.
+	// It returns a HouseholdNotFound error if there is no such household.
+	QueryConsumption(ctx context.Context, cups CUPS, from, to time.Time) ([]HourlyConsumption, error)
+}
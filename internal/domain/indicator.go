@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// IndicatorDto is the main DTO struct used to build an Indicator domain entity by calling domain.NewIndicator().
+type IndicatorDto struct {
+	ID     string
+	Name   string
+	Values []IndicatorValueDto
+}
+
+// IndicatorValueDto is the DTO struct that represents the value of an indicator at a specific datetime and geo.
+// Used as a part of IndicatorDto and only to build an Indicator domain entity.
+type IndicatorValueDto struct {
+	Datetime string
+	GeoID    string
+	GeoName  string
+	Value    float64
+}
+
+// Indicator is the domain entity that represents an Esios time-series indicator
+// (spot price, generation mix, demand...) along with some of its values.
+type Indicator struct {
+	id     IndicatorID
+	name   string
+	values []IndicatorValue
+}
+
+// IndicatorValue is the domain entity that represents the value of an Indicator at a specific
+// datetime and geo. Indicators that are not disaggregated by geo have a single geo for all their values.
+type IndicatorValue struct {
+	datetime time.Time
+	geoID    string
+	geoName  string
+	value    float64
+}
+
+// IndicatorID represents the Indicator's unique identifier, the Esios indicator number.
+type IndicatorID struct {
+	value string
+}
+
+// NewIndicatorID instantiate the VO for IndicatorID.
+func NewIndicatorID(value string) (IndicatorID, error) {
+	if !regexp.MustCompile(`^[1-9]\d{0,5}$`).MatchString(value) {
+		return IndicatorID{}, errors.NewDomainError(errors.InvalidIndicatorID, "invalid Indicator ID: %s. It must be a positive number", value)
+	}
+
+	return IndicatorID{
+		value: value,
+	}, nil
+}
+
+// String converts the IndicatorID into string.
+func (id IndicatorID) String() string {
+	return id.value
+}
+
+// IndicatorsRepository defines the expected behavior from an indicators storage.
+type IndicatorsRepository interface {
+	// Save persists the given indicator and its values, replacing the stored values
+	// of the indicator for the same datetime and geo.
+	Save(ctx context.Context, indicator Indicator) error
+
+	// Query returns the indicator with its values from (included) to (excluded), sorted by datetime and geo.
+	//
+	// If geoID is nil, it returns the values for all geos.
+	//
+	// It fails with an IndicatorNotFound error if the indicator was never stored.
+	Query(ctx context.Context, id IndicatorID, geoID *string, from, to time.Time) (Indicator, error)
+}
+
+// IndicatorsProvider defines the expected behavior from an indicators provider.
+// At the end, it's an adapter over the Esios API.
+type IndicatorsProvider interface {
+	// FetchIndicator fetches the values of the indicator for the given date.
+	// If the geoIDs slice is empty or nil, it returns the values for all geos.
+	FetchIndicator(ctx context.Context, id IndicatorID, geoIDs []string, date time.Time) (Indicator, error)
+}
+
+// NewIndicator creates a new Indicator struct.
+func NewIndicator(indicatorDto IndicatorDto) (Indicator, error) {
+	idVO, err := NewIndicatorID(indicatorDto.ID)
+	if err != nil {
+		return Indicator{}, err
+	}
+
+	values := make([]IndicatorValue, len(indicatorDto.Values))
+	for i, v := range indicatorDto.Values {
+		datetime, err := time.Parse(time.RFC3339, v.Datetime)
+		if err != nil {
+			return Indicator{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing IndicatorValue datetime value: %s", v.Datetime))
+		}
+		values[i] = IndicatorValue{
+			datetime: datetime,
+			geoID:    v.GeoID,
+			geoName:  v.GeoName,
+			value:    v.Value,
+		}
+	}
+
+	indicator := Indicator{
+		id:     idVO,
+		name:   indicatorDto.Name,
+		values: values,
+	}
+
+	return indicator, nil
+}
+
+// ID returns the Indicator's unique identifier.
+func (i Indicator) ID() IndicatorID {
+	return i.id
+}
+
+// Name returns the Indicator's name.
+func (i Indicator) Name() string {
+	return i.name
+}
+
+// Values returns the Indicator's values.
+func (i Indicator) Values() []IndicatorValue {
+	return i.values
+}
+
+// Datetime returns the IndicatorValue's datetime.
+func (v IndicatorValue) Datetime() time.Time {
+	return v.datetime
+}
+
+// GeoID returns the ID of the geo the IndicatorValue belongs to.
+func (v IndicatorValue) GeoID() string {
+	return v.geoID
+}
+
+// GeoName returns the name of the geo the IndicatorValue belongs to.
+func (v IndicatorValue) GeoName() string {
+	return v.geoName
+}
+
+// Value returns the IndicatorValue's value.
+func (v IndicatorValue) Value() float64 {
+	return v.value
+}
+
+// Serialize returns the IndicatorDto struct that represents the Indicator.
+func (i Indicator) Serialize() IndicatorDto {
+	values := make([]IndicatorValueDto, len(i.values))
+	for j, v := range i.values {
+		values[j] = IndicatorValueDto{
+			Datetime: v.datetime.Format(time.RFC3339),
+			GeoID:    v.geoID,
+			GeoName:  v.geoName,
+			Value:    v.value,
+		}
+	}
+
+	return IndicatorDto{
+		ID:     i.id.String(),
+		Name:   i.name,
+		Values: values,
+	}
+}
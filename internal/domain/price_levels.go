@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"math"
+	"sort"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// PriceLevel is how cheap an hour's price is compared to a baseline of prices.
+type PriceLevel string
+
+const (
+	// CheapPriceLevel is a price below the baseline's lower bound.
+	CheapPriceLevel PriceLevel = "cheap"
+	// NormalPriceLevel is a price between the baseline's bounds.
+	NormalPriceLevel PriceLevel = "normal"
+	// ExpensivePriceLevel is a price above the baseline's upper bound.
+	ExpensivePriceLevel PriceLevel = "expensive"
+)
+
+// NewPriceLevel instantiate the VO for PriceLevel.
+func NewPriceLevel(value string) (PriceLevel, error) {
+	switch PriceLevel(value) {
+	case CheapPriceLevel, NormalPriceLevel, ExpensivePriceLevel:
+		return PriceLevel(value), nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidPriceLevels, "invalid price level: %s. It must be one of: %s, %s, %s", value, CheapPriceLevel, NormalPriceLevel, ExpensivePriceLevel)
+	}
+}
+
+// String converts the PriceLevel into string.
+func (l PriceLevel) String() string {
+	return string(l)
+}
+
+// PriceLevelStrategy is how the bounds between price levels are set.
+type PriceLevelStrategy string
+
+const (
+	// TercilesStrategy splits the baseline prices in three groups of the same size.
+	TercilesStrategy PriceLevelStrategy = "terciles"
+	// ThresholdsStrategy uses fixed prices as bounds, regardless of the baseline.
+	ThresholdsStrategy PriceLevelStrategy = "thresholds"
+	// DeviationStrategy sets the bounds at a share below and above the baseline's mean price.
+	DeviationStrategy PriceLevelStrategy = "deviation"
+)
+
+// PriceLevelBaseline is the set of prices an hour's price is compared to.
+type PriceLevelBaseline string
+
+const (
+	// DayBaseline are the prices of the same day.
+	DayBaseline PriceLevelBaseline = "day"
+	// Rolling30DaysBaseline are the prices of the 30 days ending on the same day.
+	Rolling30DaysBaseline PriceLevelBaseline = "30d"
+)
+
+// defaultPriceLevelDeviation is the share of the mean price used by the DeviationStrategy when none is given.
+const defaultPriceLevelDeviation = 0.1
+
+// PriceLevelsDto is the DTO struct used to build a PriceLevels value object by calling domain.NewPriceLevels().
+// Empty values are the defaults: terciles of the day's prices and, for the deviation strategy, a 10% deviation.
+type PriceLevelsDto struct {
+	Strategy       string
+	Baseline       string
+	CheapBelow     *float64 // €/MWh, only for the thresholds strategy
+	ExpensiveAbove *float64 // €/MWh, only for the thresholds strategy
+	Deviation      float64  // share of the mean, only for the deviation strategy
+}
+
+// PriceLevels is the value object that classifies hourly prices into levels with a strategy and a baseline.
+type PriceLevels struct {
+	strategy       PriceLevelStrategy
+	baseline       PriceLevelBaseline
+	cheapBelow     float64
+	expensiveAbove float64
+	deviation      float64
+}
+
+// NewPriceLevels creates a new PriceLevels struct.
+func NewPriceLevels(levelsDto PriceLevelsDto) (PriceLevels, error) {
+	levels := PriceLevels{strategy: TercilesStrategy, baseline: DayBaseline}
+
+	switch PriceLevelBaseline(levelsDto.Baseline) {
+	case "", DayBaseline:
+	case Rolling30DaysBaseline:
+		levels.baseline = Rolling30DaysBaseline
+	default:
+		return PriceLevels{}, errors.NewDomainError(errors.InvalidPriceLevels, "invalid price levels baseline: %s. It must be one of: %s, %s", levelsDto.Baseline, DayBaseline, Rolling30DaysBaseline)
+	}
+
+	switch PriceLevelStrategy(levelsDto.Strategy) {
+	case "", TercilesStrategy:
+	case ThresholdsStrategy:
+		if levelsDto.CheapBelow == nil || levelsDto.ExpensiveAbove == nil {
+			return PriceLevels{}, errors.NewDomainError(errors.InvalidPriceLevels, "the %s price levels strategy needs both the cheap and the expensive thresholds", ThresholdsStrategy)
+		}
+		if *levelsDto.CheapBelow > *levelsDto.ExpensiveAbove {
+			return PriceLevels{}, errors.NewDomainError(errors.InvalidPriceLevels, "the cheap threshold %v can't be above the expensive one %v", *levelsDto.CheapBelow, *levelsDto.ExpensiveAbove)
+		}
+		levels.strategy = ThresholdsStrategy
+		levels.cheapBelow, levels.expensiveAbove = *levelsDto.CheapBelow, *levelsDto.ExpensiveAbove
+	case DeviationStrategy:
+		if levelsDto.Deviation < 0 || levelsDto.Deviation >= 1 {
+			return PriceLevels{}, errors.NewDomainError(errors.InvalidPriceLevels, "the price levels deviation must be from 0 to 1: %v", levelsDto.Deviation)
+		}
+		levels.strategy = DeviationStrategy
+		levels.deviation = levelsDto.Deviation
+		if levels.deviation == 0 {
+			levels.deviation = defaultPriceLevelDeviation
+		}
+	default:
+		return PriceLevels{}, errors.NewDomainError(errors.InvalidPriceLevels, "invalid price levels strategy: %s. It must be one of: %s, %s, %s",
+			levelsDto.Strategy, TercilesStrategy, ThresholdsStrategy, DeviationStrategy)
+	}
+
+	return levels, nil
+}
+
+// Strategy returns how the PriceLevels bounds are set.
+func (l PriceLevels) Strategy() PriceLevelStrategy {
+	return l.strategy
+}
+
+// Baseline returns the prices the PriceLevels compare each hour to.
+func (l PriceLevels) Baseline() PriceLevelBaseline {
+	return l.baseline
+}
+
+// Classifier returns the function that classifies prices compared to the baseline prices.
+// The bounds are computed once, so it can be used for every hour with the same baseline.
+func (l PriceLevels) Classifier(baseline []float64) func(price float64) PriceLevel {
+	cheapBelow, expensiveAbove := l.bounds(baseline)
+	return func(price float64) PriceLevel {
+		switch {
+		case price < cheapBelow:
+			return CheapPriceLevel
+		case price > expensiveAbove:
+			return ExpensivePriceLevel
+		default:
+			return NormalPriceLevel
+		}
+	}
+}
+
+// bounds returns the prices below which an hour is cheap and above which it is expensive.
+// Without baseline prices, every hour is normal, unless the bounds are fixed thresholds.
+func (l PriceLevels) bounds(baseline []float64) (float64, float64) {
+	if l.strategy == ThresholdsStrategy {
+		return l.cheapBelow, l.expensiveAbove
+	}
+	if len(baseline) == 0 {
+		return math.Inf(-1), math.Inf(1)
+	}
+
+	if l.strategy == DeviationStrategy {
+		var sum float64
+		for _, price := range baseline {
+			sum += price
+		}
+		mean := sum / float64(len(baseline))
+		return mean * (1 - l.deviation), mean * (1 + l.deviation)
+	}
+
+	sorted := make([]float64, len(baseline))
+	copy(sorted, baseline)
+	sort.Float64s(sorted)
+	return quantile(sorted, 1.0/3), quantile(sorted, 2.0/3)
+}
+
+// quantile returns the q quantile of the sorted values, linearly interpolated between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	rank := q * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
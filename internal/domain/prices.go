@@ -11,25 +11,59 @@ import (
 
 // PricesDto is the main DTO struct used to build a Prices domain entity by calling domain.NewPrices().
 type PricesDto struct {
-	ID     string
-	Date   string
-	Zone   ZoneDto
-	Values []HourlyPriceDto
+	ID         string
+	Type       string
+	Date       string
+	Zone       ZoneDto
+	Values     []HourlyPriceDto
+	Provenance *ProvenanceDto
 }
 
-// HourlyPriceDto is the DTO struct that represents a PVPC price for a specific hour.
+// ProvenanceDto is the DTO struct that represents where a Prices was fetched from.
+// Used as a part of PricesDto and only to build a Prices domain entity.
+type ProvenanceDto struct {
+	Provider  string
+	FetchedAt string
+	Source    string
+}
+
+// HourlyPriceDto is the DTO struct that represents a PVPC price for a specific hour, in €/MWh.
 // Used as a part of PricesDto and only to build a Prices domain entity.
 type HourlyPriceDto struct {
 	Datetime string
 	Value    float64
+	Amount   string // the exact decimal Value, when it has more digits than a float64. If set, Value is ignored.
+}
+
+// PricesRevisionDto is the DTO struct used to build a PricesRevision domain entity by calling domain.NewPricesRevision().
+type PricesRevisionDto struct {
+	Prices    PricesDto
+	RevisedAt string
 }
 
-// Prices is the domain entity that represents PVPC prices for a day.
+// Prices is the domain entity that represents the prices of a PricesType for a day.
 type Prices struct {
-	id     PricesID
-	date   time.Time
-	zone   Zone
-	values []HourlyPrice
+	id         PricesID
+	pricesType PricesType
+	date       time.Time
+	zone       Zone
+	values     []HourlyPrice
+	provenance *Provenance
+}
+
+// Provenance is the value object that records where a Prices was fetched from:
+// the provider name, when it was fetched and the upstream request (indicator, query...) used.
+type Provenance struct {
+	provider  string
+	fetchedAt time.Time
+	source    string
+}
+
+// PricesRevision is the domain entity that represents a previous version of a Prices,
+// replaced by a newer one (e.g. REE republished corrected values) at revisedAt.
+type PricesRevision struct {
+	prices    Prices
+	revisedAt time.Time
 }
 
 // HourlyPrice is the domain entity that represents a PVPC price for a specific hour.
@@ -37,7 +71,39 @@ type Prices struct {
 // which is linked to the parent Prices entity.
 type HourlyPrice struct {
 	datetime time.Time
-	value    float64
+	price    EnergyPrice
+}
+
+// PricesType is the kind of price a Prices holds. Prices of different types
+// share their IDs, as they are identified by zone and day, but are stored apart.
+type PricesType string
+
+const (
+	// PVPCPrices are the PVPC prices paid for the consumed energy. It's the default type.
+	PVPCPrices PricesType = "pvpc"
+	// SurplusPrices are the prices paid for the surplus energy of self-consumption
+	// installations (e.g. rooftop solar) under the simplified compensation mechanism.
+	SurplusPrices PricesType = "surplus"
+	// SpotPrices are the day-ahead market (spot) prices, the wholesale ones the PVPC energy term follows.
+	// They aren't a regulated price, so they are kept apart from the PricesRepository ones.
+	SpotPrices PricesType = "spot"
+)
+
+// NewPricesType instantiate the VO for PricesType. An empty value is the default PVPCPrices type.
+func NewPricesType(value string) (PricesType, error) {
+	switch PricesType(value) {
+	case "", PVPCPrices:
+		return PVPCPrices, nil
+	case SurplusPrices, SpotPrices:
+		return PricesType(value), nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidPricesType, "invalid Prices type: %s. It must be one of: %s, %s, %s", value, PVPCPrices, SurplusPrices, SpotPrices)
+	}
+}
+
+// String converts the PricesType into string.
+func (t PricesType) String() string {
+	return string(t)
 }
 
 // PricesID represents the Prices' unique identifier.
@@ -67,18 +133,50 @@ func (id PricesID) String() string {
 	return id.value
 }
 
+// ZoneID returns the ID of the Zone the prices identified by PricesID belong to.
+func (id PricesID) ZoneID() ZoneID {
+	return ZoneID{value: id.value[:3]}
+}
+
+// Date returns the day of the prices identified by PricesID, as a UTC midnight.
+func (id PricesID) Date() (time.Time, error) {
+	date, err := time.Parse("2006-01-02", id.value[4:])
+	if err != nil {
+		return time.Time{}, errors.WrapIntoDomainError(err, errors.InvalidPricesID, fmt.Sprintf("invalid Prices ID date: %s", id.value))
+	}
+	return date, nil
+}
+
 // PricesRepository defines the expected behavior from a prices storage.
 type PricesRepository interface {
-	// Save persists the given prices.
+	// Save persists the given prices, each one along with the ones of its type.
 	Save(ctx context.Context, prices []Prices) error
 
-	// Query returns the prices for the given date and zoneID.
+	// Upsert persists the given PVPC prices, replacing the stored ones with the same ID.
+	Upsert(ctx context.Context, prices []Prices) error
+
+	// Query returns the PVPC prices for the given date and zoneID.
 	//
 	// If zoneID is nil, it returns the prices for all zones.
 	//
 	// If date is nil, it returns the most up to date prices for the given zoneID,
 	// that can be today's or tomorrow's prices.
 	Query(ctx context.Context, zoneID *ZoneID, date *time.Time) ([]Prices, error)
+
+	// QueryByType returns the prices of the given type, with the same semantics as Query.
+	QueryByType(ctx context.Context, pricesType PricesType, zoneID *ZoneID, date *time.Time) ([]Prices, error)
+
+	// QueryRange returns the PVPC prices of zoneID from from to to, both days included,
+	// sorted from the oldest day. Days without stored prices are left out.
+	QueryRange(ctx context.Context, zoneID ZoneID, from, to time.Time) ([]Prices, error)
+
+	// ListRevisions returns the previous versions of the prices with the given ID,
+	// replaced by Upsert, from the oldest to the newest one.
+	ListRevisions(ctx context.Context, id PricesID) ([]PricesRevision, error)
+
+	// Aggregate returns the statistics of the PVPC prices of zoneID from from to to, both days included,
+	// by periods of granularity sorted from the oldest one. Periods without stored prices are left out.
+	Aggregate(ctx context.Context, zoneID ZoneID, granularity AggregateGranularity, from, to time.Time) ([]PricesAggregate, error)
 }
 
 // PricesProvider defines the expected behavior from a prices provider.
@@ -89,6 +187,21 @@ type PricesProvider interface {
 	FetchPVPCPrices(ctx context.Context, zones []Zone, date time.Time) ([]Prices, error)
 }
 
+// SurplusPricesProvider defines the expected behavior from a surplus compensation prices provider.
+// It's optional for a PricesProvider, as not every REE API publishes them.
+type SurplusPricesProvider interface {
+	// FetchSurplusPrices fetches the surplus compensation prices for the given zones and date.
+	// If the zones slice is empty or nil, it returns nil.
+	FetchSurplusPrices(ctx context.Context, zones []Zone, date time.Time) ([]Prices, error)
+}
+
+// NamedPricesProvider defines a PricesProvider that can tell its name, the same one it sets in
+// the provenance of the prices it fetches. It's optional, only used to identify the provider in logs.
+type NamedPricesProvider interface {
+	// Name returns the name of the provider, e.g. esios.
+	Name() string
+}
+
 // NewPrices creates a new Prices struct.
 func NewPrices(pricesDto PricesDto) (Prices, error) {
 	idVO, err := NewPricesID(pricesDto.ID)
@@ -96,6 +209,11 @@ func NewPrices(pricesDto PricesDto) (Prices, error) {
 		return Prices{}, err
 	}
 
+	pricesType, err := NewPricesType(pricesDto.Type)
+	if err != nil {
+		return Prices{}, err
+	}
+
 	zone, err := NewZone(pricesDto.Zone)
 	if err != nil {
 		return Prices{}, err
@@ -107,9 +225,16 @@ func NewPrices(pricesDto PricesDto) (Prices, error) {
 		if err != nil {
 			return Prices{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing HourlyPrice datetime value: %s", v.Datetime))
 		}
+		amount := NewDecimalFromFloat(v.Value)
+		if v.Amount != "" {
+			amount, err = NewDecimalFromString(v.Amount)
+			if err != nil {
+				return Prices{}, err
+			}
+		}
 		pricesValues[i] = HourlyPrice{
 			datetime: datetime,
-			value:    v.Value,
+			price:    NewEnergyPrice(amount, EUR, MWh),
 		}
 	}
 
@@ -118,21 +243,77 @@ func NewPrices(pricesDto PricesDto) (Prices, error) {
 		return Prices{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Prices date value: %s", pricesDto.Date))
 	}
 
+	var provenance *Provenance
+	if pricesDto.Provenance != nil {
+		fetchedAt, err := time.Parse(time.RFC3339, pricesDto.Provenance.FetchedAt)
+		if err != nil {
+			return Prices{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Provenance fetchedAt value: %s", pricesDto.Provenance.FetchedAt))
+		}
+		provenance = &Provenance{
+			provider:  pricesDto.Provenance.Provider,
+			fetchedAt: fetchedAt,
+			source:    pricesDto.Provenance.Source,
+		}
+	}
+
 	prices := Prices{
-		id:     idVO,
-		date:   date,
-		zone:   zone,
-		values: pricesValues,
+		id:         idVO,
+		pricesType: pricesType,
+		date:       date,
+		zone:       zone,
+		values:     pricesValues,
+		provenance: provenance,
 	}
 
 	return prices, nil
 }
 
+// NewPricesRevision creates a new PricesRevision struct.
+func NewPricesRevision(revisionDto PricesRevisionDto) (PricesRevision, error) {
+	prices, err := NewPrices(revisionDto.Prices)
+	if err != nil {
+		return PricesRevision{}, err
+	}
+
+	revisedAt, err := time.Parse(time.RFC3339, revisionDto.RevisedAt)
+	if err != nil {
+		return PricesRevision{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing PricesRevision revisedAt value: %s", revisionDto.RevisedAt))
+	}
+
+	return PricesRevision{
+		prices:    prices,
+		revisedAt: revisedAt,
+	}, nil
+}
+
+// Prices returns the Prices as they were before being revised.
+func (r PricesRevision) Prices() Prices {
+	return r.prices
+}
+
+// RevisedAt returns when the Prices were replaced by a newer version.
+func (r PricesRevision) RevisedAt() time.Time {
+	return r.revisedAt
+}
+
+// Serialize returns the PricesRevisionDto struct that represents the PricesRevision.
+func (r PricesRevision) Serialize() PricesRevisionDto {
+	return PricesRevisionDto{
+		Prices:    r.prices.Serialize(),
+		RevisedAt: r.revisedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 // ID returns the Prices' unique identifier.
 func (c Prices) ID() PricesID {
 	return c.id
 }
 
+// Type returns the Prices' type.
+func (c Prices) Type() PricesType {
+	return c.pricesType
+}
+
 // Date returns the Prices' date.
 func (c Prices) Date() time.Time {
 	return c.date
@@ -148,30 +329,76 @@ func (c Prices) Values() []HourlyPrice {
 	return c.values
 }
 
+// Provenance returns where the Prices were fetched from, or nil if it is unknown
+// (e.g. prices stored before provenance was tracked).
+func (c Prices) Provenance() *Provenance {
+	return c.provenance
+}
+
+// Provider returns the name of the provider the Prices were fetched from.
+func (p Provenance) Provider() string {
+	return p.provider
+}
+
+// FetchedAt returns when the Prices were fetched from the provider.
+func (p Provenance) FetchedAt() time.Time {
+	return p.fetchedAt
+}
+
+// Source returns the upstream request used to fetch the Prices.
+func (p Provenance) Source() string {
+	return p.source
+}
+
+// Serialize returns the ProvenanceDto struct that represents the Provenance.
+func (p Provenance) Serialize() ProvenanceDto {
+	return ProvenanceDto{
+		Provider:  p.provider,
+		FetchedAt: p.fetchedAt.UTC().Format(time.RFC3339),
+		Source:    p.source,
+	}
+}
+
 // Datetime returns the HourlyPrice's datetime.
 func (p HourlyPrice) Datetime() time.Time {
 	return p.datetime
 }
 
-// Value returns the HourlyPrice's value.
+// Value returns the HourlyPrice's value in €/MWh.
 func (p HourlyPrice) Value() float64 {
-	return p.value
+	return p.price.Amount().Float64()
 }
 
-// Serialize returns the PricesDto struct that represents the Prices.
+// Price returns the HourlyPrice's exact price, in €/MWh.
+func (p HourlyPrice) Price() EnergyPrice {
+	return p.price
+}
+
+// Serialize returns the PricesDto struct that represents the Prices, with datetimes in the local time of its zone.
 func (c Prices) Serialize() PricesDto {
 	values := make([]HourlyPriceDto, len(c.values))
 	for i, v := range c.values {
 		values[i] = HourlyPriceDto{
-			Datetime: v.datetime.Format(time.RFC3339),
-			Value:    v.value,
+			Datetime: v.datetime.In(c.zone.Location()).Format(time.RFC3339),
+			Value:    v.Value(),
+		}
+		if amount := v.price.Amount(); NewDecimalFromFloat(values[i].Value) != amount {
+			values[i].Amount = amount.String()
 		}
 	}
 
+	var provenance *ProvenanceDto
+	if c.provenance != nil {
+		dto := c.provenance.Serialize()
+		provenance = &dto
+	}
+
 	return PricesDto{
-		ID:     c.id.String(),
-		Date:   c.date.Format("2006-01-02"),
-		Zone:   c.zone.Serialize(),
-		Values: values,
+		ID:         c.id.String(),
+		Type:       c.pricesType.String(),
+		Date:       c.date.Format("2006-01-02"),
+		Zone:       c.zone.Serialize(),
+		Values:     values,
+		Provenance: provenance,
 	}
 }
@@ -0,0 +1,237 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// AggregateGranularity is the length of the periods prices are aggregated by.
+type AggregateGranularity string
+
+const (
+	// WeekGranularity aggregates prices by ISO weeks, from Monday to Sunday.
+	WeekGranularity AggregateGranularity = "week"
+	// MonthGranularity aggregates prices by calendar months.
+	MonthGranularity AggregateGranularity = "month"
+	// YearGranularity aggregates prices by calendar years.
+	YearGranularity AggregateGranularity = "year"
+)
+
+// NewAggregateGranularity instantiate the VO for AggregateGranularity.
+func NewAggregateGranularity(value string) (AggregateGranularity, error) {
+	switch AggregateGranularity(value) {
+	case WeekGranularity, MonthGranularity, YearGranularity:
+		return AggregateGranularity(value), nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidGranularity, "invalid aggregate granularity: %s. It must be one of: %s, %s, %s",
+			value, WeekGranularity, MonthGranularity, YearGranularity)
+	}
+}
+
+// String converts the AggregateGranularity into string.
+func (g AggregateGranularity) String() string {
+	return string(g)
+}
+
+// PeriodStart returns the first day of the period of day.
+func (g AggregateGranularity) PeriodStart(day time.Time) time.Time {
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	switch g {
+	case WeekGranularity:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case MonthGranularity:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// PreviousYear returns the day one year before day to compare its period with: for weeks,
+// the same day of the week 52 weeks before and, otherwise, the same day of the previous year.
+func (g AggregateGranularity) PreviousYear(day time.Time) time.Time {
+	if g == WeekGranularity {
+		return day.AddDate(0, 0, -364)
+	}
+	return day.AddDate(-1, 0, 0)
+}
+
+// IsPeakHour returns whether the hour starting at datetime is a peak hour as traded in the
+// electricity markets: from 08:00 to 20:00 of Monday to Friday, in the datetime's own time zone.
+// The rest of the hours are off-peak. It isn't the 2.0TD tariff split of TariffPeriodOf, which
+// depends on the holidays and the zone: it is the market's one, the same every day and zone.
+func IsPeakHour(datetime time.Time) bool {
+	if datetime.Weekday() == time.Saturday || datetime.Weekday() == time.Sunday {
+		return false
+	}
+	return datetime.Hour() >= 8 && datetime.Hour() < 20
+}
+
+// PricesDaySummary is the summary of the hourly prices of a zone and day, kept by the repositories
+// to aggregate prices without reading every hourly price again.
+type PricesDaySummary struct {
+	zoneID    ZoneID
+	date      time.Time
+	hours     int
+	min       float64
+	max       float64
+	sum       float64
+	peakHours int
+	peakSum   float64
+}
+
+// NewPricesDaySummary summarizes the hourly values of prices. Peak hours are the ones of the local time of
+// the prices' zone, whatever the offset the provider returned the datetimes with.
+func NewPricesDaySummary(prices Prices) PricesDaySummary {
+	summary := PricesDaySummary{
+		zoneID: prices.Zone().ID(),
+		date:   time.Date(prices.Date().Year(), prices.Date().Month(), prices.Date().Day(), 0, 0, 0, 0, time.UTC),
+	}
+	for i, value := range prices.Values() {
+		if i == 0 || value.Value() < summary.min {
+			summary.min = value.Value()
+		}
+		if i == 0 || value.Value() > summary.max {
+			summary.max = value.Value()
+		}
+		summary.hours++
+		summary.sum += value.Value()
+		if IsPeakHour(value.Datetime().In(prices.Zone().Location())) {
+			summary.peakHours++
+			summary.peakSum += value.Value()
+		}
+	}
+	return summary
+}
+
+// ZoneID returns the zone of the summarized prices.
+func (s PricesDaySummary) ZoneID() ZoneID {
+	return s.zoneID
+}
+
+// Date returns the day of the summarized prices, at 00:00 UTC.
+func (s PricesDaySummary) Date() time.Time {
+	return s.date
+}
+
+// Hours returns the number of hourly prices of the day.
+func (s PricesDaySummary) Hours() int {
+	return s.hours
+}
+
+// Min returns the lowest hourly price of the day.
+func (s PricesDaySummary) Min() float64 {
+	return s.min
+}
+
+// Max returns the highest hourly price of the day.
+func (s PricesDaySummary) Max() float64 {
+	return s.max
+}
+
+// Sum returns the sum of the hourly prices of the day.
+func (s PricesDaySummary) Sum() float64 {
+	return s.sum
+}
+
+// PeakHours returns the number of peak hours of the day.
+func (s PricesDaySummary) PeakHours() int {
+	return s.peakHours
+}
+
+// PeakSum returns the sum of the prices of the peak hours of the day.
+func (s PricesDaySummary) PeakSum() float64 {
+	return s.peakSum
+}
+
+// PricesAggregateDto is the DTO struct used to build a PricesAggregate value object by calling domain.NewPricesAggregate().
+type PricesAggregateDto struct {
+	ZoneID       string
+	Granularity  string
+	PeriodStart  string // YYYY-MM-DD
+	Days         int
+	Hours        int
+	Min          float64
+	Max          float64
+	Mean         float64
+	WeightedMean float64
+	PeakMean     *float64
+	OffpeakMean  *float64
+}
+
+// PricesAggregate is the value object with the statistics of the PVPC prices of a zone in a period,
+// in €/MWh. Only the days with stored prices are aggregated.
+//
+// Mean is the unweighted mean of the daily mean prices, so every day weighs the same, while
+// WeightedMean is the mean of every hourly price, so days are weighted by their number of hours.
+type PricesAggregate struct {
+	zoneID       ZoneID
+	granularity  AggregateGranularity
+	periodStart  time.Time
+	days         int
+	hours        int
+	min          float64
+	max          float64
+	mean         float64
+	weightedMean float64
+	peakMean     *float64
+	offpeakMean  *float64
+}
+
+// NewPricesAggregate creates a new PricesAggregate struct.
+func NewPricesAggregate(aggregateDto PricesAggregateDto) (PricesAggregate, error) {
+	zoneID, err := NewZoneID(aggregateDto.ZoneID)
+	if err != nil {
+		return PricesAggregate{}, err
+	}
+	granularity, err := NewAggregateGranularity(aggregateDto.Granularity)
+	if err != nil {
+		return PricesAggregate{}, err
+	}
+	periodStart, err := time.Parse(time.DateOnly, aggregateDto.PeriodStart)
+	if err != nil {
+		return PricesAggregate{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing PricesAggregate period start: %s", aggregateDto.PeriodStart))
+	}
+
+	return PricesAggregate{
+		zoneID:       zoneID,
+		granularity:  granularity,
+		periodStart:  periodStart,
+		days:         aggregateDto.Days,
+		hours:        aggregateDto.Hours,
+		min:          aggregateDto.Min,
+		max:          aggregateDto.Max,
+		mean:         aggregateDto.Mean,
+		weightedMean: aggregateDto.WeightedMean,
+		peakMean:     aggregateDto.PeakMean,
+		offpeakMean:  aggregateDto.OffpeakMean,
+	}, nil
+}
+
+// PeriodStart returns the first day of the aggregated period, at 00:00 UTC.
+func (a PricesAggregate) PeriodStart() time.Time {
+	return a.periodStart
+}
+
+// Mean returns the unweighted mean of the daily mean prices of the period.
+func (a PricesAggregate) Mean() float64 {
+	return a.mean
+}
+
+// Serialize serializes PricesAggregate into a PricesAggregateDto.
+func (a PricesAggregate) Serialize() PricesAggregateDto {
+	return PricesAggregateDto{
+		ZoneID:       a.zoneID.String(),
+		Granularity:  a.granularity.String(),
+		PeriodStart:  a.periodStart.Format(time.DateOnly),
+		Days:         a.days,
+		Hours:        a.hours,
+		Min:          a.min,
+		Max:          a.max,
+		Mean:         a.mean,
+		WeightedMean: a.weightedMean,
+		PeakMean:     a.peakMean,
+		OffpeakMean:  a.offpeakMean,
+	}
+}
@@ -0,0 +1,345 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// AnomalyKind is the kind of check a fetched Prices failed.
+type AnomalyKind string
+
+const (
+	// OutOfBoundsAnomaly flags hourly prices outside the hard bounds of AnomalyThresholds.
+	OutOfBoundsAnomaly AnomalyKind = "out_of_bounds"
+	// FlatlineAnomaly flags days where every hourly price is the same, e.g. all zeros.
+	FlatlineAnomaly AnomalyKind = "flatline"
+	// OutlierAnomaly flags days whose mean price is too many standard deviations away from
+	// the daily mean prices of the recent days.
+	OutlierAnomaly AnomalyKind = "outlier"
+	// UnitsAnomaly flags days where every hourly price is too close to zero to be in €/MWh,
+	// e.g. prices quoted in €/kWh.
+	UnitsAnomaly AnomalyKind = "units"
+)
+
+// NewAnomalyKind instantiate the VO for AnomalyKind.
+func NewAnomalyKind(value string) (AnomalyKind, error) {
+	switch AnomalyKind(value) {
+	case OutOfBoundsAnomaly, FlatlineAnomaly, OutlierAnomaly, UnitsAnomaly:
+		return AnomalyKind(value), nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidQuarantine, "invalid anomaly kind: %s. It must be one of: %s, %s, %s, %s",
+			value, OutOfBoundsAnomaly, FlatlineAnomaly, OutlierAnomaly, UnitsAnomaly)
+	}
+}
+
+// String converts the AnomalyKind into string.
+func (k AnomalyKind) String() string {
+	return string(k)
+}
+
+// PricesAnomalyDto is the DTO struct that represents a check failed by a Prices.
+// Used as a part of QuarantinedPricesDto and only to build a QuarantinedPrices domain entity.
+type PricesAnomalyDto struct {
+	Kind   string
+	Detail string
+}
+
+// PricesAnomaly is the value object that represents a check failed by a Prices, with a human
+// readable detail of why it failed.
+type PricesAnomaly struct {
+	kind   AnomalyKind
+	detail string
+}
+
+// Kind returns the kind of check the prices failed.
+func (a PricesAnomaly) Kind() AnomalyKind {
+	return a.kind
+}
+
+// Detail returns why the prices failed the check.
+func (a PricesAnomaly) Detail() string {
+	return a.detail
+}
+
+// Serialize returns the PricesAnomalyDto struct that represents the PricesAnomaly.
+func (a PricesAnomaly) Serialize() PricesAnomalyDto {
+	return PricesAnomalyDto{Kind: a.kind.String(), Detail: a.detail}
+}
+
+// AnomalyThresholds are the limits used by DetectPricesAnomalies, with prices in €/MWh.
+type AnomalyThresholds struct {
+	// MinValue and MaxValue are the hard bounds every hourly price must be within.
+	MinValue float64
+	MaxValue float64
+	// MinAbsValue is the absolute value at least one hourly price of a day must reach. Prices
+	// in €/MWh are way above it, while the same prices in €/kWh are all below it.
+	MinAbsValue float64
+	// MaxZScore is the highest number of standard deviations the mean price of a day may be
+	// from the mean of the daily mean prices of the recent days.
+	MaxZScore float64
+	// MinHistoryDays is the number of recent days needed to look for outliers.
+	MinHistoryDays int
+}
+
+// DefaultAnomalyThresholds bound prices to the harmonised limits of the day-ahead market
+// (-500 to 4000 €/MWh) with room for the regulated charges, so a change of units upstream to
+// €/GWh is caught by the bounds and one to €/kWh by MinAbsValue.
+var DefaultAnomalyThresholds = AnomalyThresholds{
+	MinValue:       -500,
+	MaxValue:       5000,
+	MinAbsValue:    1,
+	MaxZScore:      4,
+	MinHistoryDays: 7,
+}
+
+// DetectPricesAnomalies checks the hourly values of prices against thresholds and history, the
+// daily mean prices of the recent days of the same type and zone. It returns the failed checks,
+// if any. The outliers check is skipped when history has less than thresholds.MinHistoryDays days
+// or all of them have the same mean.
+func DetectPricesAnomalies(prices Prices, history []float64, thresholds AnomalyThresholds) []PricesAnomaly {
+	values := prices.Values()
+	if len(values) == 0 {
+		return nil
+	}
+
+	var anomalies []PricesAnomaly
+	var outOfBounds int
+	var sum, maxAbs float64
+	flat := len(values) > 1
+	for _, v := range values {
+		if v.Value() < thresholds.MinValue || v.Value() > thresholds.MaxValue {
+			outOfBounds++
+		}
+		maxAbs = math.Max(maxAbs, math.Abs(v.Value()))
+		if v.Value() != values[0].Value() {
+			flat = false
+		}
+		sum += v.Value()
+	}
+
+	if outOfBounds > 0 {
+		anomalies = append(anomalies, PricesAnomaly{
+			kind:   OutOfBoundsAnomaly,
+			detail: fmt.Sprintf("%d of %d hourly prices are out of [%g, %g]", outOfBounds, len(values), thresholds.MinValue, thresholds.MaxValue),
+		})
+	}
+	if flat {
+		anomalies = append(anomalies, PricesAnomaly{
+			kind:   FlatlineAnomaly,
+			detail: fmt.Sprintf("all the %d hourly prices are %g", len(values), values[0].Value()),
+		})
+	}
+	if maxAbs < thresholds.MinAbsValue {
+		anomalies = append(anomalies, PricesAnomaly{
+			kind:   UnitsAnomaly,
+			detail: fmt.Sprintf("all the %d hourly prices are within (-%g, %g)", len(values), thresholds.MinAbsValue, thresholds.MinAbsValue),
+		})
+	}
+
+	if len(history) > 0 && len(history) >= thresholds.MinHistoryDays {
+		var historySum, squares float64
+		for _, mean := range history {
+			historySum += mean
+		}
+		historyMean := historySum / float64(len(history))
+		for _, mean := range history {
+			squares += (mean - historyMean) * (mean - historyMean)
+		}
+		std := math.Sqrt(squares / float64(len(history)))
+
+		mean := sum / float64(len(values))
+		if std > 0 {
+			if z := (mean - historyMean) / std; math.Abs(z) > thresholds.MaxZScore {
+				anomalies = append(anomalies, PricesAnomaly{
+					kind: OutlierAnomaly,
+					detail: fmt.Sprintf("the mean price %.2f is %.1f standard deviations from the mean of the last %d days (%.2f)",
+						mean, z, len(history), historyMean),
+				})
+			}
+		}
+	}
+
+	return anomalies
+}
+
+// QuarantineStatus is the review status of a QuarantinedPrices.
+type QuarantineStatus string
+
+const (
+	// PendingQuarantine prices are waiting to be reviewed.
+	PendingQuarantine QuarantineStatus = "pending"
+	// ApprovedQuarantine prices were reviewed and stored.
+	ApprovedQuarantine QuarantineStatus = "approved"
+	// RejectedQuarantine prices were reviewed and discarded.
+	RejectedQuarantine QuarantineStatus = "rejected"
+)
+
+// NewQuarantineStatus instantiate the VO for QuarantineStatus.
+func NewQuarantineStatus(value string) (QuarantineStatus, error) {
+	switch QuarantineStatus(value) {
+	case PendingQuarantine, ApprovedQuarantine, RejectedQuarantine:
+		return QuarantineStatus(value), nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidQuarantine, "invalid quarantine status: %s. It must be one of: %s, %s, %s",
+			value, PendingQuarantine, ApprovedQuarantine, RejectedQuarantine)
+	}
+}
+
+// String converts the QuarantineStatus into string.
+func (s QuarantineStatus) String() string {
+	return string(s)
+}
+
+// QuarantinedPricesDto is the DTO struct used to build a QuarantinedPrices domain entity by calling domain.NewQuarantinedPrices().
+type QuarantinedPricesDto struct {
+	Prices     PricesDto
+	Anomalies  []PricesAnomalyDto
+	Status     string
+	DetectedAt string
+	ReviewedAt string // empty while pending
+}
+
+// QuarantinedPrices is the domain entity that represents fetched prices held back from being
+// stored because they failed some anomaly checks, until they are manually approved or rejected.
+type QuarantinedPrices struct {
+	prices     Prices
+	anomalies  []PricesAnomaly
+	status     QuarantineStatus
+	detectedAt time.Time
+	reviewedAt *time.Time
+}
+
+// NewQuarantinedPrices creates a new QuarantinedPrices struct.
+func NewQuarantinedPrices(quarantinedDto QuarantinedPricesDto) (QuarantinedPrices, error) {
+	prices, err := NewPrices(quarantinedDto.Prices)
+	if err != nil {
+		return QuarantinedPrices{}, err
+	}
+
+	anomalies := make([]PricesAnomaly, len(quarantinedDto.Anomalies))
+	for i, a := range quarantinedDto.Anomalies {
+		kind, err := NewAnomalyKind(a.Kind)
+		if err != nil {
+			return QuarantinedPrices{}, err
+		}
+		anomalies[i] = PricesAnomaly{kind: kind, detail: a.Detail}
+	}
+
+	status, err := NewQuarantineStatus(quarantinedDto.Status)
+	if err != nil {
+		return QuarantinedPrices{}, err
+	}
+
+	detectedAt, err := time.Parse(time.RFC3339, quarantinedDto.DetectedAt)
+	if err != nil {
+		return QuarantinedPrices{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing QuarantinedPrices detectedAt value: %s", quarantinedDto.DetectedAt))
+	}
+
+	var reviewedAt *time.Time
+	if quarantinedDto.ReviewedAt != "" {
+		t, err := time.Parse(time.RFC3339, quarantinedDto.ReviewedAt)
+		if err != nil {
+			return QuarantinedPrices{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing QuarantinedPrices reviewedAt value: %s", quarantinedDto.ReviewedAt))
+		}
+		reviewedAt = &t
+	}
+	if (status == PendingQuarantine) != (reviewedAt == nil) {
+		return QuarantinedPrices{}, errors.NewDomainError(errors.InvalidQuarantine, "only reviewed quarantined prices have a review time: %s is %s", prices.ID().String(), status)
+	}
+
+	return QuarantinedPrices{
+		prices:     prices,
+		anomalies:  anomalies,
+		status:     status,
+		detectedAt: detectedAt,
+		reviewedAt: reviewedAt,
+	}, nil
+}
+
+// QuarantinePrices returns the pending QuarantinedPrices of prices that failed the given checks at detectedAt.
+func QuarantinePrices(prices Prices, anomalies []PricesAnomaly, detectedAt time.Time) QuarantinedPrices {
+	return QuarantinedPrices{
+		prices:     prices,
+		anomalies:  anomalies,
+		status:     PendingQuarantine,
+		detectedAt: detectedAt,
+	}
+}
+
+// Prices returns the quarantined prices.
+func (q QuarantinedPrices) Prices() Prices {
+	return q.prices
+}
+
+// Anomalies returns the checks the quarantined prices failed.
+func (q QuarantinedPrices) Anomalies() []PricesAnomaly {
+	return q.anomalies
+}
+
+// Status returns the review status of the quarantined prices.
+func (q QuarantinedPrices) Status() QuarantineStatus {
+	return q.status
+}
+
+// DetectedAt returns when the anomalies were detected.
+func (q QuarantinedPrices) DetectedAt() time.Time {
+	return q.detectedAt
+}
+
+// ReviewedAt returns when the quarantined prices were approved or rejected, or nil while pending.
+func (q QuarantinedPrices) ReviewedAt() *time.Time {
+	return q.reviewedAt
+}
+
+// Review returns the quarantined prices approved or rejected, as given by status, at reviewedAt.
+// Only pending quarantined prices can be reviewed.
+func (q QuarantinedPrices) Review(status QuarantineStatus, reviewedAt time.Time) (QuarantinedPrices, error) {
+	if status == PendingQuarantine {
+		return QuarantinedPrices{}, errors.NewDomainError(errors.InvalidQuarantine, "quarantined prices can only be reviewed as %s or %s", ApprovedQuarantine, RejectedQuarantine)
+	}
+	if q.status != PendingQuarantine {
+		return QuarantinedPrices{}, errors.NewDomainError(errors.InvalidQuarantine, "quarantined %s prices %s are already %s", q.prices.Type(), q.prices.ID().String(), q.status)
+	}
+
+	q.status = status
+	q.reviewedAt = &reviewedAt
+	return q, nil
+}
+
+// Serialize returns the QuarantinedPricesDto struct that represents the QuarantinedPrices.
+func (q QuarantinedPrices) Serialize() QuarantinedPricesDto {
+	anomalies := make([]PricesAnomalyDto, len(q.anomalies))
+	for i, a := range q.anomalies {
+		anomalies[i] = a.Serialize()
+	}
+
+	var reviewedAt string
+	if q.reviewedAt != nil {
+		reviewedAt = q.reviewedAt.UTC().Format(time.RFC3339)
+	}
+
+	return QuarantinedPricesDto{
+		Prices:     q.prices.Serialize(),
+		Anomalies:  anomalies,
+		Status:     q.status.String(),
+		DetectedAt: q.detectedAt.UTC().Format(time.RFC3339),
+		ReviewedAt: reviewedAt,
+	}
+}
+
+// QuarantineRepository defines the expected behavior from a quarantined prices storage.
+type QuarantineRepository interface {
+	// Save persists the given quarantined prices, replacing the stored ones with the same type and ID.
+	Save(ctx context.Context, quarantined QuarantinedPrices) error
+
+	// Get returns the quarantined prices of pricesType with the given ID, or a QuarantineNotFound error.
+	Get(ctx context.Context, pricesType PricesType, id PricesID) (QuarantinedPrices, error)
+
+	// List returns the quarantined prices with the given status, or all of them if status is nil,
+	// sorted from the most recently detected one.
+	List(ctx context.Context, status *QuarantineStatus) ([]QuarantinedPrices, error)
+}
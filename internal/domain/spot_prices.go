@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Spot prices are the day-ahead wholesale market (OMIE) prices. They are modeled as Prices,
+// the price of every hour of a day for a zone, but stored and fetched apart from the PVPC ones.
+
+// SpotPricesRepository defines the expected behavior from a spot prices storage.
+type SpotPricesRepository interface {
+	// Save persists the given spot prices.
+	Save(ctx context.Context, prices []Prices) error
+
+	// Query returns the spot prices for the given date and zoneID, with the same
+	// semantics as PricesRepository.Query.
+	Query(ctx context.Context, zoneID *ZoneID, date *time.Time) ([]Prices, error)
+}
+
+// SpotPricesProvider defines the expected behavior from a spot prices provider.
+type SpotPricesProvider interface {
+	// FetchSpotPrices fetches the spot prices for the given zones and date.
+	// If the zones slice is empty or nil, it returns nil.
+	FetchSpotPrices(ctx context.Context, zones []Zone, date time.Time) ([]Prices, error)
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// TariffPeriod is the 2.0TD tariff period an hour belongs to, which sets the price of the access tolls.
+type TariffPeriod string
+
+const (
+	// TariffPeriodP1 is the peak period ("punta").
+	TariffPeriodP1 TariffPeriod = "P1"
+	// TariffPeriodP2 is the flat period ("llano").
+	TariffPeriodP2 TariffPeriod = "P2"
+	// TariffPeriodP3 is the off-peak period ("valle").
+	TariffPeriodP3 TariffPeriod = "P3"
+)
+
+// String converts the TariffPeriod into string.
+func (p TariffPeriod) String() string {
+	return string(p)
+}
+
+// shiftedPeriodsZones are the zones whose P1 and P2 periods start one hour later: Ceuta and Melilla.
+var shiftedPeriodsZones = map[string]bool{
+	"CEU": true,
+	"MEL": true,
+}
+
+// TariffPeriodOf returns the 2.0TD tariff period of the hour starting at localTime, which must be in
+// the local time of the zone. Weekends and national holidays are P3 the whole day. On working days:
+//
+//	            P1 (punta)      P2 (llano)               P3 (valle)
+//	default     10-14, 18-22    8-10, 14-18, 22-24       0-8
+//	Ceuta and   11-15, 19-23    8-11, 15-19, 23-24       0-8
+//	Melilla
+func TariffPeriodOf(zoneID ZoneID, localTime time.Time, calendar HolidayCalendar) TariffPeriod {
+	if weekday := localTime.Weekday(); weekday == time.Saturday || weekday == time.Sunday || calendar.IsHoliday(localTime) {
+		return TariffPeriodP3
+	}
+
+	hour := localTime.Hour()
+	if hour < 8 {
+		return TariffPeriodP3
+	}
+	if shiftedPeriodsZones[zoneID.String()] {
+		hour--
+	}
+	if (hour >= 10 && hour < 14) || (hour >= 18 && hour < 22) {
+		return TariffPeriodP1
+	}
+	return TariffPeriodP2
+}
+
+// HolidayDto is the DTO struct used to build a Holiday domain entity by calling domain.NewHoliday().
+type HolidayDto struct {
+	Date string // YYYY-MM-DD
+	Name string
+}
+
+// Holiday is the domain entity that represents a national holiday, a day fully in the P3 tariff period.
+type Holiday struct {
+	date time.Time
+	name string
+}
+
+// NewHoliday creates a new Holiday struct.
+func NewHoliday(holidayDto HolidayDto) (Holiday, error) {
+	date, err := time.Parse(time.DateOnly, holidayDto.Date)
+	if err != nil {
+		return Holiday{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing Holiday date value: %s. It must be in the shape of YYYY-MM-DD", holidayDto.Date))
+	}
+	if holidayDto.Name == "" {
+		return Holiday{}, errors.NewDomainError(errors.InvalidHoliday, "Holiday on %s must have a name", holidayDto.Date)
+	}
+
+	return Holiday{
+		date: date,
+		name: holidayDto.Name,
+	}, nil
+}
+
+// Date returns the Holiday's day, as a UTC midnight.
+func (h Holiday) Date() time.Time {
+	return h.date
+}
+
+// Name returns the Holiday's name.
+func (h Holiday) Name() string {
+	return h.name
+}
+
+// Serialize returns the HolidayDto struct that represents the Holiday.
+func (h Holiday) Serialize() HolidayDto {
+	return HolidayDto{
+		Date: h.date.Format(time.DateOnly),
+		Name: h.name,
+	}
+}
+
+// HolidayCalendar is the set of national holidays taken into account to classify hours into tariff periods.
+type HolidayCalendar struct {
+	days map[string]Holiday
+}
+
+// NewHolidayCalendar creates a HolidayCalendar with the given holidays.
+func NewHolidayCalendar(holidays []Holiday) HolidayCalendar {
+	days := make(map[string]Holiday, len(holidays))
+	for _, holiday := range holidays {
+		days[holiday.date.Format(time.DateOnly)] = holiday
+	}
+	return HolidayCalendar{days: days}
+}
+
+// IsHoliday reports whether the day of t, in t's location, is a holiday.
+func (c HolidayCalendar) IsHoliday(t time.Time) bool {
+	_, ok := c.days[t.Format(time.DateOnly)]
+	return ok
+}
+
+// HolidaysRepository defines the expected behavior from a holidays storage.
+type HolidaysRepository interface {
+	// GetAll returns all the holidays, sorted by date.
+	GetAll(ctx context.Context) ([]Holiday, error)
+
+	// Save persists the given holidays, replacing the stored ones for the same days.
+	Save(ctx context.Context, holidays []Holiday) error
+
+	// Delete removes the holiday of the given day.
+	// It returns a HolidayNotFound error if there is no holiday that day.
+	Delete(ctx context.Context, date time.Time) error
+}
@@ -3,15 +3,25 @@ package domain
 import (
 	"context"
 	"regexp"
+	"sync"
+	"time"
 
 	"pvpc-backend/internal/domain/errors"
 )
 
+// DefaultZoneTimeZone is the IANA time zone of the zones that don't set their own one.
+// It's the one in which REE publishes the prices days.
+const DefaultZoneTimeZone = "Europe/Madrid"
+
+// zoneLocations caches the loaded time zones by name, as every Prices built has its zone.
+var zoneLocations sync.Map
+
 // ZoneDto is the DTO struct used to build a Zone domain entity by calling domain.NewZone().
 type ZoneDto struct {
 	ID         string
 	ExternalID string
 	Name       string
+	TimeZone   string // IANA time zone name, DefaultZoneTimeZone if empty
 }
 
 // Zone is the domain entity that represents a PVPC zone.
@@ -19,6 +29,8 @@ type Zone struct {
 	id         ZoneID
 	externalID string
 	name       string
+	timeZone   string
+	location   *time.Location
 }
 
 // ZoneID represents the Zone's unique identifier.
@@ -68,10 +80,17 @@ func NewZone(zoneDto ZoneDto) (Zone, error) {
 		return Zone{}, err
 	}
 
+	location, err := NewZoneLocation(zoneDto.TimeZone)
+	if err != nil {
+		return Zone{}, err
+	}
+
 	zone := Zone{
 		id:         idVO,
 		externalID: zoneDto.ExternalID,
 		name:       zoneDto.Name,
+		timeZone:   zoneDto.TimeZone,
+		location:   location,
 	}
 
 	return zone, nil
@@ -92,11 +111,38 @@ func (c Zone) Name() string {
 	return c.name
 }
 
+// Location returns the Zone's local time zone, in which its days start and end.
+func (c Zone) Location() *time.Location {
+	if c.location == nil {
+		location, _ := NewZoneLocation("")
+		return location
+	}
+	return c.location
+}
+
 // Serialize returns the ZoneDto struct that represents the Zone.
 func (c Zone) Serialize() ZoneDto {
 	return ZoneDto{
 		ID:         c.id.String(),
 		ExternalID: c.externalID,
 		Name:       c.name,
+		TimeZone:   c.timeZone,
+	}
+}
+
+// NewZoneLocation loads the IANA time zone with the given name, e.g. Atlantic/Canary,
+// or DefaultZoneTimeZone if it's empty.
+func NewZoneLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		timeZone = DefaultZoneTimeZone
+	}
+	if location, ok := zoneLocations.Load(timeZone); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.InvalidTimeZone, "invalid time zone: "+timeZone)
 	}
+	zoneLocations.Store(timeZone, location)
+	return location, nil
 }
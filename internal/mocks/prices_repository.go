@@ -24,6 +24,119 @@ func (_m *PricesRepository) EXPECT() *PricesRepository_Expecter {
 	return &PricesRepository_Expecter{mock: &_m.Mock}
 }
 
+// Aggregate provides a mock function with given fields: ctx, zoneID, granularity, from, to
+func (_m *PricesRepository) Aggregate(ctx context.Context, zoneID domain.ZoneID, granularity domain.AggregateGranularity, from time.Time, to time.Time) ([]domain.PricesAggregate, error) {
+	ret := _m.Called(ctx, zoneID, granularity, from, to)
+
+	var r0 []domain.PricesAggregate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ZoneID, domain.AggregateGranularity, time.Time, time.Time) ([]domain.PricesAggregate, error)); ok {
+		return rf(ctx, zoneID, granularity, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ZoneID, domain.AggregateGranularity, time.Time, time.Time) []domain.PricesAggregate); ok {
+		r0 = rf(ctx, zoneID, granularity, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PricesAggregate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ZoneID, domain.AggregateGranularity, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, zoneID, granularity, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PricesRepository_Aggregate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Aggregate'
+type PricesRepository_Aggregate_Call struct {
+	*mock.Call
+}
+
+// Aggregate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID domain.ZoneID
+//   - granularity domain.AggregateGranularity
+//   - from time.Time
+//   - to time.Time
+func (_e *PricesRepository_Expecter) Aggregate(ctx interface{}, zoneID interface{}, granularity interface{}, from interface{}, to interface{}) *PricesRepository_Aggregate_Call {
+	return &PricesRepository_Aggregate_Call{Call: _e.mock.On("Aggregate", ctx, zoneID, granularity, from, to)}
+}
+
+func (_c *PricesRepository_Aggregate_Call) Run(run func(ctx context.Context, zoneID domain.ZoneID, granularity domain.AggregateGranularity, from time.Time, to time.Time)) *PricesRepository_Aggregate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ZoneID), args[2].(domain.AggregateGranularity), args[3].(time.Time), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PricesRepository_Aggregate_Call) Return(_a0 []domain.PricesAggregate, _a1 error) *PricesRepository_Aggregate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PricesRepository_Aggregate_Call) RunAndReturn(run func(context.Context, domain.ZoneID, domain.AggregateGranularity, time.Time, time.Time) ([]domain.PricesAggregate, error)) *PricesRepository_Aggregate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function with given fields: ctx, id
+func (_m *PricesRepository) ListRevisions(ctx context.Context, id domain.PricesID) ([]domain.PricesRevision, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []domain.PricesRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PricesID) ([]domain.PricesRevision, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PricesID) []domain.PricesRevision); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PricesRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PricesID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PricesRepository_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type PricesRepository_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id domain.PricesID
+func (_e *PricesRepository_Expecter) ListRevisions(ctx interface{}, id interface{}) *PricesRepository_ListRevisions_Call {
+	return &PricesRepository_ListRevisions_Call{Call: _e.mock.On("ListRevisions", ctx, id)}
+}
+
+func (_c *PricesRepository_ListRevisions_Call) Run(run func(ctx context.Context, id domain.PricesID)) *PricesRepository_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PricesID))
+	})
+	return _c
+}
+
+func (_c *PricesRepository_ListRevisions_Call) Return(_a0 []domain.PricesRevision, _a1 error) *PricesRepository_ListRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PricesRepository_ListRevisions_Call) RunAndReturn(run func(context.Context, domain.PricesID) ([]domain.PricesRevision, error)) *PricesRepository_ListRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Query provides a mock function with given fields: ctx, zoneID, date
 func (_m *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
 	ret := _m.Called(ctx, zoneID, date)
@@ -80,6 +193,120 @@ func (_c *PricesRepository_Query_Call) RunAndReturn(run func(context.Context, *d
 	return _c
 }
 
+// QueryByType provides a mock function with given fields: ctx, pricesType, zoneID, date
+func (_m *PricesRepository) QueryByType(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	ret := _m.Called(ctx, pricesType, zoneID, date)
+
+	var r0 []domain.Prices
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PricesType, *domain.ZoneID, *time.Time) ([]domain.Prices, error)); ok {
+		return rf(ctx, pricesType, zoneID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PricesType, *domain.ZoneID, *time.Time) []domain.Prices); ok {
+		r0 = rf(ctx, pricesType, zoneID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Prices)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PricesType, *domain.ZoneID, *time.Time) error); ok {
+		r1 = rf(ctx, pricesType, zoneID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PricesRepository_QueryByType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryByType'
+type PricesRepository_QueryByType_Call struct {
+	*mock.Call
+}
+
+// QueryByType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pricesType domain.PricesType
+//   - zoneID *domain.ZoneID
+//   - date *time.Time
+func (_e *PricesRepository_Expecter) QueryByType(ctx interface{}, pricesType interface{}, zoneID interface{}, date interface{}) *PricesRepository_QueryByType_Call {
+	return &PricesRepository_QueryByType_Call{Call: _e.mock.On("QueryByType", ctx, pricesType, zoneID, date)}
+}
+
+func (_c *PricesRepository_QueryByType_Call) Run(run func(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time)) *PricesRepository_QueryByType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.PricesType), args[2].(*domain.ZoneID), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *PricesRepository_QueryByType_Call) Return(_a0 []domain.Prices, _a1 error) *PricesRepository_QueryByType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PricesRepository_QueryByType_Call) RunAndReturn(run func(context.Context, domain.PricesType, *domain.ZoneID, *time.Time) ([]domain.Prices, error)) *PricesRepository_QueryByType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryRange provides a mock function with given fields: ctx, zoneID, from, to
+func (_m *PricesRepository) QueryRange(ctx context.Context, zoneID domain.ZoneID, from time.Time, to time.Time) ([]domain.Prices, error) {
+	ret := _m.Called(ctx, zoneID, from, to)
+
+	var r0 []domain.Prices
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ZoneID, time.Time, time.Time) ([]domain.Prices, error)); ok {
+		return rf(ctx, zoneID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.ZoneID, time.Time, time.Time) []domain.Prices); ok {
+		r0 = rf(ctx, zoneID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Prices)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.ZoneID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, zoneID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PricesRepository_QueryRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRange'
+type PricesRepository_QueryRange_Call struct {
+	*mock.Call
+}
+
+// QueryRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - zoneID domain.ZoneID
+//   - from time.Time
+//   - to time.Time
+func (_e *PricesRepository_Expecter) QueryRange(ctx interface{}, zoneID interface{}, from interface{}, to interface{}) *PricesRepository_QueryRange_Call {
+	return &PricesRepository_QueryRange_Call{Call: _e.mock.On("QueryRange", ctx, zoneID, from, to)}
+}
+
+func (_c *PricesRepository_QueryRange_Call) Run(run func(ctx context.Context, zoneID domain.ZoneID, from time.Time, to time.Time)) *PricesRepository_QueryRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ZoneID), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *PricesRepository_QueryRange_Call) Return(_a0 []domain.Prices, _a1 error) *PricesRepository_QueryRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PricesRepository_QueryRange_Call) RunAndReturn(run func(context.Context, domain.ZoneID, time.Time, time.Time) ([]domain.Prices, error)) *PricesRepository_QueryRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, prices
 func (_m *PricesRepository) Save(ctx context.Context, prices []domain.Prices) error {
 	ret := _m.Called(ctx, prices)
@@ -123,6 +350,49 @@ func (_c *PricesRepository_Save_Call) RunAndReturn(run func(context.Context, []d
 	return _c
 }
 
+// Upsert provides a mock function with given fields: ctx, prices
+func (_m *PricesRepository) Upsert(ctx context.Context, prices []domain.Prices) error {
+	ret := _m.Called(ctx, prices)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Prices) error); ok {
+		r0 = rf(ctx, prices)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PricesRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type PricesRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prices []domain.Prices
+func (_e *PricesRepository_Expecter) Upsert(ctx interface{}, prices interface{}) *PricesRepository_Upsert_Call {
+	return &PricesRepository_Upsert_Call{Call: _e.mock.On("Upsert", ctx, prices)}
+}
+
+func (_c *PricesRepository_Upsert_Call) Run(run func(ctx context.Context, prices []domain.Prices)) *PricesRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Prices))
+	})
+	return _c
+}
+
+func (_c *PricesRepository_Upsert_Call) Return(_a0 error) *PricesRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PricesRepository_Upsert_Call) RunAndReturn(run func(context.Context, []domain.Prices) error) *PricesRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewPricesRepository creates a new instance of PricesRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPricesRepository(t interface {
@@ -0,0 +1,139 @@
+package bills
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type simulateBillRequest struct {
+	ZoneID          string                     `json:"zone_id"`
+	From            string                     `json:"from"`
+	To              string                     `json:"to"`
+	ContractedPower contractedPowerRequest     `json:"contracted_power"`
+	Consumption     []hourlyConsumptionRequest `json:"consumption"`
+}
+
+type contractedPowerRequest struct {
+	P1 float64 `json:"p1"`
+	P2 float64 `json:"p2"`
+}
+
+type hourlyConsumptionRequest struct {
+	Datetime string  `json:"datetime"`
+	Kwh      float64 `json:"kwh"`
+}
+
+type billResponse struct {
+	ZoneID string             `json:"zone_id"`
+	From   string             `json:"from"`
+	To     string             `json:"to"`
+	Lines  []billLineResponse `json:"lines"`
+	Total  float64            `json:"total"`
+}
+
+type billLineResponse struct {
+	Concept  string  `json:"concept"`
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+	Price    float64 `json:"price"`
+	Amount   float64 `json:"amount"`
+}
+
+// SimulateBillHandlerV1 returns a gin.HandlerFunc to simulate the PVPC bill of a supply, given its zone,
+// contracted power (kW) per power period and hourly consumption (kWh) during the billing period,
+// from and to (YYYY-MM-DD) both included. The bill has a line item per concept and period of validity of the rates.
+func SimulateBillHandlerV1(billsService services.BillsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request simulateBillRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid bill simulation request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		simulation, err := newBillSimulation(request)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		bill, err := billsService.SimulateBill(ctx, simulation)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, newBillResponse(bill))
+	}
+}
+
+func newBillSimulation(request simulateBillRequest) (services.BillSimulation, error) {
+	zoneID, err := domain.NewZoneID(request.ZoneID)
+	if err != nil {
+		return services.BillSimulation{}, err
+	}
+	from, err := time.Parse(time.DateOnly, request.From)
+	if err != nil {
+		return services.BillSimulation{}, errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid bill from date. It must be in the shape of YYYY-MM-DD")
+	}
+	to, err := time.Parse(time.DateOnly, request.To)
+	if err != nil {
+		return services.BillSimulation{}, errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid bill to date. It must be in the shape of YYYY-MM-DD")
+	}
+	power, err := domain.NewContractedPower(request.ContractedPower.P1, request.ContractedPower.P2)
+	if err != nil {
+		return services.BillSimulation{}, err
+	}
+
+	consumption := make([]domain.HourlyConsumption, len(request.Consumption))
+	for i, c := range request.Consumption {
+		consumption[i], err = domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: c.Datetime, Kwh: c.Kwh})
+		if err != nil {
+			return services.BillSimulation{}, err
+		}
+	}
+
+	return services.BillSimulation{
+		ZoneID:          zoneID,
+		From:            from,
+		To:              to,
+		ContractedPower: power,
+		Consumption:     consumption,
+	}, nil
+}
+
+func newBillResponse(bill domain.Bill) billResponse {
+	billDto := bill.Serialize()
+	response := billResponse{
+		ZoneID: billDto.ZoneID,
+		From:   billDto.From,
+		To:     billDto.To,
+		Lines:  make([]billLineResponse, len(billDto.Lines)),
+		Total:  bill.Total(),
+	}
+
+	for i, line := range billDto.Lines {
+		response.Lines[i] = billLineResponse{
+			Concept:  line.Concept,
+			From:     line.From,
+			To:       line.To,
+			Quantity: line.Quantity,
+			Unit:     line.Unit,
+			Price:    line.Price,
+			Amount:   line.Amount,
+		}
+	}
+
+	return response
+}
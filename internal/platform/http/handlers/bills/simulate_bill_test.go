@@ -0,0 +1,135 @@
+package bills
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestBillsService(t *testing.T, repositoryMock *mocks.PricesRepository) services.BillsService {
+	rates, err := domain.NewBillRates(domain.BillRatesDto{
+		ValidFrom: "2023-01-01", PowerP1: 0.1, PowerP2: 0.01, ElectricityTax: 0.05, ElectricityTaxMinimum: 1, MeterRental: 0.02, VAT: 0.1,
+	})
+	require.NoError(t, err)
+	schedule, err := domain.NewBillRatesSchedule([]domain.BillRates{rates})
+	require.NoError(t, err)
+	zone, err := domain.NewZone(domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"})
+	require.NoError(t, err)
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil)
+	return services.NewBillsService(repositoryMock, zonesRepositoryMock, schedule)
+}
+
+func Test_SimulateBillHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+
+	r := gin.New()
+	r.POST("/v1/bills/simulate", SimulateBillHandlerV1(newTestBillsService(t, repositoryMock)))
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:     "PEN-2023-10-11",
+		Date:   "2023-10-11T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-11T10:00:00+02:00", Value: 200}, {Datetime: "2023-10-11T11:00:00+02:00", Value: 150}},
+	})
+	require.NoError(t, err)
+	zoneID, err := domain.NewZoneID("PEN")
+	require.NoError(t, err)
+	day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+	repositoryMock.On("Query", mock.Anything, &zoneID, &day).Return([]domain.Prices{prices}, nil)
+
+	body := `{
+		"zone_id": "PEN",
+		"from": "2023-10-11",
+		"to": "2023-10-11",
+		"contracted_power": {"p1": 4.6, "p2": 3.3},
+		"consumption": [
+			{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1.5},
+			{"datetime": "2023-10-11T11:00:00+02:00", "kwh": 0.5}
+		]
+	}`
+	req, err := http.NewRequest(http.MethodPost, "/v1/bills/simulate", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_SimulateBillHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "invalid body", body: `[]`},
+		{name: "invalid zone", body: `{"zone_id": "pen", "from": "2023-10-11", "to": "2023-10-11", "contracted_power": {"p1": 4.6, "p2": 4.6}}`},
+		{name: "invalid date", body: `{"zone_id": "PEN", "from": "11/10/2023", "to": "2023-10-11", "contracted_power": {"p1": 4.6, "p2": 4.6}}`},
+		{name: "missing contracted power", body: `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11"}`},
+		{name: "negative consumption", body: `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11", "contracted_power": {"p1": 4.6, "p2": 4.6}, "consumption": [{"datetime": "2023-10-11T10:00:00+02:00", "kwh": -1}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryMock := new(mocks.PricesRepository)
+
+			r := gin.New()
+			r.POST("/v1/bills/simulate", SimulateBillHandlerV1(newTestBillsService(t, repositoryMock)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/bills/simulate", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			repositoryMock.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_SimulateBillHandlerV1_PricesNotFound(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+
+	r := gin.New()
+	r.POST("/v1/bills/simulate", SimulateBillHandlerV1(newTestBillsService(t, repositoryMock)))
+
+	repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{}, nil)
+
+	body := `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11", "contracted_power": {"p1": 4.6, "p2": 4.6}, "consumption": [{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1}]}`
+	req, err := http.NewRequest(http.MethodPost, "/v1/bills/simulate", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
@@ -2,14 +2,32 @@ package health
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/alexliesenfeld/health"
 	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/platform/providers/resilient"
 )
 
+type healthResponse struct {
+	*health.CheckerResult
+	Providers map[string]providerStatus `json:"providers,omitempty"`
+}
+
+type providerStatus struct {
+	CircuitBreaker      resilient.BreakerState `json:"circuitBreaker"`
+	ConsecutiveFailures uint                   `json:"consecutiveFailures"`
+}
+
 // HealthCheckHandlerV1 returns a gin.HandlerFunc to perform health checks.
-func HealthCheckHandlerV1(db *sql.DB, dbTimeout time.Duration) gin.HandlerFunc {
+//
+// The state of the given providers circuit breakers is reported for information only:
+// an open breaker does not make the service unavailable, as there are fallback providers.
+func HealthCheckHandlerV1(db *sql.DB, dbTimeout time.Duration, breakers ...*resilient.CircuitBreaker) gin.HandlerFunc {
 	checker := health.NewChecker(
 		health.WithCheck(health.Check{
 			Name:    "database",
@@ -18,5 +36,34 @@ func HealthCheckHandlerV1(db *sql.DB, dbTimeout time.Duration) gin.HandlerFunc {
 		}),
 	)
 
-	return gin.WrapF(health.NewHandler(checker))
+	return gin.WrapF(health.NewHandler(checker, health.WithResultWriter(providersResultWriter{breakers: breakers})))
+}
+
+// providersResultWriter writes the health.CheckerResult in JSON, like health.JSONResultWriter,
+// adding the providers circuit breakers state.
+type providersResultWriter struct {
+	breakers []*resilient.CircuitBreaker
+}
+
+// Write implements health.ResultWriter.
+func (rw providersResultWriter) Write(result *health.CheckerResult, statusCode int, w http.ResponseWriter, r *http.Request) error {
+	response := healthResponse{CheckerResult: result}
+	if len(rw.breakers) > 0 {
+		response.Providers = make(map[string]providerStatus, len(rw.breakers))
+		for _, breaker := range rw.breakers {
+			response.Providers[breaker.Name()] = providerStatus{
+				CircuitBreaker:      breaker.State(),
+				ConsecutiveFailures: breaker.ConsecutiveFailures(),
+			}
+		}
+	}
+
+	jsonResp, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("cannot marshal response: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(jsonResp)
+	return err
 }
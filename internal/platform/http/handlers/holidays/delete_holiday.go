@@ -0,0 +1,33 @@
+package holidays
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+// DeleteHolidayHandlerV1 returns a gin.HandlerFunc to remove the holiday of the date path param (YYYY-MM-DD)
+// from the calendar.
+func DeleteHolidayHandlerV1(tariffPeriodsService services.TariffPeriodsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		date, err := time.Parse(time.DateOnly, ctx.Param("date"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidTime, "invalid holiday date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		if err := tariffPeriodsService.DeleteHoliday(ctx, date); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
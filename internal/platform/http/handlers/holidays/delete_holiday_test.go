@@ -0,0 +1,83 @@
+package holidays
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_DeleteHolidayHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.DELETE("/v1/admin/holidays/:date", DeleteHolidayHandlerV1(tariffPeriodsService))
+
+	repositoryMock.On("Delete", mock.Anything, time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)).Return(nil)
+
+	req, err := http.NewRequest(http.MethodDelete, "/v1/admin/holidays/2023-10-12", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Empty(t, rec.Body.String())
+}
+
+func Test_DeleteHolidayHandlerV1_NotFound(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.DELETE("/v1/admin/holidays/:date", DeleteHolidayHandlerV1(tariffPeriodsService))
+
+	repositoryMock.On("Delete", mock.Anything, mock.Anything).Return(errors.NewDomainError(errors.HolidayNotFound, "Holiday on 2023-10-13 not found"))
+
+	req, err := http.NewRequest(http.MethodDelete, "/v1/admin/holidays/2023-10-13", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_DeleteHolidayHandlerV1_InvalidDate(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.DELETE("/v1/admin/holidays/:date", DeleteHolidayHandlerV1(tariffPeriodsService))
+
+	req, err := http.NewRequest(http.MethodDelete, "/v1/admin/holidays/tomorrow", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	repositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
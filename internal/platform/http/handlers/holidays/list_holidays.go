@@ -0,0 +1,47 @@
+package holidays
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type listHolidaysResponse struct {
+	Holidays []holidayResponse `json:"holidays"`
+	Total    int               `json:"total"`
+}
+
+type holidayResponse struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+// ListHolidaysHandlerV1 returns a gin.HandlerFunc to list the national holidays taken into account
+// to classify prices hours into tariff periods.
+func ListHolidaysHandlerV1(tariffPeriodsService services.TariffPeriodsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		holidays, err := tariffPeriodsService.ListHolidays(ctx)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := listHolidaysResponse{
+			Holidays: make([]holidayResponse, len(holidays)),
+			Total:    len(holidays),
+		}
+		for i, holiday := range holidays {
+			response.Holidays[i] = holidayResponse{
+				Date: holiday.Date().Format(time.DateOnly),
+				Name: holiday.Name(),
+			}
+		}
+
+		ctx.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,73 @@
+package holidays
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_ListHolidaysHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.GET("/v1/holidays", ListHolidaysHandlerV1(tariffPeriodsService))
+
+	newYear, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-01-01", Name: "Año Nuevo"})
+	require.NoError(t, err)
+	epiphany, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-01-06", Name: "Epifanía del Señor"})
+	require.NoError(t, err)
+
+	repositoryMock.On("GetAll", mock.Anything).Return([]domain.Holiday{newYear, epiphany}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/holidays", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_ListHolidaysHandlerV1_Error(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.GET("/v1/holidays", ListHolidaysHandlerV1(tariffPeriodsService))
+
+	repositoryMock.On("GetAll", mock.Anything).Return(nil, errors.New("mock error"))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/holidays", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
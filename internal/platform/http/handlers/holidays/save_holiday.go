@@ -0,0 +1,48 @@
+package holidays
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type saveHolidayRequest struct {
+	Name string `json:"name"`
+}
+
+// SaveHolidayHandlerV1 returns a gin.HandlerFunc to add the holiday of the date path param (YYYY-MM-DD)
+// to the calendar, or to rename it if it is already there.
+func SaveHolidayHandlerV1(tariffPeriodsService services.TariffPeriodsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request saveHolidayRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidHoliday, "invalid holiday request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		holiday, err := domain.NewHoliday(domain.HolidayDto{Date: ctx.Param("date"), Name: request.Name})
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		if err := tariffPeriodsService.SaveHolidays(ctx, []domain.Holiday{holiday}); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, holidayResponse{
+			Date: holiday.Date().Format(time.DateOnly),
+			Name: holiday.Name(),
+		})
+	}
+}
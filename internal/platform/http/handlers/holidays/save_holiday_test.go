@@ -0,0 +1,81 @@
+package holidays
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_SaveHolidayHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+	r := gin.New()
+	r.PUT("/v1/admin/holidays/:date", SaveHolidayHandlerV1(tariffPeriodsService))
+
+	holiday, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-10-12", Name: "Fiesta Nacional de España"})
+	require.NoError(t, err)
+	repositoryMock.On("Save", mock.Anything, []domain.Holiday{holiday}).Return(nil)
+
+	req, err := http.NewRequest(http.MethodPut, "/v1/admin/holidays/2023-10-12", strings.NewReader(`{"name": "Fiesta Nacional de España"}`))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_SaveHolidayHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{name: "invalid date", path: "/v1/admin/holidays/12-10-2023", body: `{"name": "Fiesta Nacional de España"}`},
+		{name: "missing name", path: "/v1/admin/holidays/2023-10-12", body: `{}`},
+		{name: "invalid body", path: "/v1/admin/holidays/2023-10-12", body: `name`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repositoryMock := new(mocks.HolidaysRepository)
+			tariffPeriodsService := services.NewTariffPeriodsService(repositoryMock)
+
+			r := gin.New()
+			r.PUT("/v1/admin/holidays/:date", SaveHolidayHandlerV1(tariffPeriodsService))
+
+			req, err := http.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
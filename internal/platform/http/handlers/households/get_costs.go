@@ -0,0 +1,94 @@
+package households
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+type dailyCostResponse struct {
+	Date               string  `json:"date"`
+	Kwh                float64 `json:"kwh"`
+	Cost               float64 `json:"cost"`
+	MissingPricesHours int     `json:"missing_prices_hours"`
+}
+
+type hourlyCostResponse struct {
+	Datetime string   `json:"datetime"`
+	Kwh      float64  `json:"kwh"`
+	Price    *float64 `json:"price"`
+	Cost     *float64 `json:"cost"`
+}
+
+// GetCostsHandlerV1 returns a gin.HandlerFunc to report the cost of a household's consumption at the
+// stored PVPC prices, from and to (YYYY-MM-DD) both included. The group_by query param
+// is "day" (default, also used when it is invalid) or "hour". Hours without a stored price are reported without cost.
+func GetCostsHandlerV1(householdsService services.HouseholdsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		cups, err := domain.NewCUPS(ctx.Param("cups"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		from, err := time.Parse(time.DateOnly, ctx.Query("from"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid costs from date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+		to, err := time.Parse(time.DateOnly, ctx.Query("to"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid costs to date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		if ctx.Query("group_by") == "hour" {
+			costs, err := householdsService.HourlyCosts(ctx, cups, from, to)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(err)
+				ctx.JSON(statusCode, response)
+				return
+			}
+			response := make([]hourlyCostResponse, len(costs))
+			for i, cost := range costs {
+				response[i] = hourlyCostResponse{
+					Datetime: cost.Datetime.UTC().Format(time.RFC3339),
+					Kwh:      cost.Kwh,
+					Price:    cost.Price,
+					Cost:     cost.Cost,
+				}
+			}
+			ctx.JSON(http.StatusOK, response)
+			return
+		}
+		if groupBy := ctx.Query("group_by"); groupBy != "" && groupBy != "day" {
+			logger.DebugContext(ctx, "Invalid group_by", "group_by", groupBy)
+		}
+
+		costs, err := householdsService.DailyCosts(ctx, cups, from, to)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		response := make([]dailyCostResponse, len(costs))
+		for i, cost := range costs {
+			response[i] = dailyCostResponse{
+				Date:               cost.Date.Format(time.DateOnly),
+				Kwh:                cost.Kwh,
+				Cost:               cost.Cost,
+				MissingPricesHours: cost.MissingPricesHours,
+			}
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,112 @@
+package households
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_GetCostsHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	household, err := domain.NewHousehold(domain.HouseholdDto{CUPS: "ES0021000000000001AA", ZoneID: "PEN"})
+	require.NoError(t, err)
+	var consumption []domain.HourlyConsumption
+	for _, dto := range []domain.HourlyConsumptionDto{
+		{Datetime: "2023-10-11T08:00:00Z", Kwh: 1.5},
+		{Datetime: "2023-10-11T09:00:00Z", Kwh: 0.5},
+	} {
+		c, err := domain.NewHourlyConsumption(dto)
+		require.NoError(t, err)
+		consumption = append(consumption, c)
+	}
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:     "PEN-2023-10-11",
+		Date:   "2023-10-11T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-11T10:00:00+02:00", Value: 200}},
+	})
+	require.NoError(t, err)
+	day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+
+	for _, groupBy := range []string{"day", "hour"} {
+		t.Run(groupBy, func(t *testing.T) {
+			householdsRepositoryMock := new(mocks.HouseholdsRepository)
+			pricesRepositoryMock := new(mocks.PricesRepository)
+			householdsRepositoryMock.On("Get", mock.Anything, household.CUPS()).Return(household, nil)
+			householdsRepositoryMock.On("QueryConsumption", mock.Anything, household.CUPS(), mock.Anything, mock.Anything).Return(consumption, nil)
+			zoneID := household.ZoneID()
+			pricesRepositoryMock.On("Query", mock.Anything, &zoneID, &day).Return([]domain.Prices{prices}, nil)
+			zonesRepositoryMock := new(mocks.ZonesRepository)
+			zonesRepositoryMock.On("GetByID", mock.Anything, zoneID).Return(prices.Zone(), nil)
+
+			r := gin.New()
+			r.GET("/v1/admin/households/:cups/costs", GetCostsHandlerV1(newTestHouseholdsService(householdsRepositoryMock, zonesRepositoryMock, pricesRepositoryMock)))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/admin/households/ES0021000000000001AA/costs?from=2023-10-11&to=2023-10-11&group_by="+groupBy, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			householdsRepositoryMock.AssertExpectations(t)
+			pricesRepositoryMock.AssertExpectations(t)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_GetCostsHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		url    string
+		status int
+	}{
+		{name: "invalid CUPS", url: "/v1/admin/households/ES00/costs?from=2023-10-11&to=2023-10-11", status: http.StatusBadRequest},
+		{name: "invalid from", url: "/v1/admin/households/ES0021000000000001AA/costs?from=11-10-2023&to=2023-10-11", status: http.StatusBadRequest},
+		{name: "missing to", url: "/v1/admin/households/ES0021000000000001AA/costs?from=2023-10-11", status: http.StatusBadRequest},
+		{name: "unknown household", url: "/v1/admin/households/ES0021000000000001AA/costs?from=2023-10-11&to=2023-10-11", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			householdsRepositoryMock := new(mocks.HouseholdsRepository)
+			householdsRepositoryMock.On("Get", mock.Anything, mock.Anything).Return(domain.Household{}, errors.NewDomainError(errors.HouseholdNotFound, "household not found")).Maybe()
+
+			r := gin.New()
+			r.GET("/v1/admin/households/:cups/costs", GetCostsHandlerV1(newTestHouseholdsService(householdsRepositoryMock, new(mocks.ZonesRepository), new(mocks.PricesRepository))))
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
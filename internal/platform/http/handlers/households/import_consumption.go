@@ -0,0 +1,91 @@
+package households
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/platform/importers/consumptioncsv"
+	"pvpc-backend/internal/services"
+)
+
+// maxUploadSize is the biggest consumption CSV that can be uploaded, enough for a few years of hourly readings.
+const maxUploadSize = 32 << 20
+
+type importConsumptionResponse struct {
+	Households []importedHouseholdResponse `json:"households"`
+}
+
+type importedHouseholdResponse struct {
+	CUPS  string `json:"cups"`
+	Hours int    `json:"hours"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ImportConsumptionHandlerV1 returns a gin.HandlerFunc to import the hourly consumption CSV export of a
+// distributor or Datadis, sent as the request body or as the "file" field of a multipart form.
+// The zone_id query param is the zone the households in the export are billed in.
+func ImportConsumptionHandlerV1(householdsService services.HouseholdsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		zoneID, err := domain.NewZoneID(ctx.Query("zone_id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		body, err := uploadedFile(ctx)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid consumption upload"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+		defer body.Close()
+
+		readings, err := consumptioncsv.Parse(body)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		imported, err := householdsService.ImportConsumption(ctx, zoneID, readings)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := importConsumptionResponse{Households: make([]importedHouseholdResponse, len(imported))}
+		for i, household := range imported {
+			response.Households[i] = importedHouseholdResponse{
+				CUPS:  household.CUPS.String(),
+				Hours: household.Hours,
+				From:  household.From.UTC().Format(time.RFC3339),
+				To:    household.To.UTC().Format(time.RFC3339),
+			}
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
+
+// uploadedFile returns the "file" field of a multipart form request, or the request body otherwise.
+func uploadedFile(ctx *gin.Context) (io.ReadCloser, error) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadSize)
+	if !strings.HasPrefix(ctx.ContentType(), "multipart/") {
+		return ctx.Request.Body, nil
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	return header.Open()
+}
@@ -0,0 +1,121 @@
+package households
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+const testExport = "CUPS;Fecha;Hora;AE_kWh;REAL/ESTIMADO\n" +
+	"ES0021000000000001AA;29/10/2023;1;0,2;R\n" +
+	"ES0021000000000001AA;29/10/2023;25;0,3;R\n" +
+	"ES0021000000000002BB;29/10/2023;1;1,5;R\n"
+
+func newTestHouseholdsService(householdsRepository *mocks.HouseholdsRepository, zonesRepository *mocks.ZonesRepository, pricesRepository *mocks.PricesRepository) services.HouseholdsService {
+	return services.NewHouseholdsService(householdsRepository, zonesRepository, pricesRepository)
+}
+
+func Test_ImportConsumptionHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	newRequest := map[string]func(t *testing.T) *http.Request{
+		"raw body": func(t *testing.T) *http.Request {
+			req, err := http.NewRequest(http.MethodPost, "/v1/admin/households/consumption?zone_id=PEN", strings.NewReader(testExport))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "text/csv")
+			return req
+		},
+		"multipart form": func(t *testing.T) *http.Request {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, err := writer.CreateFormFile("file", "consumo.csv")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(testExport))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/admin/households/consumption?zone_id=PEN", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			return req
+		},
+	}
+
+	for name, newRequest := range newRequest {
+		t.Run(name, func(t *testing.T) {
+			householdsRepositoryMock := new(mocks.HouseholdsRepository)
+			zonesRepositoryMock := new(mocks.ZonesRepository)
+			zone, err := domain.NewZone(domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"})
+			require.NoError(t, err)
+			zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil)
+			householdsRepositoryMock.On("Save", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			r := gin.New()
+			r.POST("/v1/admin/households/consumption", ImportConsumptionHandlerV1(newTestHouseholdsService(householdsRepositoryMock, zonesRepositoryMock, new(mocks.PricesRepository))))
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, newRequest(t))
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			householdsRepositoryMock.AssertNumberOfCalls(t, "Save", 2)
+			require.Equal(t, http.StatusCreated, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_ImportConsumptionHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		url    string
+		body   string
+		status int
+	}{
+		{name: "invalid zone", url: "/v1/admin/households/consumption?zone_id=xx", body: testExport, status: http.StatusBadRequest},
+		{name: "malformed export", url: "/v1/admin/households/consumption?zone_id=PEN", body: "CUPS;Fecha;Hora;AE_kWh\nES0021000000000001AA;29/10/2023;26;0,2\n", status: http.StatusBadRequest},
+		{name: "unknown zone", url: "/v1/admin/households/consumption?zone_id=BAL", body: testExport, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zonesRepositoryMock := new(mocks.ZonesRepository)
+			zonesRepositoryMock.On("GetByID", mock.Anything, mock.Anything).Return(domain.Zone{}, errors.NewDomainError(errors.ZoneNotFound, "zone not found")).Maybe()
+
+			r := gin.New()
+			r.POST("/v1/admin/households/consumption", ImportConsumptionHandlerV1(newTestHouseholdsService(new(mocks.HouseholdsRepository), zonesRepositoryMock, new(mocks.PricesRepository))))
+
+			req, err := http.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
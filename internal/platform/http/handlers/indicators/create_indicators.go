@@ -0,0 +1,35 @@
+package indicators
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type createIndicatorsResponse struct {
+	IDs []string `json:"IDs"`
+}
+
+// CreateIndicatorsHandlerV1 returns a gin.HandlerFunc to fetch and store the configured indicators.
+func CreateIndicatorsHandlerV1(indicatorsService services.IndicatorsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ids, err := indicatorsService.FetchAndStoreIndicators(ctx)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := createIndicatorsResponse{
+			IDs: make([]string, len(ids)),
+		}
+
+		for i, id := range ids {
+			response.IDs[i] = id.String()
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
@@ -0,0 +1,77 @@
+package indicators
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_CreateIndicatorsV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	id, err := domain.NewIndicatorID("600")
+	require.NoError(t, err)
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{
+		ID:     "600",
+		Name:   "Precio mercado spot diario",
+		Values: []domain.IndicatorValueDto{{Datetime: "2023-09-07T22:00:00Z", GeoID: "3", GeoName: "España", Value: 106.45}},
+	})
+	require.NoError(t, err)
+	ingested := []services.IngestedIndicator{{ID: id, GeoIDs: []string{"3"}}}
+
+	t.Run("stores the fetched indicators", func(t *testing.T) {
+		providerMock := new(mocks.IndicatorsProvider)
+		providerMock.On("FetchIndicator", mock.Anything, id, []string{"3"}, mock.Anything).Return(indicator, nil)
+		repositoryMock := new(mocks.IndicatorsRepository)
+		repositoryMock.On("Save", mock.Anything, indicator).Return(nil)
+
+		r := gin.New()
+		r.POST("/v1/indicators", CreateIndicatorsHandlerV1(services.NewIndicatorsService(providerMock, repositoryMock, ingested)))
+
+		req, err := http.NewRequest(http.MethodPost, "/v1/indicators", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		snaps.MatchSnapshot(t, rec.Body.String())
+	})
+
+	t.Run("fails when the indicators can't be fetched", func(t *testing.T) {
+		providerMock := new(mocks.IndicatorsProvider)
+		providerMock.On("FetchIndicator", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.Indicator{}, errors.NewDomainError(errors.ProviderError, "error fetching indicator from Esios API"))
+
+		r := gin.New()
+		r.POST("/v1/indicators", CreateIndicatorsHandlerV1(services.NewIndicatorsService(providerMock, new(mocks.IndicatorsRepository), ingested)))
+
+		req, err := http.NewRequest(http.MethodPost, "/v1/indicators", nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		snaps.MatchSnapshot(t, rec.Body.String())
+	})
+}
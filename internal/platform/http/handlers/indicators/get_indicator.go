@@ -0,0 +1,111 @@
+package indicators
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+type getIndicatorResponse struct {
+	ID     string                   `json:"id"`
+	Name   string                   `json:"name"`
+	Values []indicatorValueResponse `json:"values"`
+}
+
+type indicatorValueResponse struct {
+	Datetime string  `json:"datetime"`
+	GeoID    string  `json:"geo_id"`
+	GeoName  string  `json:"geo_name"`
+	Value    float64 `json:"value"`
+}
+
+// GetIndicatorHandlerV1 returns a gin.HandlerFunc to retrieve the stored values of an indicator.
+//
+// The from and to query params (YYYY-MM-DD) are the first and last days to return, both included.
+// They default to today and to from respectively. The geo_id query param filters the values by geo.
+func GetIndicatorHandlerV1(indicatorsService services.IndicatorsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := domain.NewIndicatorID(ctx.Param("id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		params := parseGetIndicatorParams(ctx, ctx.Request.URL.Query())
+
+		indicator, err := indicatorsService.GetIndicator(ctx, id, params.geoID, params.from, params.to)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := getIndicatorResponse{
+			ID:     indicator.ID().String(),
+			Name:   indicator.Name(),
+			Values: make([]indicatorValueResponse, len(indicator.Values())),
+		}
+
+		for i, value := range indicator.Values() {
+			response.Values[i] = indicatorValueResponse{
+				Datetime: value.Datetime().Format(time.RFC3339),
+				GeoID:    value.GeoID(),
+				GeoName:  value.GeoName(),
+				Value:    value.Value(),
+			}
+		}
+
+		if len(response.Values) == 0 {
+			ctx.JSON(http.StatusNotFound, response)
+		} else {
+			ctx.JSON(http.StatusOK, response)
+		}
+	}
+}
+
+type getIndicatorParams struct {
+	geoID *string
+	from  time.Time
+	to    time.Time
+}
+
+func parseGetIndicatorParams(ctx context.Context, params url.Values) getIndicatorParams {
+	now := time.Now()
+	parsed := getIndicatorParams{
+		from: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	if geoID := params.Get("geo_id"); geoID != "" {
+		parsed.geoID = &geoID
+	}
+	if from := parseDateParamValue(ctx, params["from"]); from != nil {
+		parsed.from = *from
+	}
+	parsed.to = parsed.from
+	if to := parseDateParamValue(ctx, params["to"]); to != nil {
+		parsed.to = *to
+	}
+
+	return parsed
+}
+
+func parseDateParamValue(ctx context.Context, date []string) *time.Time {
+	if len(date) == 0 {
+		return nil
+	}
+	parsedDate, err := time.Parse("2006-01-02", date[0])
+	if err != nil {
+		logger.DebugContext(ctx, "Invalid date", "date", date[0], "err", err)
+		return nil
+	}
+	return &parsedDate
+}
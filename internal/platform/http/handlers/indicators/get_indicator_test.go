@@ -0,0 +1,120 @@
+package indicators
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_GetIndicatorV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.IndicatorsRepository)
+	indicatorsService := services.NewIndicatorsService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/indicators/:id", GetIndicatorHandlerV1(indicatorsService))
+
+	id, err := domain.NewIndicatorID("600")
+	require.NoError(t, err)
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{
+		ID:   "600",
+		Name: "Precio mercado spot diario",
+		Values: []domain.IndicatorValueDto{
+			{Datetime: "2023-09-07T22:00:00Z", GeoID: "3", GeoName: "España", Value: 106.45},
+			{Datetime: "2023-09-08T22:00:00Z", GeoID: "3", GeoName: "España", Value: 98.2},
+		},
+	})
+	require.NoError(t, err)
+
+	geoID := "3"
+	repositoryMock.On("Query", mock.Anything, id, &geoID, mock.Anything, mock.Anything).Return(indicator, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/indicators/600?from=2023-09-08&to=2023-09-09&geo_id=3", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	from := repositoryMock.Calls[0].Arguments.Get(3).(time.Time)
+	to := repositoryMock.Calls[0].Arguments.Get(4).(time.Time)
+	require.Equal(t, "2023-09-08T00:00:00+02:00", from.Format(time.RFC3339))
+	require.Equal(t, "2023-09-10T00:00:00+02:00", to.Format(time.RFC3339))
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetIndicatorV1_Empty(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.IndicatorsRepository)
+	indicatorsService := services.NewIndicatorsService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/indicators/:id", GetIndicatorHandlerV1(indicatorsService))
+
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{ID: "600", Name: "Precio mercado spot diario"})
+	require.NoError(t, err)
+	repositoryMock.On("Query", mock.Anything, mock.Anything, (*string)(nil), mock.Anything, mock.Anything).Return(indicator, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/indicators/600?from=2023-09-08", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetIndicatorV1_Error(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	for name, path := range map[string]string{
+		"invalid ID":         "/v1/indicators/spot",
+		"invalid date range": "/v1/indicators/600?from=2023-09-08&to=2023-09-07",
+		"unknown indicator":  "/v1/indicators/601",
+	} {
+		t.Run(name, func(t *testing.T) {
+			repositoryMock := new(mocks.IndicatorsRepository)
+			repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(domain.Indicator{}, errors.NewDomainError(errors.IndicatorNotFound, "Indicator with ID 601 not found"))
+			indicatorsService := services.NewIndicatorsService(nil, repositoryMock, nil)
+
+			r := gin.New()
+			r.GET("/v1/indicators/:id", GetIndicatorHandlerV1(indicatorsService))
+
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			snaps.MatchSnapshot(t, res.StatusCode, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package plans
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type planAppliancesRequest struct {
+	ZoneID     string             `json:"zone_id"`
+	Start      string             `json:"start"`
+	MaxPowerKw float64            `json:"max_power_kw"`
+	Appliances []applianceRequest `json:"appliances"`
+}
+
+type applianceRequest struct {
+	Name            string  `json:"name"`
+	DurationMinutes int     `json:"duration_minutes"`
+	PowerKw         float64 `json:"power_kw"`
+	EarliestStart   string  `json:"earliest_start"`
+	LatestEnd       string  `json:"latest_end"`
+}
+
+type appliancesPlanResponse struct {
+	ZoneID        string                 `json:"zone_id"`
+	Cost          float64                `json:"cost"`
+	NaiveCost     float64                `json:"naive_cost"`
+	Savings       float64                `json:"savings"`
+	Schedule      []applianceRunResponse `json:"schedule"`
+	NaiveSchedule []applianceRunResponse `json:"naive_schedule"`
+}
+
+type applianceRunResponse struct {
+	Name    string  `json:"name"`
+	Start   string  `json:"start"`
+	End     string  `json:"end"`
+	PowerKw float64 `json:"power_kw"`
+	Kwh     float64 `json:"kwh"`
+	Cost    float64 `json:"cost"`
+}
+
+// PlanAppliancesHandlerV1 returns a gin.HandlerFunc to schedule several appliances at the cheapest hours
+// of the published prices of a zone, from start (RFC 3339, now by default) on. Every appliance runs
+// without interruption for its duration, drawing its power, within its optional time window, and all of
+// them together can't draw more than max_power_kw. The schedule is compared with running them right away.
+func PlanAppliancesHandlerV1(plansService services.PlansService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request planAppliancesRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid appliances plan request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		scheduling, err := newAppliancesScheduling(request)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		plan, err := plansService.PlanAppliances(ctx, scheduling)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, appliancesPlanResponse{
+			ZoneID:        plan.ZoneID.String(),
+			Cost:          plan.Cost,
+			NaiveCost:     plan.NaiveCost,
+			Savings:       plan.Savings,
+			Schedule:      newApplianceRunsResponse(plan.Runs),
+			NaiveSchedule: newApplianceRunsResponse(plan.NaiveRuns),
+		})
+	}
+}
+
+func newAppliancesScheduling(request planAppliancesRequest) (services.AppliancesScheduling, error) {
+	zoneID, err := domain.NewZoneID(request.ZoneID)
+	if err != nil {
+		return services.AppliancesScheduling{}, err
+	}
+	start, err := parseOptionalTime(request.Start, "start")
+	if err != nil {
+		return services.AppliancesScheduling{}, err
+	}
+
+	appliances := make([]services.Appliance, len(request.Appliances))
+	for i, a := range request.Appliances {
+		appliances[i] = services.Appliance{Name: a.Name, Duration: time.Duration(a.DurationMinutes) * time.Minute, PowerKw: a.PowerKw}
+		if appliances[i].EarliestStart, err = parseOptionalTime(a.EarliestStart, fmt.Sprintf("appliance %s earliest start", a.Name)); err != nil {
+			return services.AppliancesScheduling{}, err
+		}
+		if appliances[i].LatestEnd, err = parseOptionalTime(a.LatestEnd, fmt.Sprintf("appliance %s latest end", a.Name)); err != nil {
+			return services.AppliancesScheduling{}, err
+		}
+	}
+
+	return services.AppliancesScheduling{
+		ZoneID:     zoneID,
+		Start:      start,
+		MaxPowerKw: request.MaxPowerKw,
+		Appliances: appliances,
+	}, nil
+}
+
+// parseOptionalTime parses an RFC 3339 time, returning the zero time if value is empty.
+func parseOptionalTime(value, name string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing %s: %s", name, value))
+	}
+	return t, nil
+}
+
+func newApplianceRunsResponse(runs []services.ApplianceRun) []applianceRunResponse {
+	response := make([]applianceRunResponse, len(runs))
+	for i, run := range runs {
+		response[i] = applianceRunResponse{
+			Name:    run.Name,
+			Start:   run.Start.UTC().Format(time.RFC3339),
+			End:     run.End.UTC().Format(time.RFC3339),
+			PowerKw: run.PowerKw,
+			Kwh:     run.Kwh,
+			Cost:    run.Cost,
+		}
+	}
+	return response
+}
@@ -0,0 +1,57 @@
+package plans
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_PlanAppliancesHandlerV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "success", body: `{"zone_id": "PEN", "start": "2023-10-11T00:00:00+02:00", "max_power_kw": 3.3, "appliances": [
+			{"name": "washing machine", "duration_minutes": 120, "power_kw": 2},
+			{"name": "dishwasher", "duration_minutes": 90, "power_kw": 1.8, "latest_end": "2023-10-11T06:00:00+02:00"}
+		]}`, status: http.StatusOK},
+		{name: "malformed body", body: `{"zone_id": `, status: http.StatusBadRequest},
+		{name: "invalid earliest start", body: `{"zone_id": "PEN", "max_power_kw": 3.3, "appliances": [
+			{"name": "oven", "duration_minutes": 60, "power_kw": 2, "earliest_start": "noon"}
+		]}`, status: http.StatusBadRequest},
+		{name: "unschedulable", body: `{"zone_id": "PEN", "start": "2023-10-11T00:00:00+02:00", "max_power_kw": 3.3, "appliances": [
+			{"name": "oven", "duration_minutes": 600, "power_kw": 2}
+		]}`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/v1/plans/appliances", PlanAppliancesHandlerV1(newTestPlansService(t)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/plans/appliances", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package plans
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type planEVChargingRequest struct {
+	ZoneID         string  `json:"zone_id"`
+	EnergyKwh      float64 `json:"energy_kwh"`
+	ChargerPowerKw float64 `json:"charger_power_kw"`
+	Start          string  `json:"start"`
+	Deadline       string  `json:"deadline"`
+}
+
+type chargingPlanResponse struct {
+	ZoneID        string                 `json:"zone_id"`
+	EnergyKwh     float64                `json:"energy_kwh"`
+	Cost          float64                `json:"cost"`
+	ImmediateCost float64                `json:"immediate_cost"`
+	Savings       float64                `json:"savings"`
+	Schedule      []chargingSlotResponse `json:"schedule"`
+}
+
+type chargingSlotResponse struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Kwh   float64 `json:"kwh"`
+	Price float64 `json:"price"`
+	Cost  float64 `json:"cost"`
+}
+
+// PlanEVChargingHandlerV1 returns a gin.HandlerFunc to plan the cheapest EV charging, given the zone,
+// the energy needed (kWh), the charger power (kW), the earliest start (RFC 3339, now by default) and
+// the deadline (RFC 3339). The schedule can be non-contiguous and is compared with charging immediately.
+func PlanEVChargingHandlerV1(plansService services.PlansService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request planEVChargingRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid EV charging plan request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		charging, err := newEVCharging(request)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		plan, err := plansService.PlanEVCharging(ctx, charging)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, newChargingPlanResponse(plan))
+	}
+}
+
+func newEVCharging(request planEVChargingRequest) (services.EVCharging, error) {
+	zoneID, err := domain.NewZoneID(request.ZoneID)
+	if err != nil {
+		return services.EVCharging{}, err
+	}
+
+	start, err := parseOptionalTime(request.Start, "EV charging start")
+	if err != nil {
+		return services.EVCharging{}, err
+	}
+	deadline, err := time.Parse(time.RFC3339, request.Deadline)
+	if err != nil {
+		return services.EVCharging{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing EV charging deadline: %s", request.Deadline))
+	}
+
+	return services.EVCharging{
+		ZoneID:         zoneID,
+		EnergyKwh:      request.EnergyKwh,
+		ChargerPowerKw: request.ChargerPowerKw,
+		Start:          start,
+		Deadline:       deadline,
+	}, nil
+}
+
+func newChargingPlanResponse(plan services.ChargingPlan) chargingPlanResponse {
+	response := chargingPlanResponse{
+		ZoneID:        plan.ZoneID.String(),
+		EnergyKwh:     plan.EnergyKwh,
+		Cost:          plan.Cost,
+		ImmediateCost: plan.ImmediateCost,
+		Savings:       plan.Savings,
+		Schedule:      make([]chargingSlotResponse, len(plan.Slots)),
+	}
+
+	for i, slot := range plan.Slots {
+		response.Schedule[i] = chargingSlotResponse{
+			Start: slot.Start.UTC().Format(time.RFC3339),
+			End:   slot.End.UTC().Format(time.RFC3339),
+			Kwh:   slot.Kwh,
+			Price: slot.Price,
+			Cost:  slot.Cost,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,78 @@
+package plans
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestPlansService(t *testing.T) services.PlansService {
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	dto := domain.PricesDto{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto}
+	for hour, value := range []float64{120, 110, 90, 60, 50, 70, 100, 150} {
+		datetime := time.Date(2023, 10, 11, hour, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: value})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	zoneID := zone.ID()
+	day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+	pricesRepositoryMock.On("Query", mock.Anything, &zoneID, &day).Return([]domain.Prices{prices}, nil).Maybe()
+	pricesRepositoryMock.On("Query", mock.Anything, &zoneID, mock.Anything).Return([]domain.Prices{}, nil).Maybe()
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Maybe()
+	return services.NewPlansService(pricesRepositoryMock, zonesRepositoryMock)
+}
+
+func Test_PlanEVChargingHandlerV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "success", body: `{"zone_id": "PEN", "energy_kwh": 10, "charger_power_kw": 7.4, "start": "2023-10-11T00:00:00+02:00", "deadline": "2023-10-11T07:00:00+02:00"}`, status: http.StatusOK},
+		{name: "malformed body", body: `{"zone_id": `, status: http.StatusBadRequest},
+		{name: "invalid zone", body: `{"zone_id": "xx", "energy_kwh": 10, "charger_power_kw": 7.4, "deadline": "2023-10-11T07:00:00+02:00"}`, status: http.StatusBadRequest},
+		{name: "invalid deadline", body: `{"zone_id": "PEN", "energy_kwh": 10, "charger_power_kw": 7.4, "deadline": "tomorrow"}`, status: http.StatusBadRequest},
+		{name: "not enough time", body: `{"zone_id": "PEN", "energy_kwh": 60, "charger_power_kw": 7.4, "start": "2023-10-11T00:00:00+02:00", "deadline": "2023-10-11T07:00:00+02:00"}`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/v1/plans/ev-charging", PlanEVChargingHandlerV1(newTestPlansService(t)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/plans/ev-charging", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type calculateCostRequest struct {
+	ZoneID        string                     `json:"zone_id"`
+	Consumption   []hourlyConsumptionRequest `json:"consumption"`
+	Profile       *dailyProfileRequest       `json:"profile"`
+	MissingPrices string                     `json:"missing_prices"`
+}
+
+type hourlyConsumptionRequest struct {
+	Datetime string  `json:"datetime"`
+	Kwh      float64 `json:"kwh"`
+}
+
+type dailyProfileRequest struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	Kwh  []float64 `json:"kwh"`
+}
+
+type costResponse struct {
+	ZoneID       string               `json:"zone_id"`
+	TotalKwh     float64              `json:"total_kwh"`
+	CostedKwh    float64              `json:"costed_kwh"`
+	TotalCost    float64              `json:"total_cost"`
+	AveragePrice *float64             `json:"average_price"`
+	MissingHours int                  `json:"missing_hours"`
+	Hours        []hourlyCostResponse `json:"hours"`
+}
+
+type hourlyCostResponse struct {
+	Datetime string   `json:"datetime"`
+	Kwh      float64  `json:"kwh"`
+	Price    *float64 `json:"price"`
+	Cost     *float64 `json:"cost"`
+}
+
+// CalculateCostHandlerV1 returns a gin.HandlerFunc to calculate the cost of a load profile at the stored
+// PVPC prices of a zone. The profile is either a list of hourly consumption (kWh) or a daily profile,
+// the consumption of the 24 hours of the local day repeated from from to to (YYYY-MM-DD) both included.
+// Hours without a stored price fail the calculation, unless missing_prices is "skip".
+func CalculateCostHandlerV1(costsService services.CostsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request calculateCostRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid cost calculation request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		zoneID, consumption, missingPrices, err := parseCalculateCostRequest(ctx, costsService, request)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		report, err := costsService.CalculateCost(ctx, zoneID, consumption, missingPrices)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, newCostResponse(report))
+	}
+}
+
+func parseCalculateCostRequest(ctx *gin.Context, costsService services.CostsService, request calculateCostRequest) (domain.ZoneID, []domain.HourlyConsumption, services.MissingPricesPolicy, error) {
+	zoneID, err := domain.NewZoneID(request.ZoneID)
+	if err != nil {
+		return domain.ZoneID{}, nil, "", err
+	}
+	missingPrices, err := services.NewMissingPricesPolicy(request.MissingPrices)
+	if err != nil {
+		return domain.ZoneID{}, nil, "", err
+	}
+	if (request.Profile == nil) == (len(request.Consumption) == 0) {
+		return domain.ZoneID{}, nil, "", errors.NewDomainError(errors.InvalidRequestBody, "either consumption or profile must be given")
+	}
+
+	if request.Profile != nil {
+		from, err := time.Parse(time.DateOnly, request.Profile.From)
+		if err != nil {
+			return domain.ZoneID{}, nil, "", errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid profile from date. It must be in the shape of YYYY-MM-DD")
+		}
+		to, err := time.Parse(time.DateOnly, request.Profile.To)
+		if err != nil {
+			return domain.ZoneID{}, nil, "", errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid profile to date. It must be in the shape of YYYY-MM-DD")
+		}
+		consumption, err := costsService.DailyProfileConsumption(ctx, zoneID, from, to, request.Profile.Kwh)
+		return zoneID, consumption, missingPrices, err
+	}
+
+	consumption := make([]domain.HourlyConsumption, len(request.Consumption))
+	for i, c := range request.Consumption {
+		consumption[i], err = domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: c.Datetime, Kwh: c.Kwh})
+		if err != nil {
+			return domain.ZoneID{}, nil, "", err
+		}
+	}
+	return zoneID, consumption, missingPrices, nil
+}
+
+func newCostResponse(report services.CostReport) costResponse {
+	response := costResponse{
+		ZoneID:       report.ZoneID.String(),
+		TotalKwh:     report.TotalKwh,
+		CostedKwh:    report.CostedKwh,
+		TotalCost:    report.TotalCost,
+		AveragePrice: report.AveragePrice,
+		MissingHours: report.MissingHours,
+		Hours:        make([]hourlyCostResponse, len(report.Hours)),
+	}
+
+	for i, hour := range report.Hours {
+		response.Hours[i] = hourlyCostResponse{
+			Datetime: hour.Datetime.UTC().Format(time.RFC3339),
+			Kwh:      hour.Kwh,
+			Price:    hour.Price,
+			Cost:     hour.Cost,
+		}
+	}
+
+	return response
+}
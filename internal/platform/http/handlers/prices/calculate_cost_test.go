@@ -0,0 +1,113 @@
+package prices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestCostsService(t *testing.T) services.CostsService {
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:     "PEN-2023-10-11",
+		Date:   "2023-10-11T00:00:00+02:00",
+		Zone:   zoneDto,
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-11T10:00:00+02:00", Value: 200}, {Datetime: "2023-10-11T11:00:00+02:00", Value: 100}},
+	})
+	require.NoError(t, err)
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	pricesRepositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{prices}, nil).Maybe()
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Maybe()
+	return services.NewCostsService(pricesRepositoryMock, zonesRepositoryMock)
+}
+
+func Test_CalculateCostHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "hourly consumption", body: `{"zone_id": "PEN", "consumption": [
+			{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1.5},
+			{"datetime": "2023-10-11T11:00:00+02:00", "kwh": 0.5}
+		]}`},
+		{name: "daily profile skipping missing prices", body: `{"zone_id": "PEN", "missing_prices": "skip", "profile": {
+			"from": "2023-10-11", "to": "2023-10-11",
+			"kwh": [0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.2, 0.3, 0.3, 0.2, 1, 2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.3, 0.5, 0.8, 0.8, 0.6, 0.3, 0.2]
+		}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/v1/prices/cost", CalculateCostHandlerV1(newTestCostsService(t)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/prices/cost", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_CalculateCostHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "malformed body", body: `{"zone_id": `, status: http.StatusBadRequest},
+		{name: "invalid zone", body: `{"zone_id": "xx", "consumption": [{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1}]}`, status: http.StatusBadRequest},
+		{name: "neither consumption nor profile", body: `{"zone_id": "PEN"}`, status: http.StatusBadRequest},
+		{name: "invalid missing prices policy", body: `{"zone_id": "PEN", "missing_prices": "zero", "consumption": [{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1}]}`, status: http.StatusBadRequest},
+		{name: "short profile", body: `{"zone_id": "PEN", "profile": {"from": "2023-10-11", "to": "2023-10-11", "kwh": [1, 2]}}`, status: http.StatusBadRequest},
+		{name: "missing prices", body: `{"zone_id": "PEN", "consumption": [{"datetime": "2023-10-11T12:00:00+02:00", "kwh": 1}]}`, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/v1/prices/cost", CalculateCostHandlerV1(newTestCostsService(t)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/prices/cost", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type pricesComparisonResponse struct {
+	ZoneID        string                           `json:"zone_id"`
+	Date          string                           `json:"date"`
+	Against       string                           `json:"against"`
+	Mean          float64                          `json:"mean"`
+	AgainstMean   float64                          `json:"against_mean"`
+	MeanChange    float64                          `json:"mean_change"`
+	MeanChangePct *float64                         `json:"mean_change_pct"`
+	Hours         []hourlyPricesComparisonResponse `json:"hours"`
+	Movers        []hourlyPricesComparisonResponse `json:"movers"`
+}
+
+type hourlyPricesComparisonResponse struct {
+	Hour            string   `json:"hour"`
+	Datetime        *string  `json:"datetime"`
+	Value           *float64 `json:"value"`
+	AgainstDatetime *string  `json:"against_datetime"`
+	AgainstValue    *float64 `json:"against_value"`
+	Change          *float64 `json:"change"`
+	ChangePct       *float64 `json:"change_pct"`
+}
+
+// ComparePricesHandlerV1 returns a gin.HandlerFunc to compare the PVPC prices of a zone on a day (YYYY-MM-DD),
+// today by default, with the ones of the day set by against: yesterday (default), last_week, last_year or
+// a YYYY-MM-DD day. It returns the change of every hour, matched by local time, and of the daily mean,
+// in €/MWh and in percent, and the three hours whose prices changed the most.
+func ComparePricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		zoneID, err := domain.NewZoneID(ctx.Query("zone_id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		var date *time.Time
+		if value := ctx.Query("date"); value != "" {
+			parsed, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid comparison date. It must be in the shape of YYYY-MM-DD"))
+				ctx.JSON(statusCode, response)
+				return
+			}
+			date = &parsed
+		}
+
+		comparison, err := pricesService.ComparePrices(ctx, zoneID, date, ctx.Query("against"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := pricesComparisonResponse{
+			ZoneID:        comparison.ZoneID.String(),
+			Date:          comparison.Date.Format(time.DateOnly),
+			Against:       comparison.Against.Format(time.DateOnly),
+			Mean:          comparison.Mean,
+			AgainstMean:   comparison.AgainstMean,
+			MeanChange:    comparison.MeanChange,
+			MeanChangePct: comparison.MeanChangePct,
+			Hours:         make([]hourlyPricesComparisonResponse, len(comparison.Hours)),
+			Movers:        make([]hourlyPricesComparisonResponse, len(comparison.Movers)),
+		}
+		for i, hour := range comparison.Hours {
+			response.Hours[i] = newHourlyPricesComparisonResponse(hour)
+		}
+		for i, hour := range comparison.Movers {
+			response.Movers[i] = newHourlyPricesComparisonResponse(hour)
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+func newHourlyPricesComparisonResponse(hour services.HourlyPricesComparison) hourlyPricesComparisonResponse {
+	response := hourlyPricesComparisonResponse{
+		Hour:         hour.Hour,
+		Value:        hour.Value,
+		AgainstValue: hour.AgainstValue,
+		Change:       hour.Change,
+		ChangePct:    hour.ChangePct,
+	}
+	if hour.Datetime != nil {
+		datetime := hour.Datetime.Format(time.RFC3339)
+		response.Datetime = &datetime
+	}
+	if hour.AgainstDatetime != nil {
+		datetime := hour.AgainstDatetime.Format(time.RFC3339)
+		response.AgainstDatetime = &datetime
+	}
+	return response
+}
@@ -0,0 +1,62 @@
+package prices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_ComparePricesHandlerV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	zoneID, err := domain.NewZoneID("PEN")
+	require.NoError(t, err)
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	pricesRepositoryMock.On("Query", mock.Anything, &zoneID, parseTestDate(t, "2023-10-11")).
+		Return([]domain.Prices{newTestPrices(t, "2023-10-11", map[int]float64{0: 100, 1: 120, 2: 90, 3: 80, 4: 50})}, nil)
+	pricesRepositoryMock.On("Query", mock.Anything, &zoneID, parseTestDate(t, "2022-10-11")).
+		Return([]domain.Prices{newTestPrices(t, "2022-10-11", map[int]float64{0: 200, 1: 100, 2: 90, 3: 100, 4: 0})}, nil)
+	pricesRepositoryMock.On("Query", mock.Anything, &zoneID, parseTestDate(t, "2023-10-10")).Return([]domain.Prices{}, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices/compare", ComparePricesHandlerV1(services.NewPricesService(nil, pricesRepositoryMock, nil)))
+
+	tests := map[string]struct {
+		url        string
+		statusCode int
+	}{
+		"against the same day of last year": {url: "/v1/prices/compare?zone_id=PEN&date=2023-10-11&against=last_year", statusCode: http.StatusOK},
+		"against a day without prices":      {url: "/v1/prices/compare?zone_id=PEN&date=2023-10-11", statusCode: http.StatusNotFound},
+		"against an invalid day":            {url: "/v1/prices/compare?zone_id=PEN&date=2023-10-11&against=last_month", statusCode: http.StatusBadRequest},
+		"with an invalid date":              {url: "/v1/prices/compare?zone_id=PEN&date=11-10-2023", statusCode: http.StatusBadRequest},
+		"without zone":                      {url: "/v1/prices/compare?date=2023-10-11", statusCode: http.StatusBadRequest},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, test.url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, test.statusCode, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
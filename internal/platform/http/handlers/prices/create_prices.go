@@ -7,14 +7,19 @@ import (
 
 	"pvpc-backend/internal/platform/http/responses"
 	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
 )
 
 type createPricesResponse struct {
-	IDs []string `json:"IDs"`
+	IDs        []string `json:"IDs"`
+	SurplusIDs []string `json:"surplusIDs,omitempty"`
 }
 
-// CreatePricesHandlerV1 returns a gin.HandlerFunc to fetch and store PVPC prices.
-func CreatePricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+// CreatePricesHandlerV1 returns a gin.HandlerFunc to fetch and store PVPC prices,
+// along with the self-consumption surplus compensation ones. The accuracy of the forecasts
+// of the stored PVPC prices is recorded and the prices of tomorrow not published yet are
+// forecast; failing to do so, or to store the surplus prices, doesn't fail the request.
+func CreatePricesHandlerV1(pricesService services.PricesService, forecastsService services.ForecastsService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		ids, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		if err != nil {
@@ -22,6 +27,17 @@ func CreatePricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc
 			ctx.JSON(statusCode, response)
 			return
 		}
+		if err := forecastsService.RecordAccuracy(ctx, ids); err != nil {
+			logger.ErrorContext(ctx, "couldn't record the forecasts accuracy", "err", err)
+		}
+		if err := forecastsService.StoreForecasts(ctx); err != nil {
+			logger.ErrorContext(ctx, "couldn't store the forecasts", "err", err)
+		}
+
+		surplusIDs, err := pricesService.FetchAndStoreSurplusPricesFromREE(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx, "couldn't fetch and store the surplus prices", "err", err)
+		}
 
 		response := createPricesResponse{
 			IDs: make([]string, len(ids)),
@@ -30,6 +46,9 @@ func CreatePricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc
 		for i, id := range ids {
 			response.IDs[i] = id.String()
 		}
+		for _, id := range surplusIDs {
+			response.SurplusIDs = append(response.SurplusIDs, id.String())
+		}
 		ctx.JSON(http.StatusCreated, response)
 	}
 }
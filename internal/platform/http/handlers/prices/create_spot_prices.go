@@ -0,0 +1,31 @@
+package prices
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+// CreateSpotPricesHandlerV1 returns a gin.HandlerFunc to fetch and store the day-ahead market (spot) prices.
+func CreateSpotPricesHandlerV1(spotPricesService services.SpotPricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ids, err := spotPricesService.FetchAndStoreSpotPrices(ctx)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := createPricesResponse{
+			IDs: make([]string, len(ids)),
+		}
+
+		for i, id := range ids {
+			response.IDs[i] = id.String()
+		}
+		ctx.JSON(http.StatusCreated, response)
+	}
+}
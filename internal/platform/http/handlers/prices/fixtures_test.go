@@ -0,0 +1,46 @@
+package prices
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+)
+
+// newTestPrices returns the PEN prices of date (YYYY-MM-DD), in CEST, with the value of each hour in values.
+func newTestPrices(t *testing.T, date string, values map[int]float64) domain.Prices {
+	t.Helper()
+	dto := domain.PricesDto{ID: "PEN-" + date, Date: date + "T00:00:00+02:00", Zone: domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}}
+	hours := make([]int, 0, len(values))
+	for hour := range values {
+		hours = append(hours, hour)
+	}
+	sort.Ints(hours)
+	for _, hour := range hours {
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: fmt.Sprintf("%sT%02d:00:00+02:00", date, hour), Value: values[hour]})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return prices
+}
+
+func newTestPricesAggregate(t *testing.T, periodStart string, mean float64, peakMean *float64) domain.PricesAggregate {
+	t.Helper()
+	aggregate, err := domain.NewPricesAggregate(domain.PricesAggregateDto{
+		ZoneID: "PEN", Granularity: "month", PeriodStart: periodStart, Days: 30, Hours: 720,
+		Min: mean - 50, Max: mean + 50, Mean: mean, WeightedMean: mean + 0.123, PeakMean: peakMean, OffpeakMean: &mean,
+	})
+	require.NoError(t, err)
+	return aggregate
+}
+
+func parseTestDate(t *testing.T, value string) *time.Time {
+	t.Helper()
+	date, err := time.Parse(time.DateOnly, value)
+	require.NoError(t, err)
+	return &date
+}
@@ -0,0 +1,94 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type forecastResponse struct {
+	ZoneID       string                  `json:"zone_id"`
+	Date         string                  `json:"date"`
+	Forecast     bool                    `json:"forecast"`
+	Model        string                  `json:"model"`
+	Confidence   float64                 `json:"confidence"`
+	BacktestMAE  float64                 `json:"backtest_mae"`
+	BacktestRMSE float64                 `json:"backtest_rmse"`
+	Values       []forecastValueResponse `json:"values"`
+}
+
+type forecastValueResponse struct {
+	Datetime string  `json:"datetime"`
+	Value    float64 `json:"value"`
+	Lower    float64 `json:"lower"`
+	Upper    float64 `json:"upper"`
+}
+
+// GetForecastHandlerV1 returns a gin.HandlerFunc to forecast the PVPC prices of a zone for a day (YYYY-MM-DD),
+// tomorrow by default, before they are published. The model param selects the forecast model; by default,
+// the one with the lowest error forecasting the last days is used. The response is flagged as a forecast
+// and every hour comes with the band the real price is expected to fall within.
+func GetForecastHandlerV1(forecastsService services.ForecastsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		zoneID, err := domain.NewZoneID(ctx.Query("zone_id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		var date *time.Time
+		if value := ctx.Query("date"); value != "" {
+			parsed, err := time.Parse(time.DateOnly, value)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid forecast date. It must be in the shape of YYYY-MM-DD"))
+				ctx.JSON(statusCode, response)
+				return
+			}
+			date = &parsed
+		}
+		var model *domain.ForecastModel
+		if value := ctx.Query("model"); value != "" {
+			parsed, err := domain.NewForecastModel(value)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(err)
+				ctx.JSON(statusCode, response)
+				return
+			}
+			model = &parsed
+		}
+
+		forecast, err := forecastsService.Forecast(ctx, zoneID, date, model)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		dto := forecast.Forecast.Serialize()
+		response := forecastResponse{
+			ZoneID:       dto.ZoneID,
+			Date:         dto.Date,
+			Forecast:     true,
+			Model:        dto.Model,
+			Confidence:   services.ForecastConfidence,
+			BacktestMAE:  forecast.BacktestMAE,
+			BacktestRMSE: forecast.BacktestRMSE,
+			Values:       make([]forecastValueResponse, len(dto.Values)),
+		}
+		for i, value := range dto.Values {
+			response.Values[i] = forecastValueResponse{
+				Datetime: value.Datetime,
+				Value:    value.Value,
+				Lower:    value.Lower,
+				Upper:    value.Upper,
+			}
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
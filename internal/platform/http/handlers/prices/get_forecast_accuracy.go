@@ -0,0 +1,71 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type forecastAccuracyResponse struct {
+	Date       string  `json:"date"`
+	Model      string  `json:"model"`
+	Hours      int     `json:"hours"`
+	MAE        float64 `json:"mae"`
+	RMSE       float64 `json:"rmse"`
+	Bias       float64 `json:"bias"`
+	RecordedAt string  `json:"recorded_at"`
+}
+
+// GetForecastAccuracyHandlerV1 returns a gin.HandlerFunc to list how accurate the forecasts of a zone were,
+// from and to (YYYY-MM-DD) both included. The errors are in €/MWh and the bias is positive when the forecast
+// was too high. Only the forecasts made before the real prices were stored are measured.
+func GetForecastAccuracyHandlerV1(forecastsService services.ForecastsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		zoneID, err := domain.NewZoneID(ctx.Query("zone_id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		from, err := time.Parse(time.DateOnly, ctx.Query("from"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid accuracy from date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+		to, err := time.Parse(time.DateOnly, ctx.Query("to"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid accuracy to date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		accuracies, err := forecastsService.ListAccuracy(ctx, zoneID, from, to)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := make([]forecastAccuracyResponse, len(accuracies))
+		for i, accuracy := range accuracies {
+			dto := accuracy.Serialize()
+			response[i] = forecastAccuracyResponse{
+				Date:       dto.Date,
+				Model:      dto.Model,
+				Hours:      dto.Hours,
+				MAE:        dto.MAE,
+				RMSE:       dto.RMSE,
+				Bias:       dto.Bias,
+				RecordedAt: dto.RecordedAt,
+			}
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
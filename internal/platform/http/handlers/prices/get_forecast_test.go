@@ -0,0 +1,174 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+// newTestForecastsService returns a ForecastsService whose prices repository has the PEN prices of
+// 2023-10-01 to 2023-10-14: 100 €/MWh plus the hour on weekdays and 80 plus the hour on weekends.
+func newTestForecastsService(t *testing.T) (services.ForecastsService, *mocks.ForecastsRepository) {
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	cest := time.FixedZone("CEST", 2*60*60)
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	pricesRepositoryMock.On("QueryRange", mock.Anything, zone.ID(), mock.Anything, mock.Anything).Return(func(_ context.Context, _ domain.ZoneID, from, to time.Time) []domain.Prices {
+		var prices []domain.Prices
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			if date.Before(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) || date.After(time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)) {
+				continue
+			}
+			base := 100.0
+			if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+				base = 80
+			}
+			dto := domain.PricesDto{
+				ID:   fmt.Sprintf("PEN-%s", date.Format(time.DateOnly)),
+				Date: time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, cest).Format(time.RFC3339),
+				Zone: zoneDto,
+			}
+			for hour := 0; hour < 24; hour++ {
+				datetime := time.Date(date.Year(), date.Month(), date.Day(), hour, 0, 0, 0, cest)
+				dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: base + float64(hour)})
+			}
+			dayPrices, err := domain.NewPrices(dto)
+			require.NoError(t, err)
+			prices = append(prices, dayPrices)
+		}
+		return prices
+	}, nil).Maybe()
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Maybe()
+	forecastsRepositoryMock := new(mocks.ForecastsRepository)
+
+	return services.NewForecastsService(pricesRepositoryMock, forecastsRepositoryMock, zonesRepositoryMock), forecastsRepositoryMock
+}
+
+func Test_GetForecastHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "best model", query: "zone_id=PEN&date=2023-10-15"},
+		{name: "exponential smoothing", query: "zone_id=PEN&date=2023-10-16&model=exponential_smoothing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecastsService, forecastsRepositoryMock := newTestForecastsService(t)
+			r := gin.New()
+			r.GET("/v1/prices/:id/revisions", func(ctx *gin.Context) {})
+			r.GET("/v1/prices/forecast", GetForecastHandlerV1(forecastsService))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/prices/forecast?"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			forecastsRepositoryMock.AssertExpectations(t)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_GetForecastHandlerV1_InvalidRequest(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "missing zone", query: "date=2023-10-15", status: http.StatusBadRequest},
+		{name: "invalid date", query: "zone_id=PEN&date=15/10/2023", status: http.StatusBadRequest},
+		{name: "invalid model", query: "zone_id=PEN&date=2023-10-15&model=arima", status: http.StatusBadRequest},
+		{name: "not enough history", query: "zone_id=PEN&date=2023-10-05", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecastsService, _ := newTestForecastsService(t)
+			r := gin.New()
+			r.GET("/v1/prices/forecast", GetForecastHandlerV1(forecastsService))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/prices/forecast?"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_GetForecastAccuracyHandlerV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	accuracy, err := domain.NewForecastAccuracy(domain.ForecastAccuracyDto{
+		ZoneID: "PEN", Date: "2023-10-15", Model: "weekday_profile", Hours: 24, MAE: 3, RMSE: 3.16, Bias: 1, RecordedAt: "2023-10-14T20:30:00Z",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "success", query: "zone_id=PEN&from=2023-10-15&to=2023-10-16", status: http.StatusOK},
+		{name: "missing dates", query: "zone_id=PEN", status: http.StatusBadRequest},
+		{name: "reversed dates", query: "zone_id=PEN&from=2023-10-16&to=2023-10-15", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forecastsService, forecastsRepositoryMock := newTestForecastsService(t)
+			forecastsRepositoryMock.On("ListAccuracy", mock.Anything, accuracy.ZoneID(), time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 16, 0, 0, 0, 0, time.UTC)).
+				Return([]domain.ForecastAccuracy{accuracy}, nil).Maybe()
+			r := gin.New()
+			r.GET("/v1/prices/forecast/accuracy", GetForecastAccuracyHandlerV1(forecastsService))
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/prices/forecast/accuracy?"+tt.query, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
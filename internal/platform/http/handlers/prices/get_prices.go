@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -19,23 +21,49 @@ type getPricesResponse struct {
 }
 
 type pricesResponse struct {
-	Date   string                `json:"date"`
-	ZoneID string                `json:"zone_id"`
-	Values []hourlyPriceResponse `json:"values"`
+	Date       string                `json:"date"`
+	ZoneID     string                `json:"zone_id"`
+	Unit       string                `json:"unit,omitempty"`
+	Values     []hourlyPriceResponse `json:"values"`
+	Provenance *provenanceResponse   `json:"provenance,omitempty"`
+}
+
+type provenanceResponse struct {
+	Provider  string `json:"provider"`
+	FetchedAt string `json:"fetched_at"`
+	Source    string `json:"source"`
 }
 
 type hourlyPriceResponse struct {
-	Datetime string  `json:"datetime"`
-	Value    float64 `json:"value"`
+	Datetime string   `json:"datetime"`
+	Value    float64  `json:"value"`
+	Amount   string   `json:"amount"` // the exact value, as a decimal string
+	Spot     *float64 `json:"spot,omitempty"`
+	Period   string   `json:"period,omitempty"`
+	Level    string   `json:"level,omitempty"`
 }
 
 // GetPricesHandlerV1 returns a gin.HandlerFunc to retrieve prices from storage.
-func GetPricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+// The type param selects the prices type, PVPC by default (e.g. type=surplus).
+// With include=spot, every hour also has the spot price of its zone, when it is stored.
+// Every hour has its 2.0TD tariff period (P1, P2 or P3) and its price level (cheap, normal or expensive)
+// compared to the prices of the day or, with level_baseline=30d, of the last 30 days. The level_strategy param
+// sets the levels bounds: terciles (default), thresholds (cheap_below and expensive_above, in €/MWh) or
+// deviation from the mean (level_deviation, a share of it, 0.1 by default). With level, e.g. level=cheap,normal,
+// only the hours of those levels are returned. The unit param sets the energy unit of the values: mwh (default) or kwh.
+// Every value also has its exact amount, as a decimal string.
+// Datetimes are rendered in the local time of each prices' zone or, with tz, in the given IANA time zone or UTC.
+func GetPricesHandlerV1(pricesService services.PricesService, spotPricesService services.SpotPricesService, tariffPeriodsService services.TariffPeriodsService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
-		zoneID, date := parseGetPricesParams(ctx, ctx.Request.URL.Query())
+		params, err := parseGetPricesParams(ctx, ctx.Request.URL.Query())
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
 
-		prices, err := pricesService.GetPrices(ctx, zoneID, date)
+		prices, err := pricesService.GetPricesByType(ctx, params.pricesType, params.zoneID, params.date)
 		if err != nil {
 			statusCode, response := responses.NewAPIErrorResponse(err)
 			ctx.JSON(statusCode, response)
@@ -46,21 +74,53 @@ func GetPricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
 			Prices: make([]pricesResponse, len(prices)),
 		}
 
+		periods, err := tariffPeriodsService.ClassifyPrices(ctx, prices)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		levels, err := pricesService.ClassifyPriceLevels(ctx, prices, params.levels)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
 		for i, price := range prices {
-			response.Prices[i] = pricesResponse{
-				Date:   price.Date().Format("2006-01-02"),
-				ZoneID: price.Zone().ID().String(),
-				Values: make([]hourlyPriceResponse, len(price.Values())),
+			response.Prices[i] = newPricesResponseIn(price, params.includes(includeProvenance), params.location, params.unit)
+			for j, period := range periods[i] {
+				response.Prices[i].Values[j].Period = period.String()
+			}
+			for j, level := range levels[i] {
+				response.Prices[i].Values[j].Level = level.String()
 			}
+		}
 
-			for j, value := range price.Values() {
-				response.Prices[i].Values[j] = hourlyPriceResponse{
-					Datetime: value.Datetime().Format(time.RFC3339),
-					Value:    value.Value(),
+		if params.includes(includeSpot) {
+			spotPrices, err := spotPricesByZoneAndHour(ctx, spotPricesService, prices)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(err)
+				ctx.JSON(statusCode, response)
+				return
+			}
+			for i, price := range prices {
+				for j, value := range price.Values() {
+					if spot, ok := spotPrices[price.Zone().ID()][value.Datetime().Unix()]; ok {
+						spotValue := spot.In(params.unit).Amount().Float64()
+						response.Prices[i].Values[j].Spot = &spotValue
+					}
 				}
 			}
 		}
 
+		if len(params.levelFilter) > 0 {
+			for i := range response.Prices {
+				response.Prices[i].Values = filterHourlyPricesByLevel(response.Prices[i].Values, params.levelFilter)
+			}
+		}
+
 		if len(response.Prices) == 0 {
 			ctx.JSON(http.StatusNotFound, response)
 		} else {
@@ -69,20 +129,210 @@ func GetPricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
 	}
 }
 
-func parseGetPricesParams(ctx context.Context, params url.Values) (*domain.ZoneID, *time.Time) {
-	var zoneID *domain.ZoneID
-	var date *time.Time
+// newPricesResponse returns the prices response with its datetimes in loc or, if it's nil, in the local time of the prices' zone.
+func newPricesResponse(price domain.Prices, withProvenance bool, loc *time.Location) pricesResponse {
+	if loc == nil {
+		loc = price.Zone().Location()
+	}
+
+	response := pricesResponse{
+		Date:   price.Date().Format("2006-01-02"),
+		ZoneID: price.Zone().ID().String(),
+		Values: make([]hourlyPriceResponse, len(price.Values())),
+	}
+
+	if provenance := price.Provenance(); withProvenance && provenance != nil {
+		response.Provenance = &provenanceResponse{
+			Provider:  provenance.Provider(),
+			FetchedAt: provenance.FetchedAt().UTC().Format(time.RFC3339),
+			Source:    provenance.Source(),
+		}
+	}
+
+	for i, value := range price.Values() {
+		response.Values[i] = hourlyPriceResponse{
+			Datetime: value.Datetime().In(loc).Format(time.RFC3339),
+			Value:    value.Value(),
+			Amount:   value.Price().In(domain.MWh).Amount().String(),
+		}
+	}
+
+	return response
+}
+
+// newPricesResponseIn returns the prices response with its values converted exactly to the given energy unit.
+func newPricesResponseIn(price domain.Prices, withProvenance bool, loc *time.Location, unit domain.EnergyUnit) pricesResponse {
+	response := newPricesResponse(price, withProvenance, loc)
+	response.Unit = domain.EUR.String() + "/" + unit.String()
+	for i, value := range price.Values() {
+		amount := value.Price().In(unit).Amount()
+		response.Values[i].Value = amount.Float64()
+		response.Values[i].Amount = amount.String()
+	}
+	return response
+}
+
+// filterHourlyPricesByLevel returns the hourly prices whose level is one of the given ones.
+func filterHourlyPricesByLevel(values []hourlyPriceResponse, levels map[domain.PriceLevel]bool) []hourlyPriceResponse {
+	filtered := make([]hourlyPriceResponse, 0, len(values))
+	for _, value := range values {
+		if levels[domain.PriceLevel(value.Level)] {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
+
+const (
+	// includeProvenance is the include param value to add the prices' provenance to the response.
+	includeProvenance = "provenance"
+	// includeSpot is the include param value to add the spot price of every hour to the response.
+	includeSpot = "spot"
+)
+
+type getPricesParams struct {
+	zoneID      *domain.ZoneID
+	date        *time.Time
+	pricesType  domain.PricesType
+	unit        domain.EnergyUnit
+	location    *time.Location
+	include     map[string]bool
+	levels      domain.PriceLevels
+	levelFilter map[domain.PriceLevel]bool
+}
+
+// includes reports whether the optional field was requested through the include param.
+func (p getPricesParams) includes(field string) bool {
+	return p.include[field]
+}
+
+// parseGetPricesParams parses the query params of the prices endpoints. Invalid params fall back to
+// their defaults, except an invalid tz, which is returned as an error.
+func parseGetPricesParams(ctx context.Context, params url.Values) (getPricesParams, error) {
+	parsed := getPricesParams{pricesType: domain.PVPCPrices, unit: domain.MWh}
+	var levelsDto domain.PriceLevelsDto
+	var err error
 
 	for key, value := range params {
 		switch key {
 		case "zone_id":
-			zoneID = parseZoneIDParamValue(ctx, value)
+			parsed.zoneID = parseZoneIDParamValue(ctx, value)
 		case "date":
-			date = parseDateParamValue(ctx, value)
+			parsed.date = parseDateParamValue(ctx, value)
+		case "type":
+			parsed.pricesType = parsePricesTypeParamValue(ctx, value)
+		case "unit":
+			parsed.unit = parseEnergyUnitParamValue(ctx, value)
+		case "tz":
+			if parsed.location, err = parseTimeZoneParamValue(value); err != nil {
+				return getPricesParams{}, err
+			}
+		case "include":
+			parsed.include = parseIncludeParamValue(value)
+		case "level":
+			parsed.levelFilter = parseLevelParamValue(ctx, value)
+		case "level_strategy":
+			levelsDto.Strategy = value[0]
+		case "level_baseline":
+			levelsDto.Baseline = value[0]
+		case "cheap_below":
+			levelsDto.CheapBelow = parseFloatParamValue(ctx, key, value)
+		case "expensive_above":
+			levelsDto.ExpensiveAbove = parseFloatParamValue(ctx, key, value)
+		case "level_deviation":
+			if deviation := parseFloatParamValue(ctx, key, value); deviation != nil {
+				levelsDto.Deviation = *deviation
+			}
+		}
+	}
+	parsed.levels = parsePriceLevelsParams(ctx, levelsDto)
+
+	return parsed, nil
+}
+
+// parsePriceLevelsParams returns the price levels of the level_* params or, if they are invalid, the default ones.
+func parsePriceLevelsParams(ctx context.Context, levelsDto domain.PriceLevelsDto) domain.PriceLevels {
+	levels, err := domain.NewPriceLevels(levelsDto)
+	if err != nil {
+		logger.DebugContext(ctx, "Invalid price levels", "strategy", levelsDto.Strategy, "baseline", levelsDto.Baseline, "err", err)
+		levels, _ = domain.NewPriceLevels(domain.PriceLevelsDto{})
+	}
+	return levels
+}
+
+// parseLevelParamValue parses a comma separated list of price levels, e.g. level=cheap,normal.
+// The param may also be repeated. Invalid levels are ignored.
+func parseLevelParamValue(ctx context.Context, level []string) map[domain.PriceLevel]bool {
+	levels := make(map[domain.PriceLevel]bool)
+	for field := range parseIncludeParamValue(level) {
+		parsedLevel, err := domain.NewPriceLevel(field)
+		if err != nil {
+			logger.DebugContext(ctx, "Invalid price level", "price_level", field, "err", err)
+			continue
+		}
+		levels[parsedLevel] = true
+	}
+	return levels
+}
+
+func parseFloatParamValue(ctx context.Context, key string, value []string) *float64 {
+	parsed, err := strconv.ParseFloat(value[0], 64)
+	if err != nil {
+		logger.DebugContext(ctx, "Invalid number", key, value[0], "err", err)
+		return nil
+	}
+	return &parsed
+}
+
+// parseIncludeParamValue parses a comma separated list of optional response fields,
+// e.g. include=provenance. The param may also be repeated.
+func parseIncludeParamValue(include []string) map[string]bool {
+	fields := make(map[string]bool)
+	for _, value := range include {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields[field] = true
+			}
 		}
 	}
+	return fields
+}
 
-	return zoneID, date
+func parsePricesTypeParamValue(ctx context.Context, pricesType []string) domain.PricesType {
+	if len(pricesType) == 0 {
+		return domain.PVPCPrices
+	}
+	parsedPricesType, err := domain.NewPricesType(pricesType[0])
+	if err != nil {
+		logger.DebugContext(ctx, "Invalid prices type", "type", pricesType[0], "err", err)
+		return domain.PVPCPrices
+	}
+	return parsedPricesType
+}
+
+func parseEnergyUnitParamValue(ctx context.Context, unit []string) domain.EnergyUnit {
+	if len(unit) == 0 {
+		return domain.MWh
+	}
+	parsedUnit, err := domain.NewEnergyUnit(unit[0])
+	if err != nil {
+		logger.DebugContext(ctx, "Invalid energy unit", "unit", unit[0], "err", err)
+		return domain.MWh
+	}
+	return parsedUnit
+}
+
+// parseTimeZoneParamValue parses an IANA time zone name, e.g. tz=Atlantic/Canary, or UTC.
+// It returns nil, to render the datetimes in their zone's local time, if it is missing, and an
+// error if it isn't a valid time zone name.
+func parseTimeZoneParamValue(tz []string) (*time.Location, error) {
+	if len(tz) == 0 || tz[0] == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(tz[0], "UTC") {
+		return time.UTC, nil
+	}
+	return domain.NewZoneLocation(tz[0])
 }
 
 func parseZoneIDParamValue(ctx context.Context, zoneID []string) *domain.ZoneID {
@@ -0,0 +1,126 @@
+package prices
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type pricesAggregatesResponse struct {
+	ZoneID      string                    `json:"zone_id"`
+	Granularity string                    `json:"granularity"`
+	From        string                    `json:"from"`
+	To          string                    `json:"to"`
+	Aggregates  []pricesAggregateResponse `json:"aggregates"`
+}
+
+type pricesAggregateResponse struct {
+	PeriodStart  string                   `json:"period_start"`
+	Days         int                      `json:"days"`
+	Hours        int                      `json:"hours"`
+	Min          float64                  `json:"min"`
+	Max          float64                  `json:"max"`
+	Mean         float64                  `json:"mean"`
+	WeightedMean float64                  `json:"weighted_mean"`
+	PeakMean     *float64                 `json:"peak_mean"`
+	OffpeakMean  *float64                 `json:"offpeak_mean"`
+	PreviousYear *pricesAggregateResponse `json:"previous_year,omitempty"`
+	MeanChange   *float64                 `json:"mean_change_pct,omitempty"`
+}
+
+// GetPricesAggregatesHandlerV1 returns a gin.HandlerFunc to aggregate the PVPC prices of a zone, from and to
+// (YYYY-MM-DD) both included, by week, month (default) or year. Prices are in €/MWh: mean is the unweighted
+// mean of the daily means, weighted_mean the mean of every hour and peak_mean and offpeak_mean the means of
+// the peak and off-peak hours as traded in the electricity markets: from 08:00 to 20:00 local time of Monday
+// to Friday, holidays included, and the rest. They aren't the P1, P2 and P3 periods of the 2.0TD tariff,
+// served as the period of each hourly price. Each period is compared to the same one of the previous year,
+// the same week 52 weeks before for weeks, with the change of its mean in percent.
+func GetPricesAggregatesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		zoneID, err := domain.NewZoneID(ctx.Query("zone_id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		granularity, err := domain.NewAggregateGranularity(ctx.DefaultQuery("granularity", domain.MonthGranularity.String()))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+		from, err := time.Parse(time.DateOnly, ctx.Query("from"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid aggregates from date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+		to, err := time.Parse(time.DateOnly, ctx.Query("to"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid aggregates to date. It must be in the shape of YYYY-MM-DD"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		reports, err := pricesService.AggregatePrices(ctx, zoneID, granularity, from, to)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := pricesAggregatesResponse{
+			ZoneID:      zoneID.String(),
+			Granularity: granularity.String(),
+			From:        from.Format(time.DateOnly),
+			To:          to.Format(time.DateOnly),
+			Aggregates:  make([]pricesAggregateResponse, len(reports)),
+		}
+		for i, report := range reports {
+			response.Aggregates[i] = newPricesAggregateResponse(report.Aggregate)
+			if report.PreviousYear != nil {
+				previousYear := newPricesAggregateResponse(*report.PreviousYear)
+				response.Aggregates[i].PreviousYear = &previousYear
+				if previousMean := report.PreviousYear.Mean(); previousMean != 0 {
+					change := roundPrice((report.Aggregate.Mean() - previousMean) / math.Abs(previousMean) * 100)
+					response.Aggregates[i].MeanChange = &change
+				}
+			}
+		}
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+func newPricesAggregateResponse(aggregate domain.PricesAggregate) pricesAggregateResponse {
+	dto := aggregate.Serialize()
+	response := pricesAggregateResponse{
+		PeriodStart:  dto.PeriodStart,
+		Days:         dto.Days,
+		Hours:        dto.Hours,
+		Min:          dto.Min,
+		Max:          dto.Max,
+		Mean:         roundPrice(dto.Mean),
+		WeightedMean: roundPrice(dto.WeightedMean),
+	}
+	if dto.PeakMean != nil {
+		peakMean := roundPrice(*dto.PeakMean)
+		response.PeakMean = &peakMean
+	}
+	if dto.OffpeakMean != nil {
+		offpeakMean := roundPrice(*dto.OffpeakMean)
+		response.OffpeakMean = &offpeakMean
+	}
+	return response
+}
+
+// roundPrice rounds a price to two decimals.
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
@@ -0,0 +1,91 @@
+package prices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_GetPricesAggregatesHandlerV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	zone, err := domain.NewZone(domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"})
+	require.NoError(t, err)
+	peakMean := 120.456
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	pricesRepositoryMock.On("Aggregate", mock.Anything, zone.ID(), domain.MonthGranularity,
+		time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC)).
+		Return([]domain.PricesAggregate{newTestPricesAggregate(t, "2023-09-01", 100, &peakMean), newTestPricesAggregate(t, "2023-10-01", 90, nil)}, nil).Once()
+	pricesRepositoryMock.On("Aggregate", mock.Anything, zone.ID(), domain.MonthGranularity,
+		time.Date(2022, 9, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 31, 0, 0, 0, 0, time.UTC)).
+		Return([]domain.PricesAggregate{newTestPricesAggregate(t, "2022-10-01", 120, nil)}, nil).Once()
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Once()
+
+	r := gin.New()
+	r.GET("/v1/prices/aggregates", GetPricesAggregatesHandlerV1(services.NewPricesService(nil, pricesRepositoryMock, zonesRepositoryMock)))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices/aggregates?zone_id=PEN&from=2023-09-01&to=2023-10-31", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	pricesRepositoryMock.AssertExpectations(t)
+	zonesRepositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesAggregatesHandlerV1_InvalidParams(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	zone, err := domain.NewZone(domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"})
+	require.NoError(t, err)
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Maybe()
+
+	r := gin.New()
+	r.GET("/v1/prices/aggregates", GetPricesAggregatesHandlerV1(services.NewPricesService(nil, new(mocks.PricesRepository), zonesRepositoryMock)))
+
+	tests := map[string]string{
+		"without zone":                "/v1/prices/aggregates?from=2023-09-01&to=2023-10-31",
+		"with an invalid granularity": "/v1/prices/aggregates?zone_id=PEN&granularity=day&from=2023-09-01&to=2023-10-31",
+		"without from":                "/v1/prices/aggregates?zone_id=PEN&to=2023-10-31",
+		"with an invalid to":          "/v1/prices/aggregates?zone_id=PEN&from=2023-09-01&to=31-10-2023",
+		"with to before from":         "/v1/prices/aggregates?zone_id=PEN&from=2023-09-01&to=2023-08-31",
+		"with a too long range":       "/v1/prices/aggregates?zone_id=PEN&granularity=year&from=2010-01-01&to=2023-12-31",
+	}
+	for name, url := range tests {
+		url := url
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, http.StatusBadRequest, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
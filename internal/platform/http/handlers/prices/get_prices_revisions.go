@@ -0,0 +1,66 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type getPricesRevisionsResponse struct {
+	ID        string                   `json:"id"`
+	Current   pricesResponse           `json:"current"`
+	Revisions []pricesRevisionResponse `json:"revisions"`
+}
+
+type pricesRevisionResponse struct {
+	RevisedAt string `json:"revised_at"`
+	pricesResponse
+}
+
+// GetPricesRevisionsHandlerV1 returns a gin.HandlerFunc to retrieve the current version of some prices
+// and the previous ones REE republished, so changes of already served prices can be explained.
+// Datetimes are rendered as in GetPricesHandlerV1, with the optional tz param.
+func GetPricesRevisionsHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := domain.NewPricesID(ctx.Param("id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		loc, err := parseTimeZoneParamValue(ctx.QueryArray("tz"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		current, revisions, err := pricesService.GetPricesRevisions(ctx, id)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := getPricesRevisionsResponse{
+			ID:        id.String(),
+			Current:   newPricesResponse(current, true, loc),
+			Revisions: make([]pricesRevisionResponse, len(revisions)),
+		}
+
+		for i, revision := range revisions {
+			response.Revisions[i] = pricesRevisionResponse{
+				RevisedAt:      revision.RevisedAt().UTC().Format(time.RFC3339),
+				pricesResponse: newPricesResponse(revision.Prices(), true, loc),
+			}
+		}
+
+		ctx.JSON(http.StatusOK, response)
+	}
+}
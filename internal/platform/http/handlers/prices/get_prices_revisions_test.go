@@ -0,0 +1,140 @@
+package prices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_GetPricesRevisionsV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices/:id/revisions", GetPricesRevisionsHandlerV1(pricesService))
+
+	id, err := domain.NewPricesID("ABC-2023-10-02")
+	require.NoError(t, err)
+	zoneID := id.ZoneID()
+	date, err := id.Date()
+	require.NoError(t, err)
+
+	dto := domain.PricesDto{
+		ID:     "ABC-2023-10-02",
+		Date:   "2023-10-02T00:00:00Z",
+		Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.2}},
+		Provenance: &domain.ProvenanceDto{
+			Provider:  "esios",
+			FetchedAt: "2023-10-02T10:00:00Z",
+			Source:    "/indicators/1001?geo_ids%5B%5D=1234",
+		},
+	}
+	current, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+
+	dto.Values = []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.1}}
+	dto.Provenance = &domain.ProvenanceDto{Provider: "redata", FetchedAt: "2023-10-01T20:30:00Z", Source: "/es/datos/mercados/precios-mercados-tiempo-real"}
+	revision, err := domain.NewPricesRevision(domain.PricesRevisionDto{Prices: dto, RevisedAt: "2023-10-02T10:00:01Z"})
+	require.NoError(t, err)
+
+	repositoryMock.On("Query", mock.Anything, &zoneID, &date).Return([]domain.Prices{current}, nil)
+	repositoryMock.On("ListRevisions", mock.Anything, id).Return([]domain.PricesRevision{revision}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices/ABC-2023-10-02/revisions", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesRevisionsV1_NotFound(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices/:id/revisions", GetPricesRevisionsHandlerV1(pricesService))
+
+	repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices/ABC-2023-10-02/revisions", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertNotCalled(t, "ListRevisions", mock.Anything, mock.Anything)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesRevisionsV1_InvalidID(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices/:id/revisions", GetPricesRevisionsHandlerV1(pricesService))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices/invalid/revisions", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesRevisionsV1_InvalidTimeZone(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices/:id/revisions", GetPricesRevisionsHandlerV1(pricesService))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices/PEN-2023-10-02/revisions?tz=Mars/Olympus_Mons", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
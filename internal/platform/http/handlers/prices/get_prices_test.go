@@ -1,10 +1,12 @@
 package prices
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -24,10 +26,10 @@ func Test_GetPricesV1_Success(t *testing.T) {
 	logger.SetTestLogger(os.Stderr)
 	gin.SetMode(gin.TestMode)
 	repositoryMock := new(mocks.PricesRepository)
-	pricesService := services.NewPricesService(nil, nil, repositoryMock, nil)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
 
 	r := gin.New()
-	r.GET("/v1/prices", GetPricesHandlerV1(pricesService))
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
 
 	prices, err := domain.NewPrices(domain.PricesDto{
 		ID:   "ABC-2023-10-02",
@@ -68,11 +70,261 @@ func Test_GetPricesV1_Success(t *testing.T) {
 
 }
 
+func Test_GetPricesV1_Provenance(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+	withProvenance, err := domain.NewPrices(domain.PricesDto{
+		ID:     "ABC-2023-10-02",
+		Date:   "2023-10-02T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.1}},
+		Provenance: &domain.ProvenanceDto{
+			Provider:  "esios",
+			FetchedAt: "2023-10-01T20:30:00+02:00",
+			Source:    "/indicators/1001?geo_ids%5B%5D=1234",
+		},
+	})
+	require.NoError(t, err)
+	withoutProvenance, err := domain.NewPrices(domain.PricesDto{
+		ID:     "DEF-2023-10-02",
+		Date:   "2023-10-02T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "DEF", ExternalID: "5678", Name: "zone2"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.2}},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{withProvenance, withoutProvenance}, nil)
+
+	for _, path := range []string{"/v1/prices", "/v1/prices?include=provenance"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		res := rec.Result()
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		snaps.MatchSnapshot(t, rec.Body.String())
+	}
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func Test_GetPricesV1_Spot(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+	spotRepositoryMock := new(mocks.SpotPricesRepository)
+	spotPricesService := services.NewSpotPricesService(nil, spotRepositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, spotPricesService, newTestTariffPeriodsService()))
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:   "ABC-2023-10-02",
+		Date: "2023-10-02T00:00:00+02:00",
+		Zone: domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.1},
+			{Datetime: "2023-10-02T01:00:00+02:00", Value: 0.2},
+		},
+	})
+	require.NoError(t, err)
+	spotPrices, err := domain.NewPrices(domain.PricesDto{
+		ID:     "ABC-2023-10-02",
+		Type:   domain.SpotPrices.String(),
+		Date:   "2023-10-02T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.09}},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{prices}, nil)
+	spotRepositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		mock.MatchedBy(func(date *time.Time) bool { return date.Equal(prices.Date()) }),
+	).Return([]domain.Prices{spotPrices}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices?include=spot", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	spotRepositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesV1_Surplus(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+	surplus, err := domain.NewPrices(domain.PricesDto{
+		ID:     "ABC-2023-10-02",
+		Type:   "surplus",
+		Date:   "2023-10-02T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.07}},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"QueryByType",
+		mock.Anything,
+		domain.SurplusPrices,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{surplus}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices?type=surplus", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	repositoryMock.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesV1_Unit(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:   "ABC-2023-10-02",
+		Date: "2023-10-02T00:00:00+02:00",
+		Zone: domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-02T00:00:00+02:00", Value: 123.45},
+			{Datetime: "2023-10-02T01:00:00+02:00", Amount: "98.123456789012345"},
+		},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{prices}, nil)
+
+	for _, unit := range []string{"mwh", "kwh", "KWh", "invalid"} {
+		t.Run(unit, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/prices?unit="+unit, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_GetPricesV1_TimeZone(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:   "CAN-2023-10-02",
+		Date: "2023-10-02T00:00:00+02:00",
+		Zone: domain.ZoneDto{ID: "CAN", ExternalID: "8742", Name: "Canarias", TimeZone: "Atlantic/Canary"},
+		Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-01T22:00:00Z", Value: 123.45},
+			{Datetime: "2023-10-01T23:00:00Z", Value: 98.76},
+		},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{prices}, nil)
+
+	tests := []struct {
+		name       string
+		tz         string
+		statusCode int
+	}{
+		{name: "zone", tz: "", statusCode: http.StatusOK},
+		{name: "utc", tz: "utc", statusCode: http.StatusOK},
+		{name: "madrid", tz: "Europe/Madrid", statusCode: http.StatusOK},
+		{name: "invalid", tz: "Mars/Olympus_Mons", statusCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/v1/prices?tz="+url.QueryEscape(tt.tz), nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.statusCode, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
 func Test_GetPricesV1_Empty(t *testing.T) {
 	logger.SetTestLogger(os.Stderr)
 	gin.SetMode(gin.TestMode)
 	repositoryMock := new(mocks.PricesRepository)
-	pricesService := services.NewPricesService(nil, nil, repositoryMock, nil)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
 
 	zoneIDRaw := "ZON"
 	zoneID, err := domain.NewZoneID(zoneIDRaw)
@@ -83,7 +335,7 @@ func Test_GetPricesV1_Empty(t *testing.T) {
 	require.NoError(t, err)
 
 	r := gin.New()
-	r.GET("/v1/prices", GetPricesHandlerV1(pricesService))
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
 
 	repositoryMock.On(
 		"Query",
@@ -109,10 +361,10 @@ func Test_GetPricesV1_Error(t *testing.T) {
 	logger.SetTestLogger(os.Stderr)
 	gin.SetMode(gin.TestMode)
 	repositoryMock := new(mocks.PricesRepository)
-	pricesService := services.NewPricesService(nil, nil, repositoryMock, nil)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
 
 	r := gin.New()
-	r.GET("/v1/prices", GetPricesHandlerV1(pricesService))
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
 
 	repositoryMock.On(
 		"Query",
@@ -133,3 +385,132 @@ func Test_GetPricesV1_Error(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
 	snaps.MatchSnapshot(t, rec.Body.String())
 }
+
+func Test_GetPricesV1_TariffPeriods(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+	holidaysRepositoryMock := new(mocks.HolidaysRepository)
+	tariffPeriodsService := services.NewTariffPeriodsService(holidaysRepositoryMock)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, tariffPeriodsService))
+
+	holiday, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-10-12", Name: "Fiesta Nacional de España"})
+	require.NoError(t, err)
+
+	repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{
+		newTestPrices(t, "2023-10-11", map[int]float64{7: 0.1, 9: 0.12, 11: 0.15}),
+		newTestPrices(t, "2023-10-12", map[int]float64{7: 0.1, 9: 0.12, 11: 0.15}),
+	}, nil)
+	holidaysRepositoryMock.On("GetAll", mock.Anything).Return([]domain.Holiday{holiday}, nil).Once()
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	holidaysRepositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetPricesV1_PriceLevels(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:   "PEN-2023-10-11",
+		Date: "2023-10-11T00:00:00+02:00",
+		Zone: domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"},
+		Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-11T00:00:00+02:00", Value: 80},
+			{Datetime: "2023-10-11T01:00:00+02:00", Value: 90},
+			{Datetime: "2023-10-11T02:00:00+02:00", Value: 100},
+			{Datetime: "2023-10-11T03:00:00+02:00", Value: 110},
+			{Datetime: "2023-10-11T04:00:00+02:00", Value: 120},
+			{Datetime: "2023-10-11T05:00:00+02:00", Value: 130},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"terciles of the day by default":                 "/v1/prices",
+		"fixed thresholds":                               "/v1/prices?level_strategy=thresholds&cheap_below=95&expensive_above=125",
+		"deviation from the mean":                        "/v1/prices?level_strategy=deviation&level_deviation=0.15",
+		"invalid levels fall back to the default ones":   "/v1/prices?level_strategy=thresholds&cheap_below=95",
+		"filtered by level, ignoring the invalid ones":   "/v1/prices?level=cheap,expensive,free",
+		"filtered by level with the thresholds strategy": "/v1/prices?level=normal&level_strategy=thresholds&cheap_below=85&expensive_above=125",
+	}
+	for name, url := range tests {
+		url := url
+		t.Run(name, func(t *testing.T) {
+			repositoryMock := new(mocks.PricesRepository)
+			repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{prices}, nil)
+			pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+			r := gin.New()
+			r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
+
+func Test_GetPricesV1_PriceLevels_RollingBaseline(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.PricesRepository)
+	pricesService := services.NewPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/prices", GetPricesHandlerV1(pricesService, services.SpotPricesService{}, newTestTariffPeriodsService()))
+
+	// Every day, the prices are 100 €/MWh at 00:00 and 200 at 01:00, but on 2023-10-11 they are 90 and 110,
+	// which are cheap and normal compared to the last 30 days.
+	repositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, _ *domain.ZoneID, date *time.Time) []domain.Prices {
+		day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+		if date != nil {
+			day = *date
+		}
+		if day.Day() == 11 {
+			return []domain.Prices{newTestPrices(t, day.Format(time.DateOnly), map[int]float64{0: 90, 1: 110})}
+		}
+		return []domain.Prices{newTestPrices(t, day.Format(time.DateOnly), map[int]float64{0: 100, 1: 200})}
+	}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/prices?level_baseline=30d", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+// newTestTariffPeriodsService returns a TariffPeriodsService with an empty holidays calendar.
+func newTestTariffPeriodsService() services.TariffPeriodsService {
+	holidaysRepositoryMock := new(mocks.HolidaysRepository)
+	holidaysRepositoryMock.On("GetAll", mock.Anything).Return([]domain.Holiday{}, nil).Maybe()
+	return services.NewTariffPeriodsService(holidaysRepositoryMock)
+}
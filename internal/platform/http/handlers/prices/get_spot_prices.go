@@ -0,0 +1,81 @@
+package prices
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type getSpotPricesResponse struct {
+	SpotPrices []pricesResponse `json:"spot_prices"`
+}
+
+// GetSpotPricesHandlerV1 returns a gin.HandlerFunc to retrieve the day-ahead market (spot) prices from storage.
+// It accepts the same zone_id, date, include and tz query params as GetPricesHandlerV1.
+func GetSpotPricesHandlerV1(spotPricesService services.SpotPricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+
+		params, err := parseGetPricesParams(ctx, ctx.Request.URL.Query())
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		prices, err := spotPricesService.GetSpotPrices(ctx, params.zoneID, params.date)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := getSpotPricesResponse{
+			SpotPrices: make([]pricesResponse, len(prices)),
+		}
+
+		for i, price := range prices {
+			response.SpotPrices[i] = newPricesResponse(price, params.includes(includeProvenance), params.location)
+		}
+
+		if len(response.SpotPrices) == 0 {
+			ctx.JSON(http.StatusNotFound, response)
+		} else {
+			ctx.JSON(http.StatusOK, response)
+		}
+	}
+}
+
+// spotPricesByZoneAndHour indexes the spot prices of the days of the given prices by zone and hour (unix timestamp).
+func spotPricesByZoneAndHour(ctx context.Context, spotPricesService services.SpotPricesService, prices []domain.Prices) (map[domain.ZoneID]map[int64]domain.EnergyPrice, error) {
+	index := make(map[domain.ZoneID]map[int64]domain.EnergyPrice)
+	queriedDays := make(map[string]bool)
+
+	for _, price := range prices {
+		date := price.Date()
+		if queriedDays[date.Format(time.DateOnly)] {
+			continue
+		}
+		queriedDays[date.Format(time.DateOnly)] = true
+
+		spotPrices, err := spotPricesService.GetSpotPrices(ctx, nil, &date)
+		if err != nil {
+			return nil, err
+		}
+		for _, spot := range spotPrices {
+			if _, ok := index[spot.Zone().ID()]; !ok {
+				index[spot.Zone().ID()] = make(map[int64]domain.EnergyPrice)
+			}
+			for _, value := range spot.Values() {
+				index[spot.Zone().ID()][value.Datetime().Unix()] = value.Price()
+			}
+		}
+	}
+
+	return index, nil
+}
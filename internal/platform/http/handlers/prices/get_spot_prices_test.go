@@ -0,0 +1,119 @@
+package prices
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_GetSpotPricesV1_Success(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.SpotPricesRepository)
+	spotPricesService := services.NewSpotPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/spot-prices", GetSpotPricesHandlerV1(spotPricesService))
+
+	spotPrices, err := domain.NewPrices(domain.PricesDto{
+		ID:     "ABC-2023-10-02",
+		Type:   domain.SpotPrices.String(),
+		Date:   "2023-10-02T00:00:00+02:00",
+		Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+		Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0.09}},
+	})
+	require.NoError(t, err)
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{spotPrices}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/spot-prices", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetSpotPricesV1_Empty(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.SpotPricesRepository)
+	spotPricesService := services.NewSpotPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/spot-prices", GetSpotPricesHandlerV1(spotPricesService))
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return([]domain.Prices{}, nil)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/spot-prices", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
+
+func Test_GetSpotPricesV1_Error(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	repositoryMock := new(mocks.SpotPricesRepository)
+	spotPricesService := services.NewSpotPricesService(nil, repositoryMock, nil)
+
+	r := gin.New()
+	r.GET("/v1/spot-prices", GetSpotPricesHandlerV1(spotPricesService))
+
+	repositoryMock.On(
+		"Query",
+		mock.Anything,
+		(*domain.ZoneID)(nil),
+		(*time.Time)(nil),
+	).Return(nil, errors.New("unexpected error"))
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/spot-prices", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	repositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	snaps.MatchSnapshot(t, rec.Body.String())
+}
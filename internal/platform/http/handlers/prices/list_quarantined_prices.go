@@ -0,0 +1,81 @@
+package prices
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type listQuarantinedPricesResponse struct {
+	Quarantined []quarantinedPricesResponse `json:"quarantined"`
+}
+
+type quarantinedPricesResponse struct {
+	ID         string                  `json:"id"`
+	Type       string                  `json:"type"`
+	Status     string                  `json:"status"`
+	DetectedAt string                  `json:"detected_at"`
+	ReviewedAt string                  `json:"reviewed_at,omitempty"`
+	Anomalies  []pricesAnomalyResponse `json:"anomalies"`
+	pricesResponse
+}
+
+type pricesAnomalyResponse struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+// ListQuarantinedPricesHandlerV1 returns a gin.HandlerFunc to list the fetched prices held back because
+// they failed the anomaly checks, from the most recently detected ones.
+// The status param filters them by review status (pending, approved or rejected).
+func ListQuarantinedPricesHandlerV1(pricesService services.PricesService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var status *domain.QuarantineStatus
+		if value, ok := ctx.GetQuery("status"); ok {
+			parsedStatus, err := domain.NewQuarantineStatus(value)
+			if err != nil {
+				statusCode, response := responses.NewAPIErrorResponse(err)
+				ctx.JSON(statusCode, response)
+				return
+			}
+			status = &parsedStatus
+		}
+
+		quarantined, err := pricesService.ListQuarantinedPrices(ctx, status)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		response := listQuarantinedPricesResponse{Quarantined: make([]quarantinedPricesResponse, len(quarantined))}
+		for i, q := range quarantined {
+			response.Quarantined[i] = newQuarantinedPricesResponse(q)
+		}
+
+		ctx.JSON(http.StatusOK, response)
+	}
+}
+
+func newQuarantinedPricesResponse(quarantined domain.QuarantinedPrices) quarantinedPricesResponse {
+	response := quarantinedPricesResponse{
+		ID:             quarantined.Prices().ID().String(),
+		Type:           quarantined.Prices().Type().String(),
+		Status:         quarantined.Status().String(),
+		DetectedAt:     quarantined.DetectedAt().UTC().Format(time.RFC3339),
+		Anomalies:      make([]pricesAnomalyResponse, len(quarantined.Anomalies())),
+		pricesResponse: newPricesResponse(quarantined.Prices(), true, nil),
+	}
+	if reviewedAt := quarantined.ReviewedAt(); reviewedAt != nil {
+		response.ReviewedAt = reviewedAt.UTC().Format(time.RFC3339)
+	}
+	for i, anomaly := range quarantined.Anomalies() {
+		response.Anomalies[i] = pricesAnomalyResponse{Kind: anomaly.Kind().String(), Detail: anomaly.Detail()}
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package prices
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestQuarantinedPrices(t *testing.T, status, reviewedAt string) domain.QuarantinedPrices {
+	t.Helper()
+	quarantined, err := domain.NewQuarantinedPrices(domain.QuarantinedPricesDto{
+		Prices: domain.PricesDto{
+			ID:     "ABC-2023-10-02",
+			Date:   "2023-10-02T00:00:00Z",
+			Zone:   domain.ZoneDto{ID: "ABC", ExternalID: "1234", Name: "zone1"},
+			Values: []domain.HourlyPriceDto{{Datetime: "2023-10-02T00:00:00+02:00", Value: 0}, {Datetime: "2023-10-02T01:00:00+02:00", Value: 0}},
+			Provenance: &domain.ProvenanceDto{
+				Provider:  "esios",
+				FetchedAt: "2023-10-01T20:30:00Z",
+				Source:    "/indicators/1001?geo_ids%5B%5D=1234",
+			},
+		},
+		Anomalies: []domain.PricesAnomalyDto{
+			{Kind: "flatline", Detail: "all the 2 hourly prices are 0"},
+			{Kind: "outlier", Detail: "the mean price 0.00 is -6.1 standard deviations from the mean of the last 30 days (112.34)"},
+		},
+		Status:     status,
+		DetectedAt: "2023-10-01T20:30:01Z",
+		ReviewedAt: reviewedAt,
+	})
+	require.NoError(t, err)
+	return quarantined
+}
+
+func Test_ListQuarantinedPricesV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	pending := domain.PendingQuarantine
+
+	tests := []struct {
+		name       string
+		url        string
+		status     *domain.QuarantineStatus
+		statusCode int
+	}{
+		{name: "all the quarantined prices", url: "/v1/admin/quarantine", statusCode: http.StatusOK},
+		{name: "quarantined prices by status", url: "/v1/admin/quarantine?status=pending", status: &pending, statusCode: http.StatusOK},
+		{name: "invalid status", url: "/v1/admin/quarantine?status=unknown", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quarantineRepositoryMock := new(mocks.QuarantineRepository)
+			pricesService := services.NewPricesService(nil, new(mocks.PricesRepository), nil).
+				WithQuarantine(quarantineRepositoryMock, domain.DefaultAnomalyThresholds)
+
+			r := gin.New()
+			r.GET("/v1/admin/quarantine", ListQuarantinedPricesHandlerV1(pricesService))
+
+			if tt.statusCode == http.StatusOK {
+				quarantined := []domain.QuarantinedPrices{newTestQuarantinedPrices(t, "pending", "")}
+				if tt.status == nil {
+					quarantined = append(quarantined, newTestQuarantinedPrices(t, "rejected", "2023-10-02T08:00:00Z"))
+				}
+				quarantineRepositoryMock.On("List", mock.Anything, tt.status).Return(quarantined, nil)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			quarantineRepositoryMock.AssertExpectations(t)
+			require.Equal(t, tt.statusCode, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
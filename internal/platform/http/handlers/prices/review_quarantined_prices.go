@@ -0,0 +1,41 @@
+package prices
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+// ReviewQuarantinedPricesHandlerV1 returns a gin.HandlerFunc to approve or reject, as given by status,
+// the pending quarantined prices of the id path param. Approved prices are stored and served from then on.
+// The type param selects the prices type, PVPC by default (e.g. type=surplus).
+func ReviewQuarantinedPricesHandlerV1(pricesService services.PricesService, status domain.QuarantineStatus) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := domain.NewPricesID(ctx.Param("id"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		pricesType, err := domain.NewPricesType(ctx.Query("type"))
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		reviewed, err := pricesService.ReviewQuarantinedPrices(ctx, pricesType, id, status)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, newQuarantinedPricesResponse(reviewed))
+	}
+}
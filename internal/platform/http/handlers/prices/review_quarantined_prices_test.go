@@ -0,0 +1,110 @@
+package prices
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_ReviewQuarantinedPricesV1_Approve(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	quarantineRepositoryMock := new(mocks.QuarantineRepository)
+	pricesService := services.NewPricesService(nil, pricesRepositoryMock, nil).
+		WithQuarantine(quarantineRepositoryMock, domain.DefaultAnomalyThresholds)
+
+	r := gin.New()
+	r.POST("/v1/admin/quarantine/:id/approve", ReviewQuarantinedPricesHandlerV1(pricesService, domain.ApprovedQuarantine))
+
+	quarantined := newTestQuarantinedPrices(t, "pending", "")
+	quarantineRepositoryMock.On("Get", mock.Anything, domain.PVPCPrices, quarantined.Prices().ID()).Return(quarantined, nil)
+	pricesRepositoryMock.On("Upsert", mock.Anything, []domain.Prices{quarantined.Prices()}).Return(nil)
+	quarantineRepositoryMock.On("Save", mock.Anything, mock.MatchedBy(func(q domain.QuarantinedPrices) bool {
+		return q.Status() == domain.ApprovedQuarantine && q.ReviewedAt() != nil
+	})).Return(nil)
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/admin/quarantine/ABC-2023-10-02/approve", nil)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	res := rec.Result()
+	defer res.Body.Close()
+
+	pricesRepositoryMock.AssertExpectations(t)
+	quarantineRepositoryMock.AssertExpectations(t)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Equal(t, "approved", response["status"])
+	require.NotEmpty(t, response["reviewed_at"])
+}
+
+func Test_ReviewQuarantinedPricesV1_Errors(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		url        string
+		stored     *domain.QuarantinedPrices
+		statusCode int
+	}{
+		{name: "invalid ID", url: "/v1/admin/quarantine/invalid/reject", statusCode: http.StatusBadRequest},
+		{name: "invalid type", url: "/v1/admin/quarantine/ABC-2023-10-02/reject?type=unknown", statusCode: http.StatusBadRequest},
+		{name: "not quarantined", url: "/v1/admin/quarantine/ABC-2023-10-02/reject?type=surplus", statusCode: http.StatusNotFound},
+		{name: "already reviewed", url: "/v1/admin/quarantine/ABC-2023-10-02/reject", statusCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quarantineRepositoryMock := new(mocks.QuarantineRepository)
+			pricesService := services.NewPricesService(nil, new(mocks.PricesRepository), nil).
+				WithQuarantine(quarantineRepositoryMock, domain.DefaultAnomalyThresholds)
+
+			r := gin.New()
+			r.POST("/v1/admin/quarantine/:id/reject", ReviewQuarantinedPricesHandlerV1(pricesService, domain.RejectedQuarantine))
+
+			switch tt.statusCode {
+			case http.StatusNotFound:
+				quarantineRepositoryMock.On("Get", mock.Anything, domain.SurplusPrices, mock.Anything).
+					Return(domain.QuarantinedPrices{}, errors.NewDomainError(errors.QuarantineNotFound, "quarantined surplus prices not found: ABC-2023-10-02"))
+			case http.StatusBadRequest:
+				if tt.name == "already reviewed" {
+					quarantineRepositoryMock.On("Get", mock.Anything, domain.PVPCPrices, mock.Anything).
+						Return(newTestQuarantinedPrices(t, "approved", "2023-10-02T08:00:00Z"), nil)
+				}
+			}
+
+			req, err := http.NewRequest(http.MethodPost, tt.url, nil)
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			quarantineRepositoryMock.AssertExpectations(t)
+			quarantineRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			require.Equal(t, tt.statusCode, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package simulations
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+	"pvpc-backend/internal/services"
+)
+
+type simulateBatteryRequest struct {
+	ZoneID             string                     `json:"zone_id"`
+	From               string                     `json:"from"`
+	To                 string                     `json:"to"`
+	Battery            batteryRequest             `json:"battery"`
+	Consumption        []hourlyConsumptionRequest `json:"consumption"`
+	ConsumptionProfile []float64                  `json:"consumption_profile"`
+}
+
+type batteryRequest struct {
+	CapacityKwh         float64 `json:"capacity_kwh"`
+	ChargePowerKw       float64 `json:"charge_power_kw"`
+	DischargePowerKw    float64 `json:"discharge_power_kw"`
+	RoundTripEfficiency float64 `json:"round_trip_efficiency"`
+}
+
+type hourlyConsumptionRequest struct {
+	Datetime string  `json:"datetime"`
+	Kwh      float64 `json:"kwh"`
+}
+
+type batteryReportResponse struct {
+	ZoneID        string               `json:"zone_id"`
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	BaselineCost  float64              `json:"baseline_cost"`
+	BatteryCost   float64              `json:"battery_cost"`
+	Savings       float64              `json:"savings"`
+	ChargedKwh    float64              `json:"charged_kwh"`
+	DischargedKwh float64              `json:"discharged_kwh"`
+	Days          []batteryDayResponse `json:"days"`
+}
+
+type batteryDayResponse struct {
+	Date         string                `json:"date"`
+	BaselineCost float64               `json:"baseline_cost"`
+	BatteryCost  float64               `json:"battery_cost"`
+	Savings      float64               `json:"savings"`
+	Schedule     []batteryHourResponse `json:"schedule"`
+}
+
+type batteryHourResponse struct {
+	Datetime       string  `json:"datetime"`
+	Price          float64 `json:"price"`
+	ConsumptionKwh float64 `json:"consumption_kwh"`
+	ChargeKwh      float64 `json:"charge_kwh"`
+	DischargeKwh   float64 `json:"discharge_kwh"`
+	StateOfCharge  float64 `json:"state_of_charge_kwh"`
+}
+
+// SimulateBatteryHandlerV1 returns a gin.HandlerFunc to simulate a home battery arbitrage with the stored
+// PVPC prices of a zone, from and to (YYYY-MM-DD) both included. The household's consumption is either
+// a list of hourly consumption (kWh) or a daily profile, the consumption of the 24 hours of the local day.
+func SimulateBatteryHandlerV1(simulationsService services.SimulationsService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var request simulateBatteryRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(errors.WrapIntoDomainError(err, errors.InvalidRequestBody, "invalid battery simulation request body"))
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		simulation, err := newBatterySimulation(ctx, simulationsService, request)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		report, err := simulationsService.SimulateBattery(ctx, simulation)
+		if err != nil {
+			statusCode, response := responses.NewAPIErrorResponse(err)
+			ctx.JSON(statusCode, response)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, newBatteryReportResponse(report))
+	}
+}
+
+func newBatterySimulation(ctx *gin.Context, simulationsService services.SimulationsService, request simulateBatteryRequest) (services.BatterySimulation, error) {
+	zoneID, err := domain.NewZoneID(request.ZoneID)
+	if err != nil {
+		return services.BatterySimulation{}, err
+	}
+	from, err := time.Parse(time.DateOnly, request.From)
+	if err != nil {
+		return services.BatterySimulation{}, errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid simulation from date. It must be in the shape of YYYY-MM-DD")
+	}
+	to, err := time.Parse(time.DateOnly, request.To)
+	if err != nil {
+		return services.BatterySimulation{}, errors.WrapIntoDomainError(err, errors.InvalidDateRange, "invalid simulation to date. It must be in the shape of YYYY-MM-DD")
+	}
+	battery, err := domain.NewBattery(domain.BatteryDto(request.Battery))
+	if err != nil {
+		return services.BatterySimulation{}, err
+	}
+
+	var consumption []domain.HourlyConsumption
+	switch {
+	case len(request.Consumption) > 0 && len(request.ConsumptionProfile) > 0:
+		return services.BatterySimulation{}, errors.NewDomainError(errors.InvalidRequestBody, "either consumption or consumption_profile must be given, not both")
+	case len(request.ConsumptionProfile) > 0:
+		consumption, err = simulationsService.DailyProfileConsumption(ctx, zoneID, from, to, request.ConsumptionProfile)
+		if err != nil {
+			return services.BatterySimulation{}, err
+		}
+	default:
+		consumption = make([]domain.HourlyConsumption, len(request.Consumption))
+		for i, c := range request.Consumption {
+			consumption[i], err = domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: c.Datetime, Kwh: c.Kwh})
+			if err != nil {
+				return services.BatterySimulation{}, err
+			}
+		}
+	}
+
+	return services.BatterySimulation{
+		ZoneID:      zoneID,
+		From:        from,
+		To:          to,
+		Battery:     battery,
+		Consumption: consumption,
+	}, nil
+}
+
+func newBatteryReportResponse(report services.BatteryReport) batteryReportResponse {
+	response := batteryReportResponse{
+		ZoneID:        report.ZoneID.String(),
+		From:          report.From.Format(time.DateOnly),
+		To:            report.To.Format(time.DateOnly),
+		BaselineCost:  report.BaselineCost,
+		BatteryCost:   report.BatteryCost,
+		Savings:       report.Savings,
+		ChargedKwh:    report.ChargedKwh,
+		DischargedKwh: report.DischargedKwh,
+		Days:          make([]batteryDayResponse, len(report.Days)),
+	}
+
+	for i, day := range report.Days {
+		response.Days[i] = batteryDayResponse{
+			Date:         day.Date.Format(time.DateOnly),
+			BaselineCost: day.BaselineCost,
+			BatteryCost:  day.BatteryCost,
+			Savings:      day.Savings,
+			Schedule:     make([]batteryHourResponse, len(day.Hours)),
+		}
+		for j, hour := range day.Hours {
+			response.Days[i].Schedule[j] = batteryHourResponse{
+				Datetime:       hour.Datetime.UTC().Format(time.RFC3339),
+				Price:          hour.Price,
+				ConsumptionKwh: hour.ConsumptionKwh,
+				ChargeKwh:      hour.ChargeKwh,
+				DischargeKwh:   hour.DischargeKwh,
+				StateOfCharge:  hour.StateOfCharge,
+			}
+		}
+	}
+
+	return response
+}
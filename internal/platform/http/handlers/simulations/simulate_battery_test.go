@@ -0,0 +1,84 @@
+package simulations
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gkampitakis/go-snaps/snaps"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/services"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestSimulationsService(t *testing.T) services.SimulationsService {
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	dto := domain.PricesDto{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto}
+	for hour := 0; hour < 24; hour++ {
+		value := 100.0
+		if hour < 6 {
+			value = 50
+		} else if hour >= 20 {
+			value = 250
+		}
+		datetime := time.Date(2023, 10, 11, hour, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: value})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+
+	pricesRepositoryMock := new(mocks.PricesRepository)
+	pricesRepositoryMock.On("Query", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{prices}, nil).Maybe()
+	zonesRepositoryMock := new(mocks.ZonesRepository)
+	zonesRepositoryMock.On("GetByID", mock.Anything, zone.ID()).Return(zone, nil).Maybe()
+	return services.NewSimulationsService(pricesRepositoryMock, zonesRepositoryMock)
+}
+
+func Test_SimulateBatteryHandlerV1(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	battery := `"battery": {"capacity_kwh": 5, "charge_power_kw": 2.5, "discharge_power_kw": 2.5, "round_trip_efficiency": 0.9}`
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "daily profile", body: `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11", ` + battery + `,
+			"consumption_profile": [0.2, 0.2, 0.2, 0.2, 0.2, 0.2, 0.3, 0.5, 0.4, 0.3, 0.3, 0.4, 0.5, 0.6, 0.4, 0.3, 0.3, 0.4, 0.6, 0.8, 1.2, 1.5, 1, 0.5]}`, status: http.StatusOK},
+		{name: "malformed body", body: `{"zone_id": `, status: http.StatusBadRequest},
+		{name: "invalid battery", body: `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11", "battery": {"capacity_kwh": 5, "charge_power_kw": 2.5, "discharge_power_kw": 2.5, "round_trip_efficiency": 1.2}}`, status: http.StatusBadRequest},
+		{name: "invalid from", body: `{"zone_id": "PEN", "from": "11/10/2023", "to": "2023-10-11", ` + battery + `}`, status: http.StatusBadRequest},
+		{name: "both consumption and profile", body: `{"zone_id": "PEN", "from": "2023-10-11", "to": "2023-10-11", ` + battery + `,
+			"consumption": [{"datetime": "2023-10-11T10:00:00+02:00", "kwh": 1}], "consumption_profile": [1]}`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.POST("/v1/simulations/battery", SimulateBatteryHandlerV1(newTestSimulationsService(t)))
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/simulations/battery", strings.NewReader(tt.body))
+			require.NoError(t, err)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			defer res.Body.Close()
+
+			require.Equal(t, tt.status, res.StatusCode)
+			snaps.MatchSnapshot(t, rec.Body.String())
+		})
+	}
+}
@@ -19,6 +19,7 @@ type zonesResponse struct {
 	ID         string `json:"ID"`
 	ExternalID string `json:"externalID"`
 	Name       string `json:"name"`
+	TimeZone   string `json:"timeZone"` // IANA time zone in which the zone's days start and end
 }
 
 // ListZonesHandlerV1 returns a gin.HandlerFunc to list prices zones.
@@ -47,6 +48,7 @@ func mapZonesResponse(zones []domain.Zone) response {
 			ID:         zone.ID().String(),
 			ExternalID: zone.ExternalID(),
 			Name:       zone.Name(),
+			TimeZone:   zone.Location().String(),
 		}
 	}
 
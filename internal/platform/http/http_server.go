@@ -11,13 +11,22 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/http/handlers/bills"
 	"pvpc-backend/internal/platform/http/handlers/health"
+	"pvpc-backend/internal/platform/http/handlers/holidays"
+	"pvpc-backend/internal/platform/http/handlers/households"
+	"pvpc-backend/internal/platform/http/handlers/indicators"
+	"pvpc-backend/internal/platform/http/handlers/plans"
 	"pvpc-backend/internal/platform/http/handlers/prices"
+	"pvpc-backend/internal/platform/http/handlers/simulations"
 	"pvpc-backend/internal/platform/http/handlers/zones"
 	"pvpc-backend/internal/platform/http/middlewares"
 	"pvpc-backend/internal/platform/providers/esios"
 	"pvpc-backend/internal/platform/providers/redataapi"
+	"pvpc-backend/internal/platform/providers/resilient"
 	"pvpc-backend/internal/platform/storage/postgresql"
+	"pvpc-backend/internal/platform/storage/sqlite"
 	servicespkg "pvpc-backend/internal/services"
 	"pvpc-backend/pkg/logger"
 )
@@ -28,19 +37,45 @@ type HttpServer struct {
 	shutdownTimeout time.Duration
 	storage         storage
 	services        services
+	breakers        []*resilient.CircuitBreaker
+	adminToken      string
 }
 
+const (
+	// PricesProviderEsios selects the Esios API as prices provider.
+	PricesProviderEsios = "esios"
+	// PricesProviderREData selects the REData API as prices provider.
+	PricesProviderREData = "redata"
+)
+
+const (
+	// StorageDriverPostgreSQL selects the PostgreSQL repositories.
+	StorageDriverPostgreSQL = "postgresql"
+	// StorageDriverSQLite selects the embedded SQLite repositories.
+	StorageDriverSQLite = "sqlite"
+)
+
 type storage struct {
+	driver    string
 	db        *sql.DB
 	dbTimeout time.Duration
 }
 
 type services struct {
-	pricesService servicespkg.PricesService
-	zonesService  servicespkg.ZonesService
+	pricesService        servicespkg.PricesService
+	zonesService         servicespkg.ZonesService
+	indicatorsService    servicespkg.IndicatorsService
+	spotPricesService    servicespkg.SpotPricesService
+	tariffPeriodsService servicespkg.TariffPeriodsService
+	billsService         servicespkg.BillsService
+	householdsService    servicespkg.HouseholdsService
+	costsService         servicespkg.CostsService
+	plansService         servicespkg.PlansService
+	simulationsService   servicespkg.SimulationsService
+	forecastsService     servicespkg.ForecastsService
 }
 
-func NewHttpServer(host string, port uint, env string, shutdownTimeout time.Duration, db *sql.DB, dbTimeout time.Duration, redataApiUrl, esiosApiUrl, esiosApiToken string) HttpServer {
+func NewHttpServer(host string, port uint, env string, shutdownTimeout time.Duration, storageDriver string, db *sql.DB, dbTimeout time.Duration, redataApiUrl, esiosApiUrl, esiosApiToken string, pricesProviders, ingestedIndicators []string, holidaysFile, billRatesFile, adminToken string, providersConfig resilient.Config) HttpServer {
 	if env == "prod" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -48,14 +83,17 @@ func NewHttpServer(host string, port uint, env string, shutdownTimeout time.Dura
 		engine:          gin.New(),
 		address:         fmt.Sprintf("%s:%d", host, port),
 		shutdownTimeout: shutdownTimeout,
+		adminToken:      adminToken,
 		storage: storage{
+			driver:    storageDriver,
 			db:        db,
 			dbTimeout: dbTimeout,
 		},
 	}
 
 	srv.registerMiddlewares()
-	srv.registerServices(redataApiUrl, esiosApiUrl, esiosApiToken)
+	srv.registerServices(redataApiUrl, esiosApiUrl, esiosApiToken, pricesProviders, ingestedIndicators, billRatesFile, providersConfig)
+	srv.loadHolidays(holidaysFile)
 	srv.registerRoutes()
 
 	return srv
@@ -66,30 +104,158 @@ func (s *HttpServer) registerMiddlewares() {
 	s.engine.Use(middlewares.Logger([]string{"/v1/health"}))
 }
 
-func (s *HttpServer) registerServices(redataApiUrl, esiosApiUrl, esiosApiToken string) {
+func (s *HttpServer) registerServices(redataApiUrl, esiosApiUrl, esiosApiToken string, pricesProviders, ingestedIndicators []string, billRatesFile string, providersConfig resilient.Config) {
 	// Providers
-	pricesProviderEsios := esios.NewEsiosAPI(esiosApiUrl, esiosApiToken)
-	pricesProviderREData := redataapi.NewREDataAPI(redataApiUrl)
+	pricesProvidersChain := make([]domain.PricesProvider, 0, len(pricesProviders))
+	for _, name := range pricesProviders {
+		client := resilient.NewClient(name, providersConfig)
+		switch name {
+		case PricesProviderEsios:
+			pricesProvidersChain = append(pricesProvidersChain, esios.NewEsiosAPI(esiosApiUrl, esiosApiToken, client))
+		case PricesProviderREData:
+			pricesProvidersChain = append(pricesProvidersChain, redataapi.NewREDataAPI(redataApiUrl, client))
+		default:
+			logger.Fatal("Unknown prices provider", "provider", name)
+		}
+		s.breakers = append(s.breakers, client.Breaker())
+	}
+	indicatorsClient := resilient.NewClient(PricesProviderEsios+"-indicators", providersConfig)
+	indicatorsProvider := esios.NewEsiosAPI(esiosApiUrl, esiosApiToken, indicatorsClient)
+	s.breakers = append(s.breakers, indicatorsClient.Breaker())
+	spotPricesClient := resilient.NewClient(PricesProviderEsios+"-spot", providersConfig)
+	spotPricesProvider := esios.NewEsiosAPI(esiosApiUrl, esiosApiToken, spotPricesClient)
+	s.breakers = append(s.breakers, spotPricesClient.Breaker())
+
+	indicatorsToIngest, err := servicespkg.ParseIngestedIndicators(ingestedIndicators)
+	if err != nil {
+		logger.Fatal("Invalid indicators to ingest", "err", err)
+	}
+
+	billRates, err := servicespkg.ReadBillRatesFile(billRatesFile)
+	if err != nil {
+		logger.Fatal("Invalid bill rates file", "file", billRatesFile, "err", err)
+	}
 
 	// Repositories
-	pricesRepository := postgresql.NewPricesRepository(s.storage.db, s.storage.dbTimeout)
-	zonesRepository := postgresql.NewZonesRepository(s.storage.db, s.storage.dbTimeout)
+	var pricesRepository domain.PricesRepository
+	var zonesRepository domain.ZonesRepository
+	var indicatorsRepository domain.IndicatorsRepository
+	var spotPricesRepository domain.SpotPricesRepository
+	var holidaysRepository domain.HolidaysRepository
+	var householdsRepository domain.HouseholdsRepository
+	var forecastsRepository domain.ForecastsRepository
+	var quarantineRepository domain.QuarantineRepository
+	switch s.storage.driver {
+	case StorageDriverSQLite:
+		pricesRepository = sqlite.NewPricesRepository(s.storage.db, s.storage.dbTimeout)
+		zonesRepository = sqlite.NewZonesRepository(s.storage.db, s.storage.dbTimeout)
+		indicatorsRepository = sqlite.NewIndicatorsRepository(s.storage.db, s.storage.dbTimeout)
+		spotPricesRepository = sqlite.NewSpotPricesRepository(s.storage.db, s.storage.dbTimeout)
+		holidaysRepository = sqlite.NewHolidaysRepository(s.storage.db, s.storage.dbTimeout)
+		householdsRepository = sqlite.NewHouseholdsRepository(s.storage.db, s.storage.dbTimeout)
+		forecastsRepository = sqlite.NewForecastsRepository(s.storage.db, s.storage.dbTimeout)
+		quarantineRepository = sqlite.NewQuarantineRepository(s.storage.db, s.storage.dbTimeout)
+	default:
+		pricesRepository = postgresql.NewPricesRepository(s.storage.db, s.storage.dbTimeout)
+		zonesRepository = postgresql.NewZonesRepository(s.storage.db, s.storage.dbTimeout)
+		indicatorsRepository = postgresql.NewIndicatorsRepository(s.storage.db, s.storage.dbTimeout)
+		spotPricesRepository = postgresql.NewSpotPricesRepository(s.storage.db, s.storage.dbTimeout)
+		holidaysRepository = postgresql.NewHolidaysRepository(s.storage.db, s.storage.dbTimeout)
+		householdsRepository = postgresql.NewHouseholdsRepository(s.storage.db, s.storage.dbTimeout)
+		forecastsRepository = postgresql.NewForecastsRepository(s.storage.db, s.storage.dbTimeout)
+		quarantineRepository = postgresql.NewQuarantineRepository(s.storage.db, s.storage.dbTimeout)
+	}
 
 	// Services
-	s.services.pricesService = servicespkg.NewPricesService(pricesProviderEsios, pricesProviderREData, pricesRepository, zonesRepository)
+	s.services.pricesService = servicespkg.NewPricesService(pricesProvidersChain, pricesRepository, zonesRepository).
+		WithQuarantine(quarantineRepository, domain.DefaultAnomalyThresholds)
 	s.services.zonesService = servicespkg.NewZonesService(zonesRepository)
+	s.services.indicatorsService = servicespkg.NewIndicatorsService(indicatorsProvider, indicatorsRepository, indicatorsToIngest)
+	s.services.spotPricesService = servicespkg.NewSpotPricesService(spotPricesProvider, spotPricesRepository, zonesRepository)
+	s.services.tariffPeriodsService = servicespkg.NewTariffPeriodsService(holidaysRepository)
+	s.services.billsService = servicespkg.NewBillsService(pricesRepository, zonesRepository, billRates)
+	s.services.householdsService = servicespkg.NewHouseholdsService(householdsRepository, zonesRepository, pricesRepository)
+	s.services.costsService = servicespkg.NewCostsService(pricesRepository, zonesRepository)
+	s.services.plansService = servicespkg.NewPlansService(pricesRepository, zonesRepository)
+	s.services.simulationsService = servicespkg.NewSimulationsService(pricesRepository, zonesRepository)
+	s.services.forecastsService = servicespkg.NewForecastsService(pricesRepository, forecastsRepository, zonesRepository)
+}
+
+// loadHolidays seeds the holidays calendar with the years of holidaysFile, if any, it doesn't have yet,
+// keeping the holidays changed through the admin endpoints. It warns when the calendar doesn't cover
+// the current or the next year.
+func (s *HttpServer) loadHolidays(holidaysFile string) {
+	ctx := context.Background()
+	if holidaysFile != "" {
+		holidaysToLoad, err := servicespkg.ReadHolidaysFile(holidaysFile)
+		if err != nil {
+			logger.Fatal("Invalid holidays file", "file", holidaysFile, "err", err)
+		}
+		seeded, err := s.services.tariffPeriodsService.SeedHolidays(ctx, holidaysToLoad)
+		if err != nil {
+			logger.Fatal("Error loading holidays", "file", holidaysFile, "err", err)
+		}
+		logger.Info("Holidays loaded", "file", holidaysFile, "count", seeded)
+	}
+
+	year := time.Now().Year()
+	uncovered, err := s.services.tariffPeriodsService.UncoveredYears(ctx, year, year+1)
+	if err != nil {
+		logger.Fatal("Error reading holidays", "err", err)
+	}
+	for _, year := range uncovered {
+		logger.Warn("The holidays calendar doesn't cover the year, its national holidays are classified as working days", "year", year)
+	}
 }
 
 func (s *HttpServer) registerRoutes() {
 	// Health check
-	s.engine.GET("/v1/health", health.HealthCheckHandlerV1(s.storage.db, s.storage.dbTimeout))
+	s.engine.GET("/v1/health", health.HealthCheckHandlerV1(s.storage.db, s.storage.dbTimeout, s.breakers...))
 
 	// Prices
-	s.engine.GET("/v1/prices", prices.GetPricesHandlerV1(s.services.pricesService))
-	s.engine.POST("/v1/prices", prices.CreatePricesHandlerV1(s.services.pricesService))
+	s.engine.GET("/v1/prices", prices.GetPricesHandlerV1(s.services.pricesService, s.services.spotPricesService, s.services.tariffPeriodsService))
+	s.engine.POST("/v1/prices", prices.CreatePricesHandlerV1(s.services.pricesService, s.services.forecastsService))
+	s.engine.GET("/v1/prices/:id/revisions", prices.GetPricesRevisionsHandlerV1(s.services.pricesService))
+	s.engine.POST("/v1/prices/cost", prices.CalculateCostHandlerV1(s.services.costsService))
+	s.engine.GET("/v1/prices/forecast", prices.GetForecastHandlerV1(s.services.forecastsService))
+	s.engine.GET("/v1/prices/forecast/accuracy", prices.GetForecastAccuracyHandlerV1(s.services.forecastsService))
+	s.engine.GET("/v1/prices/aggregates", prices.GetPricesAggregatesHandlerV1(s.services.pricesService))
+	s.engine.GET("/v1/prices/compare", prices.ComparePricesHandlerV1(s.services.pricesService))
+
+	// Spot prices
+	s.engine.GET("/v1/spot-prices", prices.GetSpotPricesHandlerV1(s.services.spotPricesService))
+	s.engine.POST("/v1/spot-prices", prices.CreateSpotPricesHandlerV1(s.services.spotPricesService))
 
 	// Zones
 	s.engine.GET("/v1/zones", zones.ListZonesHandlerV1(s.services.zonesService))
+
+	// Indicators
+	s.engine.GET("/v1/indicators/:id", indicators.GetIndicatorHandlerV1(s.services.indicatorsService))
+	s.engine.POST("/v1/indicators", indicators.CreateIndicatorsHandlerV1(s.services.indicatorsService))
+
+	// Bills
+	s.engine.POST("/v1/bills/simulate", bills.SimulateBillHandlerV1(s.services.billsService))
+
+	// Plans
+	s.engine.POST("/v1/plans/ev-charging", plans.PlanEVChargingHandlerV1(s.services.plansService))
+	s.engine.POST("/v1/plans/appliances", plans.PlanAppliancesHandlerV1(s.services.plansService))
+
+	// Simulations
+	s.engine.POST("/v1/simulations/battery", simulations.SimulateBatteryHandlerV1(s.services.simulationsService))
+
+	// Holidays
+	s.engine.GET("/v1/holidays", holidays.ListHolidaysHandlerV1(s.services.tariffPeriodsService))
+
+	// Admin
+	admin := s.engine.Group("/v1/admin", middlewares.AdminAuth(s.adminToken))
+	admin.PUT("/holidays/:date", holidays.SaveHolidayHandlerV1(s.services.tariffPeriodsService))
+	admin.DELETE("/holidays/:date", holidays.DeleteHolidayHandlerV1(s.services.tariffPeriodsService))
+	admin.GET("/quarantine", prices.ListQuarantinedPricesHandlerV1(s.services.pricesService))
+	admin.POST("/quarantine/:id/approve", prices.ReviewQuarantinedPricesHandlerV1(s.services.pricesService, domain.ApprovedQuarantine))
+	admin.POST("/quarantine/:id/reject", prices.ReviewQuarantinedPricesHandlerV1(s.services.pricesService, domain.RejectedQuarantine))
+	// Households consumption is personal data of the supply point holders, so only admins handle it.
+	admin.POST("/households/consumption", households.ImportConsumptionHandlerV1(s.services.householdsService))
+	admin.GET("/households/:cups/costs", households.GetCostsHandlerV1(s.services.householdsService))
 }
 
 func (s *HttpServer) Run() {
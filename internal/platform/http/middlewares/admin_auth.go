@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/http/responses"
+)
+
+// AdminAuth is a gin.HandlerFunc that only lets through the requests with
+// an "Authorization: Bearer <token>" header matching the given admin token.
+// If token is empty, every request is rejected.
+// It is intended to be used as a middleware of the admin routes.
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		bearer, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			statusCode, response := responses.NewAPIErrorResponse(errors.NewDomainError(errors.Unauthorized, "a valid admin token is required"))
+			ctx.AbortWithStatusJSON(statusCode, response)
+			return
+		}
+
+		ctx.Next()
+	}
+}
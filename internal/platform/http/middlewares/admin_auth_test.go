@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/pkg/logger"
+)
+
+func TestAdminAuth(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		expected      int
+	}{
+		{name: "request with the admin token is let through", token: "secret", authorization: "Bearer secret", expected: http.StatusNoContent},
+		{name: "request without token is rejected", token: "secret", authorization: "", expected: http.StatusUnauthorized},
+		{name: "request with a wrong token is rejected", token: "secret", authorization: "Bearer wrong", expected: http.StatusUnauthorized},
+		{name: "request without bearer scheme is rejected", token: "secret", authorization: "secret", expected: http.StatusUnauthorized},
+		{name: "every request is rejected when no admin token is configured", token: "", authorization: "Bearer ", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/admin", AdminAuth(tt.token), func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+			req, err := http.NewRequest(http.MethodGet, "/admin", nil)
+			require.NoError(t, err)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expected, rec.Code)
+			if tt.expected == http.StatusUnauthorized {
+				require.JSONEq(t, `{"errorCode":"UNAUTHORIZED","message":"a valid admin token is required","statusCode":401}`, rec.Body.String())
+			}
+		})
+	}
+}
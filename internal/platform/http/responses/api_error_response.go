@@ -35,10 +35,19 @@ func getErrorCode(err error) string {
 
 func mapErrorToStatusCode(err error) int {
 	switch errors.Code(err) {
-	case errors.InvalidPricesID, errors.InvalidZoneID:
+	case errors.InvalidPricesID, errors.InvalidPricesType, errors.InvalidZoneID, errors.InvalidDateRange, errors.InvalidIndicatorID, errors.InvalidTime,
+		errors.InvalidHoliday, errors.InvalidConsumption, errors.InvalidContractedPower, errors.InvalidRequestBody,
+		errors.InvalidCUPS, errors.InvalidChargingPlan, errors.InvalidBattery,
+		errors.InvalidAppliancesPlan, errors.InvalidForecastModel, errors.InvalidPriceLevels, errors.InvalidGranularity, errors.InvalidQuarantine,
+		errors.InvalidDecimal, errors.InvalidPriceUnit, errors.InvalidTimeZone:
 		return http.StatusBadRequest
-	case errors.ZoneNotFound:
+	case errors.ZoneNotFound, errors.ProviderNotFound, errors.PricesNotFound, errors.IndicatorNotFound, errors.HolidayNotFound,
+		errors.BillRatesNotFound, errors.HouseholdNotFound, errors.QuarantineNotFound, errors.AppliancesPlanNotFound:
 		return http.StatusNotFound
+	case errors.PricesAlreadyStored:
+		return http.StatusConflict
+	case errors.Unauthorized:
+		return http.StatusUnauthorized
 	case errors.ProviderError:
 		return http.StatusServiceUnavailable
 	case errors.PersistenceError, errors.InternalError:
@@ -0,0 +1,175 @@
+// Package consumptioncsv parses the hourly consumption CSV exports of Spanish distributors and Datadis.
+//
+// Exports have, in any order, a CUPS, a date, an hour and a kWh column, separated by ";" or ",".
+// Hours are numbered from 1 to 24 (or 23 and 25 on DST change days), the first one being 00:00-01:00,
+// either as a number or as the hour end time (e.g. "01:00"). Decimal commas are accepted.
+package consumptioncsv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+const (
+	cupsColumn = "cups"
+	dateColumn = "date"
+	hourColumn = "hour"
+	kwhColumn  = "kwh"
+)
+
+// headerAliases maps the normalized header names found in the exports to the columns they hold.
+var headerAliases = map[string]string{
+	"cups":                  cupsColumn,
+	"fecha":                 dateColumn,
+	"date":                  dateColumn,
+	"hora":                  hourColumn,
+	"hour":                  hourColumn,
+	"time":                  hourColumn,
+	"consumo":               kwhColumn,
+	"consumo_kwh":           kwhColumn,
+	"consumokwh":            kwhColumn,
+	"ae_kwh":                kwhColumn,
+	"kwh":                   kwhColumn,
+	"consumption":           kwhColumn,
+	"consumption_kwh":       kwhColumn,
+	"consumptionkwh":        kwhColumn,
+	"energia_consumida_kwh": kwhColumn,
+}
+
+// dateLayouts are the date formats found in the exports.
+var dateLayouts = []string{"2006/01/02", "02/01/2006", "2006-01-02", "02-01-2006"}
+
+// Parse reads a consumption CSV export and returns its readings, in the same order.
+// It returns an InvalidConsumption error pointing to the line of the first malformed record.
+func Parse(r io.Reader) ([]domain.ConsumptionReadingDto, error) {
+	reader := bufio.NewReader(r)
+	if bom, err := reader.Peek(3); err == nil && bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+		_, _ = reader.Discard(3)
+	}
+	header, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, errors.WrapIntoDomainError(err, errors.InvalidConsumption, "error reading consumption CSV")
+	}
+	if strings.TrimSpace(header) == "" {
+		return nil, errors.NewDomainError(errors.InvalidConsumption, "consumption CSV is empty")
+	}
+
+	csvReader := csv.NewReader(io.MultiReader(strings.NewReader(header), reader))
+	csvReader.Comma = separator(header)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.InvalidConsumption, "error reading consumption CSV")
+	}
+
+	columns, err := headerColumns(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make([]domain.ConsumptionReadingDto, 0, len(records)-1)
+	for i, record := range records[1:] {
+		line := i + 2
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		reading, err := parseRecord(record, columns)
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.InvalidConsumption, fmt.Sprintf("invalid consumption CSV line %d", line))
+		}
+		readings = append(readings, reading)
+	}
+
+	return readings, nil
+}
+
+// separator returns the fields separator of the export, guessed from its header line.
+func separator(header string) rune {
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		return ';'
+	}
+	return ','
+}
+
+// headerColumns returns the index of every column in the header record.
+func headerColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		normalized := strings.ToLower(strings.Join(strings.Fields(strings.TrimSpace(name)), "_"))
+		if column, ok := headerAliases[normalized]; ok {
+			if _, repeated := columns[column]; !repeated {
+				columns[column] = i
+			}
+		}
+	}
+
+	for _, column := range []string{cupsColumn, dateColumn, hourColumn, kwhColumn} {
+		if _, ok := columns[column]; !ok {
+			return nil, errors.NewDomainError(errors.InvalidConsumption, "consumption CSV has no %s column", column)
+		}
+	}
+	return columns, nil
+}
+
+func parseRecord(record []string, columns map[string]int) (domain.ConsumptionReadingDto, error) {
+	field := func(column string) string {
+		if i := columns[column]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	date, err := parseDate(field(dateColumn))
+	if err != nil {
+		return domain.ConsumptionReadingDto{}, err
+	}
+	hour, err := parseHour(field(hourColumn))
+	if err != nil {
+		return domain.ConsumptionReadingDto{}, err
+	}
+	kwh, err := strconv.ParseFloat(strings.Replace(field(kwhColumn), ",", ".", 1), 64)
+	if err != nil {
+		return domain.ConsumptionReadingDto{}, fmt.Errorf("invalid kWh value: %s", field(kwhColumn))
+	}
+
+	return domain.ConsumptionReadingDto{
+		CUPS: field(cupsColumn),
+		Date: date,
+		Hour: hour,
+		Kwh:  kwh,
+	}, nil
+}
+
+// parseDate returns the given date as YYYY-MM-DD.
+func parseDate(value string) (string, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.Format(time.DateOnly), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date: %s", value)
+}
+
+// parseHour returns the number of the given hour, which can be a number or the hour end time (HH:MM).
+func parseHour(value string) (int, error) {
+	hour, minutes, isTime := strings.Cut(value, ":")
+	if isTime && minutes != "00" {
+		return 0, fmt.Errorf("invalid hour: %s", value)
+	}
+	n, err := strconv.Atoi(hour)
+	if err != nil || n < 1 || n > 25 {
+		return 0, fmt.Errorf("invalid hour: %s", value)
+	}
+	return n, nil
+}
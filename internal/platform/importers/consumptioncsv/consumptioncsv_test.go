@@ -0,0 +1,79 @@
+package consumptioncsv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+func Test_Parse(t *testing.T) {
+	t.Run("parses a Datadis export", func(t *testing.T) {
+		export := "\xEF\xBB\xBFcups;date;time;consumptionKWh;obtainMethod\n" +
+			"ES0021000000000001AA;2023/10/11;01:00;0,123;Real\n" +
+			"ES0021000000000001AA;2023/10/11;24:00;1,5;Real\n"
+
+		readings, err := Parse(strings.NewReader(export))
+
+		require.NoError(t, err)
+		require.Equal(t, []domain.ConsumptionReadingDto{
+			{CUPS: "ES0021000000000001AA", Date: "2023-10-11", Hour: 1, Kwh: 0.123},
+			{CUPS: "ES0021000000000001AA", Date: "2023-10-11", Hour: 24, Kwh: 1.5},
+		}, readings)
+	})
+
+	t.Run("parses a distributor export", func(t *testing.T) {
+		export := "CUPS;Fecha;Hora;AE_kWh;REAL/ESTIMADO\r\n" +
+			"ES0021000000000001AA;11/10/2023;1;0,2;R\r\n" +
+			"\r\n" +
+			"ES0021000000000001AA;11/10/2023;2;0,3;R\r\n"
+
+		readings, err := Parse(strings.NewReader(export))
+
+		require.NoError(t, err)
+		require.Equal(t, []domain.ConsumptionReadingDto{
+			{CUPS: "ES0021000000000001AA", Date: "2023-10-11", Hour: 1, Kwh: 0.2},
+			{CUPS: "ES0021000000000001AA", Date: "2023-10-11", Hour: 2, Kwh: 0.3},
+		}, readings)
+	})
+
+	t.Run("parses a comma separated export", func(t *testing.T) {
+		export := "cups,fecha,hora,consumo\nES0021000000000001AA,2023-10-29,25,0.4\n"
+
+		readings, err := Parse(strings.NewReader(export))
+
+		require.NoError(t, err)
+		require.Equal(t, []domain.ConsumptionReadingDto{
+			{CUPS: "ES0021000000000001AA", Date: "2023-10-29", Hour: 25, Kwh: 0.4},
+		}, readings)
+	})
+
+	t.Run("returns an error if a column is missing", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("cups;fecha;consumo\nES0021000000000001AA;2023-10-11;0.4\n"))
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("returns an error pointing to the malformed line", func(t *testing.T) {
+		for _, line := range []string{
+			"ES0021000000000001AA;2023-13-11;1;0.4",
+			"ES0021000000000001AA;2023-10-11;26;0.4",
+			"ES0021000000000001AA;2023-10-11;01:30;0.4",
+			"ES0021000000000001AA;2023-10-11;1;n/a",
+		} {
+			_, err := Parse(strings.NewReader("cups;fecha;hora;consumo\nES0021000000000001AA;2023-10-11;1;0.4\n" + line + "\n"))
+
+			require.Equal(t, errors.InvalidConsumption, errors.Code(err), line)
+			require.Contains(t, err.Error(), "line 3", line)
+		}
+	})
+
+	t.Run("returns an error if the export is empty", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(""))
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	"pvpc-backend/internal/domain"
 	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/providers/resilient"
 	"pvpc-backend/pkg/logger"
 )
 
@@ -17,18 +18,49 @@ type EsiosAPI struct {
 	client *sling.Sling
 }
 
+var now = time.Now
+
 const (
+	// providerName identifies this provider in the provenance of the fetched prices.
+	providerName = "esios"
 	// pvpcPricesEndpoint is the endpoint to fetch PVPC prices from REE.
 	pvpcPricesEndpoint = "/indicators/1001"
+	// surplusPricesEndpoint is the endpoint to fetch the self-consumption surplus compensation prices from REE.
+	surplusPricesEndpoint = "/indicators/1739"
+	// indicatorsEndpoint is the endpoint to fetch any indicator from REE.
+	indicatorsEndpoint = "/indicators/"
+	// spotPricesEndpoint is the endpoint to fetch the day-ahead market (OMIE) prices from REE.
+	spotPricesEndpoint = "/indicators/600"
+	// spotPricesGeoID is the geo of the Spanish day-ahead market price, the one that applies to every zone.
+	spotPricesGeoID = 3
 )
 
-func NewEsiosAPI(baseUrl, token string) *EsiosAPI {
+// NewEsiosAPI returns an Esios API adapter that does its requests through doer.
+// If doer is nil, http.DefaultClient is used.
+func NewEsiosAPI(baseUrl, token string, doer sling.Doer) *EsiosAPI {
 	return &EsiosAPI{
-		client: sling.New().Base(baseUrl).Add("x-api-key", token),
+		client: sling.New().Doer(doer).Base(baseUrl).Add("x-api-key", token),
 	}
 }
 
+// Name implements the domain.NamedPricesProvider interface.
+func (r *EsiosAPI) Name() string {
+	return providerName
+}
+
 func (r *EsiosAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, date time.Time) ([]domain.Prices, error) {
+	return r.fetchZonesPrices(ctx, pvpcPricesEndpoint, "PVPC", domain.PVPCPrices, zones, date)
+}
+
+// FetchSurplusPrices implements the domain.SurplusPricesProvider interface.
+// Surplus compensation prices are published per zone along with the PVPC ones.
+func (r *EsiosAPI) FetchSurplusPrices(ctx context.Context, zones []domain.Zone, date time.Time) ([]domain.Prices, error) {
+	return r.fetchZonesPrices(ctx, surplusPricesEndpoint, "surplus", domain.SurplusPrices, zones, date)
+}
+
+// fetchZonesPrices fetches the prices of pricesType published by endpoint, an indicator with a value
+// per hour and zone, for the given zones and date. name is used to describe the prices in logs and errors.
+func (r *EsiosAPI) fetchZonesPrices(ctx context.Context, endpoint, name string, pricesType domain.PricesType, zones []domain.Zone, date time.Time) ([]domain.Prices, error) {
 	if len(zones) == 0 {
 		return nil, nil
 	}
@@ -59,15 +91,22 @@ func (r *EsiosAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, dat
 	resBody := new(fetchPVPCPricesResponse)
 	query := fetchPVPCPricesRequest{StartDate: startDate, EndDate: endDate, GeoIds: geoIDs}
 
-	logger.DebugContext(ctx, "fetching PVPC prices from Esios", "zones", zonesNames, "query", query)
-	_, err := r.client.Path(pvpcPricesEndpoint).QueryStruct(query).Add("Accept", "application/json").ReceiveSuccess(resBody)
+	logger.DebugContext(ctx, fmt.Sprintf("fetching %s prices from Esios", name), "zones", zonesNames, "query", query)
+	req := r.client.New().Path(endpoint).QueryStruct(query).Add("Accept", "application/json")
+	err := resilient.Receive(ctx, req, resBody)
 
 	if err != nil || len(resBody.Indicator.Values) == 0 {
-		msg := "error fetching PVPC prices from Esios API"
+		msg := fmt.Sprintf("error fetching %s prices from Esios API", name)
 		logger.ErrorContext(ctx, msg, "err", err, "zones", zonesNames)
 		return nil, errors.WrapIntoDomainError(err, errors.ProviderError, msg)
 	}
 
+	provenance := &domain.ProvenanceDto{
+		Provider:  providerName,
+		FetchedAt: now().Format(time.RFC3339),
+		Source:    resilient.RequestURI(req),
+	}
+
 	for _, value := range resBody.Indicator.Values {
 
 		pricesDto, ok := pricesDtoMap[value.GeoID]
@@ -76,13 +115,15 @@ func (r *EsiosAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, dat
 			zone := zonesMapByExternalID[value.GeoID]
 			pricesDto = domain.PricesDto{
 				ID:   fmt.Sprintf("%s-%s", zone.ID().String(), dateString),
+				Type: pricesType.String(),
 				Date: dateTruncated.Format(time.RFC3339),
 				Zone: domain.ZoneDto{
 					ID:         zone.ID().String(),
 					ExternalID: zone.ExternalID(),
 					Name:       zone.Name(),
 				},
-				Values: make([]domain.HourlyPriceDto, 0, 24),
+				Values:     make([]domain.HourlyPriceDto, 0, 24),
+				Provenance: provenance,
 			}
 		}
 
@@ -108,3 +149,103 @@ func (r *EsiosAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, dat
 	return prices, nil
 
 }
+
+// FetchIndicator implements the domain.IndicatorsProvider interface.
+// Unlike PVPC prices, an indicator without values for the date is not an error,
+// as many indicators are published later or with gaps.
+func (r *EsiosAPI) FetchIndicator(ctx context.Context, id domain.IndicatorID, geoIDs []string, date time.Time) (domain.Indicator, error) {
+	dateString := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location()).Format("2006-01-02")
+
+	resBody := new(fetchIndicatorResponse)
+	query := fetchIndicatorRequest{StartDate: dateString + "T00:00:00", EndDate: dateString + "T23:59:59", GeoIds: geoIDs}
+
+	logger.DebugContext(ctx, "fetching indicator from Esios", "indicator", id.String(), "query", query)
+	req := r.client.New().Path(indicatorsEndpoint+id.String()).QueryStruct(query).Add("Accept", "application/json")
+	if err := resilient.Receive(ctx, req, resBody); err != nil {
+		msg := "error fetching indicator from Esios API"
+		logger.ErrorContext(ctx, msg, "err", err, "indicator", id.String())
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.ProviderError, msg)
+	}
+
+	indicatorDto := domain.IndicatorDto{
+		ID:     id.String(),
+		Name:   resBody.Indicator.Name,
+		Values: make([]domain.IndicatorValueDto, len(resBody.Indicator.Values)),
+	}
+	for i, value := range resBody.Indicator.Values {
+		indicatorDto.Values[i] = domain.IndicatorValueDto{
+			Datetime: value.Datetime,
+			GeoID:    strconv.Itoa(value.GeoID),
+			GeoName:  value.GeoName,
+			Value:    value.Value,
+		}
+	}
+
+	indicator, err := domain.NewIndicator(indicatorDto)
+	if err != nil {
+		logger.ErrorContext(ctx, "error creating Indicator domain object", "err", err, "indicator", indicatorDto.ID)
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.ProviderError, "error mapping indicator from Esios API")
+	}
+
+	return indicator, nil
+}
+
+// FetchSpotPrices implements the domain.SpotPricesProvider interface.
+// The day-ahead market price is the same for every zone, so all of them get the Spanish one.
+func (r *EsiosAPI) FetchSpotPrices(ctx context.Context, zones []domain.Zone, date time.Time) ([]domain.Prices, error) {
+	if len(zones) == 0 {
+		return nil, nil
+	}
+
+	dateTruncated := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	dateString := dateTruncated.Format("2006-01-02")
+
+	resBody := new(fetchIndicatorResponse)
+	query := fetchIndicatorRequest{StartDate: dateString + "T00:00:00", EndDate: dateString + "T23:59:59", GeoIds: []string{strconv.Itoa(spotPricesGeoID)}}
+
+	logger.DebugContext(ctx, "fetching spot prices from Esios", "query", query)
+	req := r.client.New().Path(spotPricesEndpoint).QueryStruct(query).Add("Accept", "application/json")
+	err := resilient.Receive(ctx, req, resBody)
+
+	values := make([]domain.HourlyPriceDto, 0, 24)
+	if err == nil {
+		for _, value := range resBody.Indicator.Values {
+			if value.GeoID == spotPricesGeoID {
+				values = append(values, domain.HourlyPriceDto{Datetime: value.Datetime, Value: value.Value})
+			}
+		}
+	}
+
+	if err != nil || len(values) == 0 {
+		msg := "error fetching spot prices from Esios API"
+		logger.ErrorContext(ctx, msg, "err", err)
+		return nil, errors.WrapIntoDomainError(err, errors.ProviderError, msg)
+	}
+
+	provenance := &domain.ProvenanceDto{
+		Provider:  providerName,
+		FetchedAt: now().Format(time.RFC3339),
+		Source:    resilient.RequestURI(req),
+	}
+
+	prices := make([]domain.Prices, 0, len(zones))
+	for _, zone := range zones {
+		pricesDto := domain.PricesDto{
+			ID:         fmt.Sprintf("%s-%s", zone.ID().String(), dateString),
+			Type:       domain.SpotPrices.String(),
+			Date:       dateTruncated.Format(time.RFC3339),
+			Zone:       zone.Serialize(),
+			Values:     values,
+			Provenance: provenance,
+		}
+
+		pricesDomain, err := domain.NewPrices(pricesDto)
+		if err != nil {
+			logger.ErrorContext(ctx, "error creating Prices domain object", "err", err, "prices", pricesDto)
+			continue
+		}
+		prices = append(prices, pricesDomain)
+	}
+
+	return prices, nil
+}
@@ -2,14 +2,17 @@ package esios
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
 	"pvpc-backend/internal/domain"
 	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/providers/fakeree"
 
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/stretchr/testify/require"
@@ -32,6 +35,8 @@ func Test_FetchPVPCPrices_Success(t *testing.T) {
 		w.Write(res)
 	}))
 	defer server.Close()
+	now = func() time.Time { return time.Date(2023, 9, 8, 18, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
 
 	zone1, err := domain.NewZone(domain.ZoneDto{ID: "FOO", ExternalID: "1234", Name: "Foo Zone"})
 	require.NoError(t, err)
@@ -41,7 +46,7 @@ func Test_FetchPVPCPrices_Success(t *testing.T) {
 	date, err := time.Parse("2006-01-02T15:04:05Z", "2023-09-08T17:54:36Z")
 	require.NoError(t, err)
 
-	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN)
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
 	prices, err := adapter.FetchPVPCPrices(context.Background(), []domain.Zone{zone1, zone2}, date)
 	require.NoError(t, err)
 	require.Len(t, prices, 2)
@@ -66,9 +71,239 @@ func Test_FetchPVPCPrices_Error(t *testing.T) {
 	date, err := time.Parse("2006-01-02T15:04:05Z", "2023-09-08T17:54:36Z")
 	require.NoError(t, err)
 
-	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN)
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
 	prices, err := adapter.FetchPVPCPrices(context.Background(), []domain.Zone{zone}, date)
 	require.Error(t, err)
 	require.Equal(t, errors.ProviderError, errors.Code(err))
 	require.Len(t, prices, 0)
 }
+
+func Test_FetchPVPCPrices_FakeREE(t *testing.T) {
+	fake, err := fakeree.NewServer("../../../../docs", MOCK_TOKEN)
+	require.NoError(t, err)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	zones := make([]domain.Zone, 0, 5)
+	for i, id := range []string{"PEN", "CAN", "BAL", "CEU", "MEL"} {
+		zone, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: fmt.Sprintf("%d", 8741+i), Name: id})
+		require.NoError(t, err)
+		zones = append(zones, zone)
+	}
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+
+	t.Run("fetches every zone", func(t *testing.T) {
+		fake.ClearFaults()
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 5)
+		for _, p := range prices {
+			require.Len(t, p.Values(), 24)
+			require.Equal(t, "2023-09-08", p.Date().Format("2006-01-02"))
+		}
+	})
+
+	t.Run("fails when the API errors", func(t *testing.T) {
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{StatusCode: http.StatusInternalServerError})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderError, errors.Code(err))
+		require.Len(t, prices, 0)
+	})
+
+	t.Run("fails when the API returns no values", func(t *testing.T) {
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{EmptyValues: true})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderError, errors.Code(err))
+		require.Len(t, prices, 0)
+	})
+
+	t.Run("returns only the zones present in the response", func(t *testing.T) {
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{MissingGeoIDs: []string{"8742", "8745"}})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 3)
+		for _, p := range prices {
+			require.NotContains(t, []string{"CAN", "MEL"}, p.Zone().ID().String())
+		}
+	})
+}
+
+func Test_FetchIndicator_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/indicators/600", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("accept"))
+		require.Equal(t, MOCK_TOKEN, r.Header.Get("x-api-key"))
+		require.Equal(t, "end_date=2023-09-08T23%3A59%3A59&geo_ids%5B%5D=1&geo_ids%5B%5D=3&start_date=2023-09-08T00%3A00%3A00", r.URL.RawQuery)
+
+		res, err := os.ReadFile("./mocks/fetch_indicator_response.json")
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(res)
+	}))
+	defer server.Close()
+
+	id, err := domain.NewIndicatorID("600")
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 17, 54, 36, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	indicator, err := adapter.FetchIndicator(context.Background(), id, []string{"1", "3"}, date)
+	require.NoError(t, err)
+	require.Len(t, indicator.Values(), 4)
+	snaps.MatchSnapshot(t, indicator.Serialize())
+}
+
+func Test_FetchIndicator_AllGeos(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/indicators/1293", r.URL.Path)
+		require.Equal(t, "end_date=2023-09-08T23%3A59%3A59&start_date=2023-09-08T00%3A00%3A00", r.URL.RawQuery)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"indicator":{"id":1293,"name":"Demanda real","values":[]}}`))
+	}))
+	defer server.Close()
+
+	id, err := domain.NewIndicatorID("1293")
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	indicator, err := adapter.FetchIndicator(context.Background(), id, nil, date)
+	require.NoError(t, err)
+	require.Equal(t, "Demanda real", indicator.Name())
+	require.Empty(t, indicator.Values())
+}
+
+func Test_FetchIndicator_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("error"))
+	}))
+	defer server.Close()
+
+	id, err := domain.NewIndicatorID("600")
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	_, err = adapter.FetchIndicator(context.Background(), id, nil, date)
+	require.Error(t, err)
+	require.Equal(t, errors.ProviderError, errors.Code(err))
+}
+
+func Test_FetchSpotPrices_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, spotPricesEndpoint, r.URL.Path)
+		require.Equal(t, MOCK_TOKEN, r.Header.Get("x-api-key"))
+		require.Equal(t, "end_date=2023-09-08T23%3A59%3A59&geo_ids%5B%5D=3&start_date=2023-09-08T00%3A00%3A00", r.URL.RawQuery)
+
+		res, err := os.ReadFile("./mocks/fetch_indicator_response.json")
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(res)
+	}))
+	defer server.Close()
+	now = func() time.Time { return time.Date(2023, 9, 7, 14, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	zone1, err := domain.NewZone(domain.ZoneDto{ID: "FOO", ExternalID: "1234", Name: "Foo Zone"})
+	require.NoError(t, err)
+	zone2, err := domain.NewZone(domain.ZoneDto{ID: "BAR", ExternalID: "5678", Name: "Bar Zone"})
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	prices, err := adapter.FetchSpotPrices(context.Background(), []domain.Zone{zone1, zone2}, date)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	require.Equal(t, prices[0].Serialize().Values, prices[1].Serialize().Values)
+	snaps.MatchSnapshot(t, prices[0].Serialize())
+}
+
+func Test_FetchSpotPrices_Error(t *testing.T) {
+	zone, err := domain.NewZone(domain.ZoneDto{ID: "ZON", ExternalID: "1234", Name: "Zone Name"})
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	for name, handler := range map[string]http.HandlerFunc{
+		"API error": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("error"))
+		},
+		"no values": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"indicator":{"id":600,"values":[]}}`))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+			prices, err := adapter.FetchSpotPrices(context.Background(), []domain.Zone{zone}, date)
+			require.Error(t, err)
+			require.Equal(t, errors.ProviderError, errors.Code(err))
+			require.Len(t, prices, 0)
+		})
+	}
+}
+
+func Test_FetchSurplusPrices_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, surplusPricesEndpoint, r.URL.Path)
+		require.Equal(t, MOCK_TOKEN, r.Header.Get("x-api-key"))
+		require.Equal(t, "end_date=2023-09-08T23%3A59%3A59&geo_ids%5B%5D=1234&geo_ids%5B%5D=5678&start_date=2023-09-08T00%3A00%3A00", r.URL.RawQuery)
+
+		res, err := os.ReadFile("./mocks/fetch_surplus_response.json")
+		require.NoError(t, err)
+
+		w.WriteHeader(http.StatusOK)
+		w.Write(res)
+	}))
+	defer server.Close()
+	now = func() time.Time { return time.Date(2023, 9, 7, 20, 30, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	zone1, err := domain.NewZone(domain.ZoneDto{ID: "FOO", ExternalID: "1234", Name: "Foo Zone"})
+	require.NoError(t, err)
+	zone2, err := domain.NewZone(domain.ZoneDto{ID: "BAR", ExternalID: "5678", Name: "Bar Zone"})
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	prices, err := adapter.FetchSurplusPrices(context.Background(), []domain.Zone{zone1, zone2}, date)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	for _, p := range prices {
+		require.Equal(t, domain.SurplusPrices, p.Type())
+	}
+	sort.Slice(prices, func(i, j int) bool { return prices[i].ID().String() < prices[j].ID().String() })
+	snaps.MatchSnapshot(t, prices[0].Serialize(), prices[1].Serialize())
+}
+
+func Test_FetchSurplusPrices_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, surplusPricesEndpoint, r.URL.Path)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("error"))
+	}))
+	defer server.Close()
+
+	zone, err := domain.NewZone(domain.ZoneDto{ID: "ZON", ExternalID: "1234", Name: "Zone Name"})
+	require.NoError(t, err)
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	adapter := NewEsiosAPI(server.URL, MOCK_TOKEN, nil)
+	prices, err := adapter.FetchSurplusPrices(context.Background(), []domain.Zone{zone}, date)
+	require.Error(t, err)
+	require.Equal(t, errors.ProviderError, errors.Code(err))
+	require.Len(t, prices, 0)
+}
@@ -5,3 +5,9 @@ type fetchPVPCPricesRequest struct {
 	EndDate   string   `url:"end_date"`
 	GeoIds    []string `url:"geo_ids[]"`
 }
+
+type fetchIndicatorRequest struct {
+	StartDate string   `url:"start_date"`
+	EndDate   string   `url:"end_date"`
+	GeoIds    []string `url:"geo_ids[],omitempty"`
+}
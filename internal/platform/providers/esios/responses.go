@@ -12,3 +12,16 @@ type fetchPVPCPricesResponse struct {
 		} `json:"values"`
 	} `json:"indicator"`
 }
+
+type fetchIndicatorResponse struct {
+	Indicator struct {
+		ID     int    `json:"id"`
+		Name   string `json:"name"`
+		Values []struct {
+			Value    float64 `json:"value"`
+			Datetime string  `json:"datetime"`
+			GeoID    int     `json:"geo_id"`
+			GeoName  string  `json:"geo_name"`
+		} `json:"values"`
+	} `json:"indicator"`
+}
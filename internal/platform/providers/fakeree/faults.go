@@ -0,0 +1,67 @@
+package fakeree
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Fault describes a misbehavior to inject in the responses of a faked API.
+type Fault struct {
+	// StatusCode, when not zero, replaces the fixture response with an error response with this status.
+	StatusCode int
+	// RetryAfter, when not empty, is sent as the Retry-After header of the error response.
+	RetryAfter string
+	// Delay is waited before responding, to trigger client timeouts.
+	Delay time.Duration
+	// EmptyValues makes the response contain no price values.
+	EmptyValues bool
+	// MissingGeoIDs are the geo IDs (zone external IDs) left out of the response.
+	MissingGeoIDs []string
+	// Times is the number of requests affected by the fault. Zero means every request.
+	Times int
+}
+
+// faultRequest is the JSON representation of a Fault accepted by the faults endpoint.
+type faultRequest struct {
+	StatusCode    int      `json:"status_code"`
+	RetryAfter    string   `json:"retry_after"`
+	Delay         string   `json:"delay"`
+	EmptyValues   bool     `json:"empty_values"`
+	MissingGeoIDs []string `json:"missing_geo_ids"`
+	Times         int      `json:"times"`
+}
+
+// UnmarshalJSON decodes a Fault, reading Delay as a duration string (e.g. "30s").
+func (f *Fault) UnmarshalJSON(data []byte) error {
+	var req faultRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return err
+	}
+
+	var delay time.Duration
+	if req.Delay != "" {
+		var err error
+		if delay, err = time.ParseDuration(req.Delay); err != nil {
+			return err
+		}
+	}
+
+	*f = Fault{
+		StatusCode:    req.StatusCode,
+		RetryAfter:    req.RetryAfter,
+		Delay:         delay,
+		EmptyValues:   req.EmptyValues,
+		MissingGeoIDs: req.MissingGeoIDs,
+		Times:         req.Times,
+	}
+	return nil
+}
+
+func (f Fault) missingGeoID(geoID string) bool {
+	for _, id := range f.MissingGeoIDs {
+		if id == geoID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package fakeree
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// esiosFixtureFile is the Esios /indicators/1001 response example, relative to the docs directory.
+	esiosFixtureFile = "ree_request_example.jsonc"
+	// redataFixtureFile is the REData API prices response example, relative to the docs directory.
+	redataFixtureFile = "REData API.jsonc"
+	// datetimeLayout is the local datetime layout used by both REE APIs.
+	datetimeLayout = "2006-01-02T15:04:05.000-07:00"
+)
+
+// esiosFixture holds the Esios indicator response example.
+// Values are grouped by geo ID, in hour order, so they can be rebased to any date.
+type esiosFixture struct {
+	raw      []byte
+	geoIDs   []uint16
+	geoNames map[uint16]string
+	values   map[uint16][]float64
+}
+
+// redataFixture holds the REData API response example.
+// Values are in hour order, so they can be rebased to any date.
+type redataFixture struct {
+	raw    []byte
+	values []float64
+}
+
+func loadEsiosFixture(docsDir string) (esiosFixture, error) {
+	raw, err := readJSONC(filepath.Join(docsDir, esiosFixtureFile))
+	if err != nil {
+		return esiosFixture{}, err
+	}
+
+	var parsed struct {
+		Indicator struct {
+			Values []struct {
+				Value   float64 `json:"value"`
+				GeoID   uint16  `json:"geo_id"`
+				GeoName string  `json:"geo_name"`
+			} `json:"values"`
+		} `json:"indicator"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return esiosFixture{}, fmt.Errorf("error decoding fixture %s: %w", esiosFixtureFile, err)
+	}
+
+	fixture := esiosFixture{
+		raw:      raw,
+		geoNames: make(map[uint16]string),
+		values:   make(map[uint16][]float64),
+	}
+	for _, v := range parsed.Indicator.Values {
+		if _, ok := fixture.geoNames[v.GeoID]; !ok {
+			fixture.geoIDs = append(fixture.geoIDs, v.GeoID)
+		}
+		fixture.geoNames[v.GeoID] = v.GeoName
+		fixture.values[v.GeoID] = append(fixture.values[v.GeoID], v.Value)
+	}
+
+	return fixture, nil
+}
+
+func loadREDataFixture(docsDir string) (redataFixture, error) {
+	raw, err := readJSONC(filepath.Join(docsDir, redataFixtureFile))
+	if err != nil {
+		return redataFixture{}, err
+	}
+
+	var parsed struct {
+		Included []struct {
+			Attributes struct {
+				Values []struct {
+					Value float64 `json:"value"`
+				} `json:"values"`
+			} `json:"attributes"`
+		} `json:"included"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return redataFixture{}, fmt.Errorf("error decoding fixture %s: %w", redataFixtureFile, err)
+	}
+	if len(parsed.Included) == 0 {
+		return redataFixture{}, fmt.Errorf("fixture %s has no included values", redataFixtureFile)
+	}
+
+	fixture := redataFixture{raw: raw}
+	for _, v := range parsed.Included[0].Attributes.Values {
+		fixture.values = append(fixture.values, v.Value)
+	}
+
+	return fixture, nil
+}
+
+// readJSONC reads a JSON file whose lines may be // comments, like the ones in docs/,
+// and returns its content without them.
+func readJSONC(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %w", path, err)
+	}
+
+	var clean bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		clean.WriteString(line)
+		clean.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %w", path, err)
+	}
+
+	return clean.Bytes(), nil
+}
+
+// dayHours returns the start of every hour of the given day in loc.
+// DST days have 23 or 25 hours.
+func dayHours(day time.Time, loc *time.Location) []time.Time {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+
+	hours := make([]time.Time, 0, 25)
+	for h := start; h.Before(end); h = h.Add(time.Hour) {
+		hours = append(hours, h.In(loc))
+	}
+	return hours
+}
@@ -0,0 +1,298 @@
+// Package fakeree implements a fake of the REE APIs (Esios and REData) that serves
+// the response examples stored in docs/, rebased to the requested date, and allows
+// injecting faults. It is meant for offline end-to-end testing of the providers.
+package fakeree
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pvpc-backend/pkg/logger"
+)
+
+// API identifies one of the faked REE APIs.
+type API string
+
+const (
+	// Esios is the Esios API (api.esios.ree.es).
+	Esios API = "esios"
+	// REData is the REData API (apidatos.ree.es).
+	REData API = "redata"
+)
+
+const (
+	// EsiosPVPCPricesPath is the Esios endpoint serving PVPC prices.
+	EsiosPVPCPricesPath = "/indicators/1001"
+	// REDataPVPCPricesPath is the REData API endpoint serving PVPC prices.
+	REDataPVPCPricesPath = "/es/datos/mercados/precios-mercados-tiempo-real"
+	// FaultsPath is the endpoint to inject faults: PUT /faults/{api} with a Fault JSON body,
+	// DELETE /faults to clear every fault.
+	FaultsPath = "/faults"
+	// pricesLocation is the time zone the REE APIs use for datetimes.
+	pricesLocation = "Europe/Madrid"
+)
+
+// Server is a fake REE APIs server. It implements http.Handler.
+type Server struct {
+	mu       sync.Mutex
+	token    string
+	location *time.Location
+	esios    esiosFixture
+	redata   redataFixture
+	faults   map[API]*Fault
+	requests map[API]int
+	mux      *http.ServeMux
+}
+
+// NewServer loads the fixtures from docsDir and returns a fake REE server.
+// If token is not empty, Esios requests must send it in the x-api-key header.
+func NewServer(docsDir, token string) (*Server, error) {
+	esios, err := loadEsiosFixture(docsDir)
+	if err != nil {
+		return nil, err
+	}
+	redata, err := loadREDataFixture(docsDir)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(pricesLocation)
+	if err != nil {
+		logger.Warn("error loading timezone, using UTC", "location", pricesLocation, "err", err)
+		loc = time.UTC
+	}
+
+	s := &Server{
+		token:    token,
+		location: loc,
+		esios:    esios,
+		redata:   redata,
+		faults:   make(map[API]*Fault),
+		requests: make(map[API]int),
+		mux:      http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc(EsiosPVPCPricesPath, s.handleEsiosPVPCPrices)
+	s.mux.HandleFunc(REDataPVPCPricesPath, s.handleREDataPVPCPrices)
+	s.mux.HandleFunc(FaultsPath, s.handleFaults)
+	s.mux.HandleFunc(FaultsPath+"/", s.handleFaults)
+
+	return s, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// InjectFault makes the following requests to the given API misbehave as described by fault.
+// It replaces any fault previously injected for that API.
+func (s *Server) InjectFault(api API, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[api] = &fault
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = make(map[API]*Fault)
+}
+
+// Requests returns the number of requests received by the given API.
+func (s *Server) Requests(api API) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[api]
+}
+
+// begin accounts a request to the given API and returns the fault to apply to it, if any.
+func (s *Server) begin(api API) Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[api]++
+	fault, ok := s.faults[api]
+	if !ok {
+		return Fault{}
+	}
+	if fault.Times > 0 {
+		fault.Times--
+		if fault.Times == 0 {
+			delete(s.faults, api)
+		}
+	}
+	return *fault
+}
+
+// applyFault waits for the fault delay and writes the fault error response, if any.
+// It returns true when the request has already been answered.
+func applyFault(w http.ResponseWriter, r *http.Request, fault Fault) bool {
+	if fault.Delay > 0 {
+		select {
+		case <-time.After(fault.Delay):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+
+	if fault.StatusCode != 0 {
+		if fault.RetryAfter != "" {
+			w.Header().Set("Retry-After", fault.RetryAfter)
+		}
+		writeJSON(w, fault.StatusCode, map[string]string{"error": "injected fault"})
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) handleEsiosPVPCPrices(w http.ResponseWriter, r *http.Request) {
+	fault := s.begin(Esios)
+	if applyFault(w, r, fault) {
+		return
+	}
+
+	if s.token != "" && r.Header.Get("x-api-key") != s.token {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid token"})
+		return
+	}
+
+	query := r.URL.Query()
+	day, ok := parseDay(query.Get("start_date"))
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid start_date"})
+		return
+	}
+
+	geoIDs := s.esios.geoIDs
+	if requested := query["geo_ids[]"]; len(requested) > 0 {
+		geoIDs = make([]uint16, 0, len(requested))
+		for _, id := range requested {
+			geoID, err := strconv.ParseUint(id, 10, 16)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid geo_ids[]"})
+				return
+			}
+			geoIDs = append(geoIDs, uint16(geoID))
+		}
+	}
+
+	values := make([]map[string]interface{}, 0, len(geoIDs)*24)
+	if !fault.EmptyValues {
+		hours := dayHours(day, s.location)
+		for _, geoID := range geoIDs {
+			fixtureValues, ok := s.esios.values[geoID]
+			if !ok || fault.missingGeoID(strconv.FormatUint(uint64(geoID), 10)) {
+				continue
+			}
+			for i, hour := range hours {
+				values = append(values, map[string]interface{}{
+					"value":        fixtureValues[i%len(fixtureValues)],
+					"datetime":     hour.Format(datetimeLayout),
+					"datetime_utc": hour.UTC().Format(time.RFC3339),
+					"tz_time":      hour.UTC().Format("2006-01-02T15:04:05.000Z"),
+					"geo_id":       geoID,
+					"geo_name":     s.esios.geoNames[geoID],
+				})
+			}
+		}
+	}
+
+	var response map[string]map[string]interface{}
+	if err := json.Unmarshal(s.esios.raw, &response); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	response["indicator"]["values"] = values
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleREDataPVPCPrices(w http.ResponseWriter, r *http.Request) {
+	fault := s.begin(REData)
+	if applyFault(w, r, fault) {
+		return
+	}
+
+	query := r.URL.Query()
+	day, ok := parseDay(query.Get("start_date"))
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid start_date"})
+		return
+	}
+
+	var response map[string]interface{}
+	if err := json.Unmarshal(s.redata.raw, &response); err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	if fault.missingGeoID(query.Get("geo_ids")) {
+		response["included"] = []interface{}{}
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+
+	values := make([]map[string]interface{}, 0, 25)
+	if !fault.EmptyValues {
+		for i, hour := range dayHours(day, s.location) {
+			values = append(values, map[string]interface{}{
+				"value":      s.redata.values[i%len(s.redata.values)],
+				"percentage": 1,
+				"datetime":   hour.Format(datetimeLayout),
+			})
+		}
+	}
+
+	included := response["included"].([]interface{})
+	attributes := included[0].(map[string]interface{})["attributes"].(map[string]interface{})
+	attributes["values"] = values
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodDelete:
+		s.ClearFaults()
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		api := API(strings.TrimPrefix(r.URL.Path, FaultsPath+"/"))
+		if api != Esios && api != REData {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "unknown API"})
+			return
+		}
+		var fault Fault
+		if err := json.NewDecoder(r.Body).Decode(&fault); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		s.InjectFault(api, fault)
+		logger.Info("fault injected", "api", api, "fault", fault)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// parseDay parses the date part of the start_date query parameter of both REE APIs.
+func parseDay(startDate string) (time.Time, bool) {
+	if len(startDate) < 10 {
+		return time.Time{}, false
+	}
+	day, err := time.Parse("2006-01-02", startDate[:10])
+	return day, err == nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Error("error encoding fake REE response", "err", err)
+	}
+}
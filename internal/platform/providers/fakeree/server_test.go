@@ -0,0 +1,191 @@
+package fakeree
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/pkg/logger"
+)
+
+const docsDir = "../../../../docs"
+
+type esiosTestResponse struct {
+	Indicator struct {
+		ID     int `json:"id"`
+		Values []struct {
+			Value    float64 `json:"value"`
+			Datetime string  `json:"datetime"`
+			GeoID    uint16  `json:"geo_id"`
+		} `json:"values"`
+	} `json:"indicator"`
+}
+
+type redataTestResponse struct {
+	Included []struct {
+		Attributes struct {
+			Values []struct {
+				Value    float64 `json:"value"`
+				Datetime string  `json:"datetime"`
+			} `json:"values"`
+		} `json:"attributes"`
+	} `json:"included"`
+}
+
+func newTestServer(t *testing.T, token string) (*Server, *httptest.Server) {
+	t.Helper()
+	logger.SetTestLogger(os.Stderr)
+	fake, err := NewServer(docsDir, token)
+	require.NoError(t, err)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func get(t *testing.T, url string, body interface{}) *http.Response {
+	t.Helper()
+	res, err := http.Get(url)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	if body != nil && res.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(body))
+	}
+	return res
+}
+
+func Test_Server_Esios(t *testing.T) {
+	t.Run("serves the fixture rebased to the requested date and geo IDs", func(t *testing.T) {
+		_, server := newTestServer(t, "")
+
+		var body esiosTestResponse
+		res := get(t, server.URL+EsiosPVPCPricesPath+"?start_date=2023-09-08T00:00:00&end_date=2023-09-08T23:59:59&geo_ids[]=8741&geo_ids[]=8745", &body)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, 1001, body.Indicator.ID)
+		require.Len(t, body.Indicator.Values, 48)
+		require.Equal(t, "2023-09-08T00:00:00.000+02:00", body.Indicator.Values[0].Datetime)
+		require.Equal(t, 151.96, body.Indicator.Values[0].Value)
+		require.Equal(t, uint16(8745), body.Indicator.Values[47].GeoID)
+		require.Equal(t, "2023-09-08T23:00:00.000+02:00", body.Indicator.Values[47].Datetime)
+	})
+
+	t.Run("serves 25 hours on the autumn DST change day", func(t *testing.T) {
+		_, server := newTestServer(t, "")
+
+		var body esiosTestResponse
+		res := get(t, server.URL+EsiosPVPCPricesPath+"?start_date=2023-10-29T00:00:00&geo_ids[]=8741", &body)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, body.Indicator.Values, 25)
+		require.Equal(t, "2023-10-29T02:00:00.000+02:00", body.Indicator.Values[2].Datetime)
+		require.Equal(t, "2023-10-29T02:00:00.000+01:00", body.Indicator.Values[3].Datetime)
+	})
+
+	t.Run("rejects requests without the configured token", func(t *testing.T) {
+		_, server := newTestServer(t, "token")
+
+		res := get(t, server.URL+EsiosPVPCPricesPath+"?start_date=2023-09-08T00:00:00", nil)
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("leaves out missing geo IDs and empties values", func(t *testing.T) {
+		fake, server := newTestServer(t, "")
+		url := server.URL + EsiosPVPCPricesPath + "?start_date=2023-09-08T00:00:00&geo_ids[]=8741&geo_ids[]=8742"
+
+		fake.InjectFault(Esios, Fault{MissingGeoIDs: []string{"8742"}})
+		var body esiosTestResponse
+		get(t, url, &body)
+		require.Len(t, body.Indicator.Values, 24)
+		require.Equal(t, uint16(8741), body.Indicator.Values[23].GeoID)
+
+		fake.InjectFault(Esios, Fault{EmptyValues: true})
+		body = esiosTestResponse{}
+		get(t, url, &body)
+		require.Len(t, body.Indicator.Values, 0)
+	})
+}
+
+func Test_Server_REData(t *testing.T) {
+	t.Run("serves the fixture rebased to the requested date", func(t *testing.T) {
+		_, server := newTestServer(t, "")
+
+		var body redataTestResponse
+		res := get(t, server.URL+REDataPVPCPricesPath+"?start_date=2023-08-10T00:00&end_date=2023-08-10T23:59&time_trunc=hour&geo_ids=8742", &body)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, body.Included, 1)
+		require.Len(t, body.Included[0].Attributes.Values, 24)
+		require.Equal(t, "2023-08-10T00:00:00.000+02:00", body.Included[0].Attributes.Values[0].Datetime)
+		require.Equal(t, 150.95, body.Included[0].Attributes.Values[0].Value)
+	})
+
+	t.Run("responds without included data for missing geo IDs", func(t *testing.T) {
+		fake, server := newTestServer(t, "")
+		fake.InjectFault(REData, Fault{MissingGeoIDs: []string{"8742"}})
+
+		var body redataTestResponse
+		res := get(t, server.URL+REDataPVPCPricesPath+"?start_date=2023-08-10T00:00&geo_ids=8742", &body)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Len(t, body.Included, 0)
+	})
+}
+
+func Test_Server_Faults(t *testing.T) {
+	t.Run("status code faults only affect the given number of requests", func(t *testing.T) {
+		fake, server := newTestServer(t, "")
+		fake.InjectFault(REData, Fault{StatusCode: http.StatusBadGateway, RetryAfter: "1", Times: 2})
+		url := server.URL + REDataPVPCPricesPath + "?start_date=2023-08-10T00:00&geo_ids=8741"
+
+		res := get(t, url, nil)
+		require.Equal(t, http.StatusBadGateway, res.StatusCode)
+		require.Equal(t, "1", res.Header.Get("Retry-After"))
+		require.Equal(t, http.StatusBadGateway, get(t, url, nil).StatusCode)
+		require.Equal(t, http.StatusOK, get(t, url, nil).StatusCode)
+		require.Equal(t, 3, fake.Requests(REData))
+		require.Equal(t, 0, fake.Requests(Esios))
+	})
+
+	t.Run("delays make clients time out", func(t *testing.T) {
+		fake, server := newTestServer(t, "")
+		fake.InjectFault(Esios, Fault{Delay: time.Second})
+
+		client := http.Client{Timeout: 10 * time.Millisecond}
+		_, err := client.Get(server.URL + EsiosPVPCPricesPath + "?start_date=2023-08-10T00:00:00")
+		require.Error(t, err)
+	})
+
+	t.Run("faults can be injected and cleared through the faults endpoint", func(t *testing.T) {
+		_, server := newTestServer(t, "")
+		url := server.URL + EsiosPVPCPricesPath + "?start_date=2023-08-10T00:00:00"
+
+		req, err := http.NewRequest(http.MethodPut, server.URL+FaultsPath+"/esios", strings.NewReader(`{"status_code": 500, "delay": "1ms"}`))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+		require.Equal(t, http.StatusInternalServerError, get(t, url, nil).StatusCode)
+
+		req, err = http.NewRequest(http.MethodDelete, server.URL+FaultsPath, nil)
+		require.NoError(t, err)
+		res, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+		require.Equal(t, http.StatusOK, get(t, url, nil).StatusCode)
+	})
+
+	t.Run("unknown APIs are rejected by the faults endpoint", func(t *testing.T) {
+		_, server := newTestServer(t, "")
+
+		req, err := http.NewRequest(http.MethodPut, server.URL+FaultsPath+"/unknown", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/dghubble/sling"
 
 	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/providers/resilient"
 	"pvpc-backend/pkg/logger"
 )
 
@@ -15,17 +16,28 @@ type REDataAPI struct {
 	client *sling.Sling
 }
 
+var now = time.Now
+
 const (
+	// providerName identifies this provider in the provenance of the fetched prices.
+	providerName = "redata"
 	// pvpcPricesEndpoint is the endpoint to fetch PVPC prices from REE.
 	pvpcPricesEndpoint = "/es/datos/mercados/precios-mercados-tiempo-real"
 )
 
-func NewREDataAPI(baseUrl string) *REDataAPI {
+// NewREDataAPI returns a REData API adapter that does its requests through doer.
+// If doer is nil, http.DefaultClient is used.
+func NewREDataAPI(baseUrl string, doer sling.Doer) *REDataAPI {
 	return &REDataAPI{
-		client: sling.New().Base(baseUrl),
+		client: sling.New().Doer(doer).Base(baseUrl),
 	}
 }
 
+// Name implements the domain.NamedPricesProvider interface.
+func (r *REDataAPI) Name() string {
+	return providerName
+}
+
 func (r *REDataAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, date time.Time) ([]domain.Prices, error) {
 	if len(zones) == 0 {
 		return nil, nil
@@ -42,9 +54,10 @@ func (r *REDataAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, da
 		query := fetchPVPCPricesRequest{StartDate: startDate, EndDate: endDate, TimeTrunc: "hour", GeoIds: zone.ExternalID()}
 
 		logger.DebugContext(ctx, "fetching PVPC prices from REData API", "zone", zone.Name(), "query", query)
-		_, err := r.client.Path(pvpcPricesEndpoint).QueryStruct(query).Add("Accept", "application/json").ReceiveSuccess(resBody)
+		req := r.client.New().Path(pvpcPricesEndpoint).QueryStruct(query).Add("Accept", "application/json")
+		err := resilient.Receive(ctx, req, resBody)
 
-		if err != nil || len(resBody.Included) == 0 {
+		if err != nil || len(resBody.Included) == 0 || len(resBody.Included[0].Attributes.Values) == 0 {
 			logger.ErrorContext(ctx, "error fetching PVPC prices from REData API", "err", err, "zone", zone.Name())
 			continue
 		}
@@ -58,6 +71,11 @@ func (r *REDataAPI) FetchPVPCPrices(ctx context.Context, zones []domain.Zone, da
 				Name:       zone.Name(),
 			},
 			Values: make([]domain.HourlyPriceDto, 0, 24),
+			Provenance: &domain.ProvenanceDto{
+				Provider:  providerName,
+				FetchedAt: now().Format(time.RFC3339),
+				Source:    resilient.RequestURI(req),
+			},
 		}
 
 		for _, v := range resBody.Included[0].Attributes.Values {
@@ -2,6 +2,7 @@ package redataapi
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/providers/fakeree"
 
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/stretchr/testify/require"
@@ -28,6 +30,8 @@ func Test_FetchPVPCPrices_Success(t *testing.T) {
 		w.Write(res)
 	}))
 	defer server.Close()
+	now = func() time.Time { return time.Date(2023, 9, 8, 18, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
 
 	zone, err := domain.NewZone(domain.ZoneDto{ID: "ZON", ExternalID: "1234", Name: "Zone Name"})
 	require.NoError(t, err)
@@ -35,7 +39,7 @@ func Test_FetchPVPCPrices_Success(t *testing.T) {
 	date, err := time.Parse("2006-01-02T15:04:05Z", "2023-09-08T17:54:36Z")
 	require.NoError(t, err)
 
-	adapter := NewREDataAPI(server.URL)
+	adapter := NewREDataAPI(server.URL, nil)
 	prices, err := adapter.FetchPVPCPrices(context.Background(), []domain.Zone{zone}, date)
 	require.NoError(t, err)
 	require.Len(t, prices, 1)
@@ -60,8 +64,56 @@ func Test_FetchPVPCPrices_Error(t *testing.T) {
 	date, err := time.Parse("2006-01-02T15:04:05Z", "2023-09-08T17:54:36Z")
 	require.NoError(t, err)
 
-	adapter := NewREDataAPI(server.URL)
+	adapter := NewREDataAPI(server.URL, nil)
 	prices, err := adapter.FetchPVPCPrices(context.Background(), []domain.Zone{zone}, date)
 	require.NoError(t, err)
 	require.Len(t, prices, 0)
 }
+
+func Test_FetchPVPCPrices_FakeREE(t *testing.T) {
+	fake, err := fakeree.NewServer("../../../../docs", "")
+	require.NoError(t, err)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	zones := make([]domain.Zone, 0, 5)
+	for i, id := range []string{"PEN", "CAN", "BAL", "CEU", "MEL"} {
+		zone, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: fmt.Sprintf("%d", 8741+i), Name: id})
+		require.NoError(t, err)
+		zones = append(zones, zone)
+	}
+	date := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+	adapter := NewREDataAPI(server.URL, nil)
+
+	t.Run("fetches every zone", func(t *testing.T) {
+		fake.ClearFaults()
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 5)
+		for _, p := range prices {
+			require.Len(t, p.Values(), 24)
+			require.Equal(t, "2023-09-08", p.Date().Format("2006-01-02"))
+		}
+	})
+
+	t.Run("skips the zones whose request errors", func(t *testing.T) {
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusInternalServerError, Times: 2})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 3)
+	})
+
+	t.Run("skips the zones without values", func(t *testing.T) {
+		fake.InjectFault(fakeree.REData, fakeree.Fault{EmptyValues: true})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 0)
+	})
+
+	t.Run("skips the zones missing in the response", func(t *testing.T) {
+		fake.InjectFault(fakeree.REData, fakeree.Fault{MissingGeoIDs: []string{"8741"}})
+		prices, err := adapter.FetchPVPCPrices(context.Background(), zones, date)
+		require.NoError(t, err)
+		require.Len(t, prices, 4)
+	})
+}
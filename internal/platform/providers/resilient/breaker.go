@@ -0,0 +1,127 @@
+package resilient
+
+import (
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState string
+
+const (
+	// BreakerClosed lets every request through.
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects every request until the cooldown elapses.
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single trial request through to decide whether to close again.
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// CircuitBreaker stops calling a provider after a number of consecutive failures,
+// so a failing upstream is not hammered and callers fail fast to their fallback.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	name      string
+	threshold uint
+	cooldown  time.Duration
+	state     BreakerState
+	failures  uint
+	openedAt  time.Time
+	trial     bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker that opens after threshold consecutive
+// failures and allows a trial request after cooldown. A zero threshold disables it.
+func NewCircuitBreaker(name string, threshold uint, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// Name returns the name of the provider protected by the breaker.
+func (b *CircuitBreaker) Name() string {
+	return b.name
+}
+
+// State returns the current breaker state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.state
+}
+
+// ConsecutiveFailures returns the number of failures since the last success.
+func (b *CircuitBreaker) ConsecutiveFailures() uint {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures
+}
+
+// Allow returns an error if a request must not be done because the breaker is open,
+// or because it is half-open and the trial request is already in flight.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+
+	switch b.state {
+	case BreakerOpen:
+		return errors.NewDomainError(errors.ProviderError, "circuit breaker of %s provider is open", b.name)
+	case BreakerHalfOpen:
+		if b.trial {
+			return errors.NewDomainError(errors.ProviderError, "circuit breaker of %s provider is half-open and a trial request is in flight", b.name)
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Success records a successful request, closing the breaker.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.trial = false
+	b.state = BreakerClosed
+}
+
+// Failure records a failed request, opening the breaker if the threshold is reached
+// or if it was the trial request of a half-open breaker.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.threshold == 0 {
+		return
+	}
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+		b.trial = false
+	}
+}
+
+// Release records a request given up by its caller, without counting it as a success
+// nor a failure. A half-open breaker lets another trial request through.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+// refresh moves an open breaker to half-open once the cooldown has elapsed.
+// It must be called holding the lock.
+func (b *CircuitBreaker) refresh() {
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.state = BreakerHalfOpen
+		b.trial = false
+	}
+}
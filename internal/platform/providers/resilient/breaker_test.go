@@ -0,0 +1,74 @@
+package resilient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain/errors"
+)
+
+func Test_CircuitBreaker(t *testing.T) {
+	t.Run("opens after the threshold of consecutive failures", func(t *testing.T) {
+		breaker := NewCircuitBreaker("test", 2, time.Minute)
+
+		require.NoError(t, breaker.Allow())
+		breaker.Failure()
+		require.Equal(t, BreakerClosed, breaker.State())
+		breaker.Success()
+		breaker.Failure()
+		require.Equal(t, BreakerClosed, breaker.State())
+		breaker.Failure()
+		require.Equal(t, BreakerOpen, breaker.State())
+		require.Equal(t, uint(2), breaker.ConsecutiveFailures())
+
+		err := breaker.Allow()
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderError, errors.Code(err))
+	})
+
+	t.Run("allows a single trial request after the cooldown", func(t *testing.T) {
+		now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		breaker := NewCircuitBreaker("test", 1, time.Minute)
+		breaker.now = func() time.Time { return now }
+
+		breaker.Failure()
+		require.Equal(t, BreakerOpen, breaker.State())
+
+		now = now.Add(time.Minute)
+		require.Equal(t, BreakerHalfOpen, breaker.State())
+		require.NoError(t, breaker.Allow())
+		require.Error(t, breaker.Allow())
+
+		breaker.Success()
+		require.Equal(t, BreakerClosed, breaker.State())
+		require.NoError(t, breaker.Allow())
+	})
+
+	t.Run("opens again when the trial request fails", func(t *testing.T) {
+		now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		breaker := NewCircuitBreaker("test", 3, time.Minute)
+		breaker.now = func() time.Time { return now }
+
+		breaker.Failure()
+		breaker.Failure()
+		breaker.Failure()
+		now = now.Add(time.Minute)
+		require.NoError(t, breaker.Allow())
+
+		breaker.Failure()
+		require.Equal(t, BreakerOpen, breaker.State())
+		require.Error(t, breaker.Allow())
+	})
+
+	t.Run("never opens with a zero threshold", func(t *testing.T) {
+		breaker := NewCircuitBreaker("test", 0, time.Minute)
+
+		for i := 0; i < 10; i++ {
+			breaker.Failure()
+		}
+		require.Equal(t, BreakerClosed, breaker.State())
+		require.NoError(t, breaker.Allow())
+	})
+}
@@ -0,0 +1,204 @@
+// Package resilient provides the HTTP client shared by the REE providers, which adds
+// per-request timeouts, retries with jittered exponential backoff and a circuit breaker.
+package resilient
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pvpc-backend/pkg/logger"
+)
+
+// Config configures a resilient Client.
+type Config struct {
+	// Timeout is the maximum duration of each request attempt. Zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries is the number of retries after a failed attempt.
+	MaxRetries uint
+	// BaseBackoff is the backoff before the first retry. It doubles on every retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the backoff between retries, including the one asked by Retry-After.
+	MaxBackoff time.Duration
+	// BreakerThreshold is the number of consecutive failed requests that opens the circuit breaker.
+	// Zero disables the breaker.
+	BreakerThreshold uint
+	// BreakerCooldown is how long the circuit breaker stays open before allowing a trial request.
+	BreakerCooldown time.Duration
+}
+
+// Client is an HTTP client that implements sling.Doer.
+//
+// Network errors, 429 and 5xx responses are retried. A request fails, from the
+// circuit breaker point of view, when it still fails after all its retries. Requests
+// given up because the caller's context is done are not counted as failures.
+type Client struct {
+	name       string
+	config     Config
+	httpClient *http.Client
+	breaker    *CircuitBreaker
+}
+
+// NewClient returns a resilient Client for the provider with the given name.
+func NewClient(name string, config Config) *Client {
+	return &Client{
+		name:       name,
+		config:     config,
+		httpClient: &http.Client{},
+		breaker:    NewCircuitBreaker(name, config.BreakerThreshold, config.BreakerCooldown),
+	}
+}
+
+// Breaker returns the circuit breaker of the client.
+func (c *Client) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// Do implements the sling.Doer interface.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if err := c.breaker.Allow(); err != nil {
+		logger.WarnContext(ctx, "request rejected by circuit breaker", "provider", c.name, "url", req.URL.Path)
+		return nil, err
+	}
+
+	var res *http.Response
+	var err error
+	for attempt := uint(0); ; attempt++ {
+		res, err = c.attempt(req)
+		if !retryable(res, err) || attempt >= c.config.MaxRetries || ctx.Err() != nil {
+			break
+		}
+
+		delay := c.backoff(attempt, res)
+		logger.WarnContext(ctx, "retrying provider request", "provider", c.name, "url", req.URL.Path, "attempt", attempt+1, "delay", delay, "status", statusCode(res), "err", err)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			c.breaker.Release()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if ctx.Err() != nil {
+		if res != nil {
+			res.Body.Close()
+		}
+		c.breaker.Release()
+		return nil, ctx.Err()
+	}
+	if retryable(res, err) {
+		c.breaker.Failure()
+	} else {
+		c.breaker.Success()
+	}
+	return res, err
+}
+
+// attempt does a single request with the configured timeout.
+// The timeout is released when the response body is closed.
+func (c *Client) attempt(req *http.Request) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if c.config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		attemptReq.Body = body
+	}
+
+	res, err := c.httpClient.Do(attemptReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// backoff returns the delay before the retry following the given attempt: the Retry-After
+// of the response if any, or an exponential backoff with jitter, capped by MaxBackoff.
+func (c *Client) backoff(attempt uint, res *http.Response) time.Duration {
+	if delay, ok := retryAfter(res); ok {
+		if c.config.MaxBackoff > 0 && delay > c.config.MaxBackoff {
+			return c.config.MaxBackoff
+		}
+		return delay
+	}
+
+	delay := c.config.BaseBackoff << attempt
+	if delay <= 0 || (c.config.MaxBackoff > 0 && delay > c.config.MaxBackoff) {
+		delay = c.config.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Equal jitter: half of the delay is kept, the other half is random.
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryable reports whether the request failed in a way worth retrying.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfter parses the Retry-After header, either in seconds or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+	value := res.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+func statusCode(res *http.Response) int {
+	if res == nil {
+		return 0
+	}
+	return res.StatusCode
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
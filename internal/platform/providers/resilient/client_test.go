@@ -0,0 +1,165 @@
+package resilient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/providers/fakeree"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestClient(t *testing.T, config Config) (*Client, *fakeree.Server, func() (*http.Response, error)) {
+	t.Helper()
+	logger.SetTestLogger(os.Stderr)
+	fake, err := fakeree.NewServer("../../../../docs", "")
+	require.NoError(t, err)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := NewClient("test", config)
+	do := func() (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+fakeree.REDataPVPCPricesPath+"?start_date=2023-08-10T00:00&geo_ids=8741", nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		if res != nil {
+			res.Body.Close()
+		}
+		return res, err
+	}
+	return client, fake, do
+}
+
+func Test_Client_Do(t *testing.T) {
+	t.Run("retries 5xx responses until success", func(t *testing.T) {
+		client, fake, do := newTestClient(t, Config{MaxRetries: 3, BaseBackoff: time.Millisecond})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusBadGateway, Times: 2})
+
+		res, err := do()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, 3, fake.Requests(fakeree.REData))
+		require.Equal(t, BreakerClosed, client.Breaker().State())
+	})
+
+	t.Run("returns the last response when retries are exhausted", func(t *testing.T) {
+		client, fake, do := newTestClient(t, Config{MaxRetries: 2, BaseBackoff: time.Millisecond, BreakerThreshold: 5})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusServiceUnavailable})
+
+		res, err := do()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		require.Equal(t, 3, fake.Requests(fakeree.REData))
+		require.Equal(t, uint(1), client.Breaker().ConsecutiveFailures())
+	})
+
+	t.Run("does not retry other 4xx responses", func(t *testing.T) {
+		_, fake, do := newTestClient(t, Config{MaxRetries: 3, BaseBackoff: time.Millisecond})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusBadRequest})
+
+		res, err := do()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.Equal(t, 1, fake.Requests(fakeree.REData))
+	})
+
+	t.Run("honours Retry-After capped by the max backoff", func(t *testing.T) {
+		_, fake, do := newTestClient(t, Config{MaxRetries: 1, BaseBackoff: time.Millisecond, MaxBackoff: 50 * time.Millisecond})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusTooManyRequests, RetryAfter: "120", Times: 1})
+
+		start := time.Now()
+		res, err := do()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("times out slow attempts and retries them", func(t *testing.T) {
+		_, fake, do := newTestClient(t, Config{Timeout: 50 * time.Millisecond, MaxRetries: 1, BaseBackoff: time.Millisecond})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{Delay: time.Second, Times: 1})
+
+		res, err := do()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, 2, fake.Requests(fakeree.REData))
+	})
+
+	t.Run("fails fast when the circuit breaker is open", func(t *testing.T) {
+		client, fake, do := newTestClient(t, Config{BreakerThreshold: 2, BreakerCooldown: time.Minute})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusInternalServerError})
+
+		_, err := do()
+		require.NoError(t, err)
+		_, err = do()
+		require.NoError(t, err)
+		require.Equal(t, BreakerOpen, client.Breaker().State())
+
+		_, err = do()
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderError, errors.Code(err))
+		require.Equal(t, 2, fake.Requests(fakeree.REData))
+	})
+
+	t.Run("stops retrying when the context is cancelled", func(t *testing.T) {
+		client, fake, _ := newTestClient(t, Config{MaxRetries: 5, BaseBackoff: time.Second})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusInternalServerError})
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+fakeree.REDataPVPCPricesPath+"?start_date=2023-08-10T00:00", nil)
+		require.NoError(t, err)
+
+		_, err = client.Do(req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, fake.Requests(fakeree.REData))
+	})
+
+	t.Run("does not count cancelled requests as breaker failures", func(t *testing.T) {
+		client, fake, _ := newTestClient(t, Config{MaxRetries: 5, BaseBackoff: time.Second, BreakerThreshold: 1, BreakerCooldown: time.Minute})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{Delay: time.Second})
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		for i := 0; i < 2; i++ {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+fakeree.REDataPVPCPricesPath+"?start_date=2023-08-10T00:00", nil)
+			require.NoError(t, err)
+
+			_, err = client.Do(req)
+			cancel()
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		}
+		require.Equal(t, BreakerClosed, client.Breaker().State())
+		require.Equal(t, uint(0), client.Breaker().ConsecutiveFailures())
+	})
+}
+
+func Test_Client_backoff(t *testing.T) {
+	client := NewClient("test", Config{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second})
+
+	for attempt := uint(0); attempt < 6; attempt++ {
+		expected := 100 * time.Millisecond << attempt
+		if expected > time.Second {
+			expected = time.Second
+		}
+		delay := client.backoff(attempt, nil)
+		require.GreaterOrEqual(t, delay, expected/2)
+		require.LessOrEqual(t, delay, expected)
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "0")
+	require.Equal(t, time.Duration(0), client.backoff(3, res))
+
+	res.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.Equal(t, time.Second, client.backoff(0, res))
+}
@@ -0,0 +1,35 @@
+package resilient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dghubble/sling"
+)
+
+// Receive does the request built by req bound to ctx, so it is cancelled with it,
+// and decodes a successful response into successV. Non 2xx responses are errors.
+func Receive(ctx context.Context, req *sling.Sling, successV interface{}) error {
+	httpReq, err := req.Request()
+	if err != nil {
+		return err
+	}
+	res, err := req.Do(httpReq.WithContext(ctx), successV, nil)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	return nil
+}
+
+// RequestURI returns the path and query of the request built by req, to be recorded as
+// the upstream source of the fetched data. It returns an empty string if the request can't be built.
+func RequestURI(req *sling.Sling) string {
+	httpReq, err := req.Request()
+	if err != nil {
+		return ""
+	}
+	return httpReq.URL.RequestURI()
+}
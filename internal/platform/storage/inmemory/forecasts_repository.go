@@ -0,0 +1,108 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// ForecastsRepository is an in-memory domain.ForecastsRepository implementation.
+// It mirrors the semantics of the SQL implementations: forecasts reference an existing zone
+// and both forecasts and accuracies are unique by zone, day and model.
+type ForecastsRepository struct {
+	mu         sync.RWMutex
+	forecasts  map[forecastKey]domain.Forecast
+	accuracies map[forecastKey]domain.ForecastAccuracy
+	zones      *ZonesRepository
+}
+
+type forecastKey struct {
+	zoneID domain.ZoneID
+	date   string
+	model  domain.ForecastModel
+}
+
+// NewForecastsRepository initializes an in-memory implementation of domain.ForecastsRepository.
+// The given zones repository plays the role of the zones table.
+func NewForecastsRepository(zones *ZonesRepository) *ForecastsRepository {
+	return &ForecastsRepository{
+		forecasts:  make(map[forecastKey]domain.Forecast),
+		accuracies: make(map[forecastKey]domain.ForecastAccuracy),
+		zones:      zones,
+	}
+}
+
+// Save implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) Save(ctx context.Context, forecast domain.Forecast) error {
+	key := forecastKey{zoneID: forecast.ZoneID(), date: forecast.Date().Format(time.DateOnly), model: forecast.Model()}
+	logger.DebugContext(ctx, "Saving Forecast into memory", "zone_id", key.zoneID.String(), "date", key.date, "model", key.model.String())
+	if _, ok := r.zones.get(forecast.ZoneID()); !ok {
+		return errors.NewDomainError(errors.PersistenceError, "error trying to persist Forecast into memory: unknown zone %s", forecast.ZoneID().String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.forecasts[key] = forecast
+	return nil
+}
+
+// Query implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) Query(ctx context.Context, zoneID domain.ZoneID, date time.Time) ([]domain.Forecast, error) {
+	logger.DebugContext(ctx, "Querying Forecasts from memory", "zone_id", zoneID.String(), "date", date.Format(time.DateOnly))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	forecasts := make([]domain.Forecast, 0)
+	for key, forecast := range r.forecasts {
+		if key.zoneID == zoneID && key.date == date.Format(time.DateOnly) {
+			forecasts = append(forecasts, forecast)
+		}
+	}
+	sort.Slice(forecasts, func(i, j int) bool { return forecasts[i].Model() < forecasts[j].Model() })
+
+	return forecasts, nil
+}
+
+// SaveAccuracy implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) SaveAccuracy(ctx context.Context, accuracy domain.ForecastAccuracy) error {
+	key := forecastKey{zoneID: accuracy.ZoneID(), date: accuracy.Date().Format(time.DateOnly), model: accuracy.Model()}
+	logger.DebugContext(ctx, "Saving ForecastAccuracy into memory", "zone_id", key.zoneID.String(), "date", key.date, "model", key.model.String())
+	if _, ok := r.zones.get(accuracy.ZoneID()); !ok {
+		return errors.NewDomainError(errors.PersistenceError, "error trying to persist ForecastAccuracy into memory: unknown zone %s", accuracy.ZoneID().String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.accuracies[key] = accuracy
+	return nil
+}
+
+// ListAccuracy implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) ListAccuracy(ctx context.Context, zoneID domain.ZoneID, from, to time.Time) ([]domain.ForecastAccuracy, error) {
+	logger.DebugContext(ctx, "Listing ForecastAccuracy from memory", "zone_id", zoneID.String(), "from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fromKey, toKey := from.Format(time.DateOnly), to.Format(time.DateOnly)
+	accuracies := make([]domain.ForecastAccuracy, 0)
+	for key, accuracy := range r.accuracies {
+		if key.zoneID == zoneID && key.date >= fromKey && key.date <= toKey {
+			accuracies = append(accuracies, accuracy)
+		}
+	}
+	sort.Slice(accuracies, func(i, j int) bool {
+		if !accuracies[i].Date().Equal(accuracies[j].Date()) {
+			return accuracies[i].Date().Before(accuracies[j].Date())
+		}
+		return accuracies[i].Model() < accuracies[j].Model()
+	})
+
+	return accuracies, nil
+}
@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// HolidaysRepository is an in-memory domain.HolidaysRepository implementation.
+// Holidays are unique by day, as in the SQL implementations.
+type HolidaysRepository struct {
+	mu       sync.RWMutex
+	holidays map[string]domain.Holiday
+}
+
+// NewHolidaysRepository initializes an in-memory implementation of domain.HolidaysRepository.
+func NewHolidaysRepository() *HolidaysRepository {
+	return &HolidaysRepository{
+		holidays: make(map[string]domain.Holiday),
+	}
+}
+
+// GetAll implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) GetAll(ctx context.Context) ([]domain.Holiday, error) {
+	logger.DebugContext(ctx, "Getting all Holidays from memory")
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holidays := make([]domain.Holiday, 0, len(r.holidays))
+	for _, holiday := range r.holidays {
+		holidays = append(holidays, holiday)
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date().Before(holidays[j].Date())
+	})
+
+	return holidays, nil
+}
+
+// Save implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) Save(ctx context.Context, holidays []domain.Holiday) error {
+	logger.DebugContext(ctx, "Saving Holidays into memory", "count", len(holidays))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, holiday := range holidays {
+		r.holidays[holiday.Date().Format(time.DateOnly)] = holiday
+	}
+
+	return nil
+}
+
+// Delete implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) Delete(ctx context.Context, date time.Time) error {
+	day := date.Format(time.DateOnly)
+	logger.DebugContext(ctx, "Deleting Holiday from memory", "date", day)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.holidays[day]; !ok {
+		return errors.NewDomainError(errors.HolidayNotFound, "Holiday on %s not found", day)
+	}
+	delete(r.holidays, day)
+
+	return nil
+}
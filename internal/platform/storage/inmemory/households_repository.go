@@ -0,0 +1,106 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// HouseholdsRepository is an in-memory domain.HouseholdsRepository implementation.
+// It mirrors the semantics of the SQL implementations: households reference an existing zone,
+// consumption is unique by household and hour, and datetimes are returned in UTC.
+type HouseholdsRepository struct {
+	mu         sync.RWMutex
+	households map[domain.CUPS]*storedHousehold
+	zones      *ZonesRepository
+}
+
+type storedHousehold struct {
+	household   domain.Household
+	consumption map[int64]float64
+}
+
+// NewHouseholdsRepository initializes an in-memory implementation of domain.HouseholdsRepository.
+// The given zones repository plays the role of the zones table.
+func NewHouseholdsRepository(zones *ZonesRepository) *HouseholdsRepository {
+	return &HouseholdsRepository{
+		households: make(map[domain.CUPS]*storedHousehold),
+		zones:      zones,
+	}
+}
+
+// Save implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) Save(ctx context.Context, household domain.Household, consumption []domain.HourlyConsumption) error {
+	logger.DebugContext(ctx, "Saving Household into memory", "cups", household.CUPS().String(), "hours", len(consumption))
+	if _, ok := r.zones.get(household.ZoneID()); !ok {
+		return errors.NewDomainError(errors.PersistenceError, "error trying to persist Household into memory: unknown zone %s", household.ZoneID().String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.households[household.CUPS()]
+	if !ok {
+		stored = &storedHousehold{consumption: make(map[int64]float64)}
+		r.households[household.CUPS()] = stored
+	}
+	stored.household = household
+	for _, c := range consumption {
+		stored.consumption[c.Datetime().Unix()] = c.Kwh()
+	}
+
+	return nil
+}
+
+// Get implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) Get(ctx context.Context, cups domain.CUPS) (domain.Household, error) {
+	logger.DebugContext(ctx, "Getting Household from memory", "cups", cups.String())
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.households[cups]
+	if !ok {
+		return domain.Household{}, errors.NewDomainError(errors.HouseholdNotFound, "Household with CUPS %s not found", cups.String())
+	}
+
+	return stored.household, nil
+}
+
+// QueryConsumption implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) QueryConsumption(ctx context.Context, cups domain.CUPS, from, to time.Time) ([]domain.HourlyConsumption, error) {
+	logger.DebugContext(ctx, "Querying Household consumption from memory", "cups", cups.String(), "from", from, "to", to)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.households[cups]
+	if !ok {
+		return nil, errors.NewDomainError(errors.HouseholdNotFound, "Household with CUPS %s not found", cups.String())
+	}
+
+	datetimes := make([]int64, 0, len(stored.consumption))
+	for datetime := range stored.consumption {
+		if datetime >= from.Unix() && datetime < to.Unix() {
+			datetimes = append(datetimes, datetime)
+		}
+	}
+	sort.Slice(datetimes, func(i, j int) bool { return datetimes[i] < datetimes[j] })
+
+	consumption := make([]domain.HourlyConsumption, len(datetimes))
+	for i, datetime := range datetimes {
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{
+			Datetime: time.Unix(datetime, 0).UTC().Format(time.RFC3339),
+			Kwh:      stored.consumption[datetime],
+		})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Household consumption from memory to domain")
+		}
+		consumption[i] = c
+	}
+
+	return consumption, nil
+}
@@ -0,0 +1,103 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// IndicatorsRepository is an in-memory domain.IndicatorsRepository implementation.
+// It mirrors the semantics of the SQL implementations: values are unique by
+// datetime and geo, and datetimes are returned in UTC.
+type IndicatorsRepository struct {
+	mu         sync.RWMutex
+	indicators map[domain.IndicatorID]*storedIndicator
+}
+
+type storedIndicator struct {
+	name   string
+	values map[indicatorValueKey]domain.IndicatorValueDto
+}
+
+type indicatorValueKey struct {
+	datetime int64
+	geoID    string
+}
+
+// NewIndicatorsRepository initializes an in-memory implementation of domain.IndicatorsRepository.
+func NewIndicatorsRepository() *IndicatorsRepository {
+	return &IndicatorsRepository{
+		indicators: make(map[domain.IndicatorID]*storedIndicator),
+	}
+}
+
+// Save implements the domain.IndicatorsRepository interface.
+func (r *IndicatorsRepository) Save(ctx context.Context, indicator domain.Indicator) error {
+	logger.DebugContext(ctx, "Saving Indicator into memory", "id", indicator.ID().String())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.indicators[indicator.ID()]
+	if !ok {
+		stored = &storedIndicator{values: make(map[indicatorValueKey]domain.IndicatorValueDto)}
+		r.indicators[indicator.ID()] = stored
+	}
+	stored.name = indicator.Name()
+
+	for _, v := range indicator.Values() {
+		stored.values[indicatorValueKey{datetime: v.Datetime().Unix(), geoID: v.GeoID()}] = domain.IndicatorValueDto{
+			Datetime: v.Datetime().UTC().Format(time.RFC3339),
+			GeoID:    v.GeoID(),
+			GeoName:  v.GeoName(),
+			Value:    v.Value(),
+		}
+	}
+
+	return nil
+}
+
+// Query implements the domain.IndicatorsRepository interface.
+func (r *IndicatorsRepository) Query(ctx context.Context, id domain.IndicatorID, geoID *string, from, to time.Time) (domain.Indicator, error) {
+	logger.DebugContext(ctx, "Querying Indicator from memory", "id", id.String(), "geoID", fmt.Sprintf("%v", geoID), "from", from, "to", to)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.indicators[id]
+	if !ok {
+		return domain.Indicator{}, errors.NewDomainError(errors.IndicatorNotFound, "Indicator with ID %s not found", id.String())
+	}
+
+	keys := make([]indicatorValueKey, 0, len(stored.values))
+	for key := range stored.values {
+		if key.datetime < from.Unix() || key.datetime >= to.Unix() {
+			continue
+		}
+		if geoID != nil && key.geoID != *geoID {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].datetime != keys[j].datetime {
+			return keys[i].datetime < keys[j].datetime
+		}
+		return keys[i].geoID < keys[j].geoID
+	})
+
+	values := make([]domain.IndicatorValueDto, len(keys))
+	for i, key := range keys {
+		values[i] = stored.values[key]
+	}
+
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{ID: id.String(), Name: stored.name, Values: values})
+	if err != nil {
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Indicator from memory to domain")
+	}
+	return indicator, nil
+}
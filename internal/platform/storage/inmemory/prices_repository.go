@@ -0,0 +1,312 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// PricesRepository is an in-memory domain.PricesRepository implementation.
+// It mirrors the semantics of the SQL implementations: prices reference an
+// existing zone, IDs are unique by type and dates are compared by day.
+type PricesRepository struct {
+	mu        sync.RWMutex
+	prices    map[pricesKey]domain.Prices
+	revisions map[domain.PricesID][]domain.PricesRevision
+	summaries map[domain.PricesID]domain.PricesDaySummary
+	zones     *ZonesRepository
+}
+
+// pricesKey identifies stored prices, as prices of different types share their IDs.
+type pricesKey struct {
+	pricesType domain.PricesType
+	id         domain.PricesID
+}
+
+func keyOf(prices domain.Prices) pricesKey {
+	return pricesKey{pricesType: prices.Type(), id: prices.ID()}
+}
+
+// NewPricesRepository initializes an in-memory implementation of domain.PricesRepository.
+// The given zones repository plays the role of the zones table.
+func NewPricesRepository(zones *ZonesRepository) *PricesRepository {
+	return &PricesRepository{
+		prices:    make(map[pricesKey]domain.Prices),
+		revisions: make(map[domain.PricesID][]domain.PricesRevision),
+		summaries: make(map[domain.PricesID]domain.PricesDaySummary),
+		zones:     zones,
+	}
+}
+
+// Save implements the domain.PricesRepository interface.
+// Either all the prices are stored or none of them.
+func (r *PricesRepository) Save(ctx context.Context, prices []domain.Prices) error {
+	logger.DebugContext(ctx, "Saving Prices into memory")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	batch := make(map[pricesKey]struct{}, len(prices))
+	for _, p := range prices {
+		if _, ok := r.zones.get(p.Zone().ID()); !ok {
+			return errors.NewDomainError(errors.PersistenceError, "error trying to persist Prices into memory: unknown zone %s", p.Zone().ID().String())
+		}
+		_, stored := r.prices[keyOf(p)]
+		_, duplicated := batch[keyOf(p)]
+		if stored || duplicated {
+			return errors.NewDomainError(errors.PersistenceError, "error trying to persist Prices into memory: duplicated ID %s", p.ID().String())
+		}
+		batch[keyOf(p)] = struct{}{}
+	}
+
+	for _, p := range prices {
+		r.prices[keyOf(p)] = p
+		if p.Type() == domain.PVPCPrices {
+			r.summaries[p.ID()] = domain.NewPricesDaySummary(p)
+		}
+	}
+
+	return nil
+}
+
+// Upsert implements the domain.PricesRepository interface.
+// Either all the prices are stored or none of them.
+func (r *PricesRepository) Upsert(ctx context.Context, prices []domain.Prices) error {
+	logger.DebugContext(ctx, "Upserting Prices into memory")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range prices {
+		if p.Type() != domain.PVPCPrices {
+			return errors.NewDomainError(errors.InvalidPricesType, "only %s prices can be upserted, got %s prices: %s", domain.PVPCPrices, p.Type(), p.ID().String())
+		}
+	}
+
+	batch := make(map[domain.PricesID]struct{}, len(prices))
+	for _, p := range prices {
+		if _, ok := r.zones.get(p.Zone().ID()); !ok {
+			return errors.NewDomainError(errors.PersistenceError, "error trying to persist Prices into memory: unknown zone %s", p.Zone().ID().String())
+		}
+		if _, duplicated := batch[p.ID()]; duplicated {
+			return errors.NewDomainError(errors.PersistenceError, "error trying to persist Prices into memory: duplicated ID %s", p.ID().String())
+		}
+		batch[p.ID()] = struct{}{}
+	}
+
+	revisedAt := time.Now()
+	revisions := make(map[domain.PricesID]domain.PricesRevision)
+	for _, p := range prices {
+		if stored, ok := r.prices[keyOf(p)]; ok {
+			zone, _ := r.zones.get(stored.Zone().ID())
+			revision, err := asRevision(stored, zone, revisedAt)
+			if err != nil {
+				return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices revisions into memory")
+			}
+			revisions[p.ID()] = revision
+		}
+	}
+
+	for _, p := range prices {
+		if revision, ok := revisions[p.ID()]; ok {
+			r.revisions[p.ID()] = append(r.revisions[p.ID()], revision)
+		}
+		r.prices[keyOf(p)] = p
+		r.summaries[p.ID()] = domain.NewPricesDaySummary(p)
+	}
+
+	return nil
+}
+
+// Aggregate implements the domain.PricesRepository interface.
+// As the SQL implementations, it aggregates the daily summaries refreshed on every Save and Upsert.
+func (r *PricesRepository) Aggregate(ctx context.Context, zoneID domain.ZoneID, granularity domain.AggregateGranularity, from, to time.Time) ([]domain.PricesAggregate, error) {
+	logger.DebugContext(ctx, "Aggregating prices from memory", "zone_id", zoneID.String(), "granularity", granularity.String(),
+		"from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fromKey, toKey := from.Format(time.DateOnly), to.Format(time.DateOnly)
+	periods := make(map[string][]domain.PricesDaySummary)
+	for _, summary := range r.summaries {
+		day := summary.Date().Format(time.DateOnly)
+		if summary.ZoneID() != zoneID || day < fromKey || day > toKey || summary.Hours() == 0 {
+			continue
+		}
+		periodStart := granularity.PeriodStart(summary.Date()).Format(time.DateOnly)
+		periods[periodStart] = append(periods[periodStart], summary)
+	}
+
+	periodStarts := make([]string, 0, len(periods))
+	for periodStart := range periods {
+		periodStarts = append(periodStarts, periodStart)
+	}
+	sort.Strings(periodStarts)
+
+	aggregates := make([]domain.PricesAggregate, len(periodStarts))
+	for i, periodStart := range periodStarts {
+		aggregate, err := domain.NewPricesAggregate(aggregateSummaries(zoneID, granularity, periodStart, periods[periodStart]))
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices aggregate from memory to domain")
+		}
+		aggregates[i] = aggregate
+	}
+
+	return aggregates, nil
+}
+
+// aggregateSummaries returns the aggregate of the daily summaries of a period.
+func aggregateSummaries(zoneID domain.ZoneID, granularity domain.AggregateGranularity, periodStart string, summaries []domain.PricesDaySummary) domain.PricesAggregateDto {
+	dto := domain.PricesAggregateDto{ZoneID: zoneID.String(), Granularity: granularity.String(), PeriodStart: periodStart, Days: len(summaries)}
+	var sum, dailyMeans, peakSum float64
+	var peakHours int
+	for i, summary := range summaries {
+		if i == 0 || summary.Min() < dto.Min {
+			dto.Min = summary.Min()
+		}
+		if i == 0 || summary.Max() > dto.Max {
+			dto.Max = summary.Max()
+		}
+		dto.Hours += summary.Hours()
+		sum += summary.Sum()
+		dailyMeans += summary.Sum() / float64(summary.Hours())
+		peakHours += summary.PeakHours()
+		peakSum += summary.PeakSum()
+	}
+	dto.Mean = dailyMeans / float64(len(summaries))
+	dto.WeightedMean = sum / float64(dto.Hours)
+	if peakHours > 0 {
+		peakMean := peakSum / float64(peakHours)
+		dto.PeakMean = &peakMean
+	}
+	if offpeakHours := dto.Hours - peakHours; offpeakHours > 0 {
+		offpeakMean := (sum - peakSum) / float64(offpeakHours)
+		dto.OffpeakMean = &offpeakMean
+	}
+	return dto
+}
+
+// ListRevisions implements the domain.PricesRepository interface.
+func (r *PricesRepository) ListRevisions(ctx context.Context, id domain.PricesID) ([]domain.PricesRevision, error) {
+	logger.DebugContext(ctx, "Listing prices revisions from memory", "id", id.String())
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := make([]domain.PricesRevision, len(r.revisions[id]))
+	copy(revisions, r.revisions[id])
+	return revisions, nil
+}
+
+// Query implements the domain.PricesRepository interface.
+func (r *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	return r.QueryByType(ctx, domain.PVPCPrices, zoneID, date)
+}
+
+// QueryByType implements the domain.PricesRepository interface.
+func (r *PricesRepository) QueryByType(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	logger.DebugContext(ctx, "Querying prices from memory", "type", pricesType.String(), "zoneID", fmt.Sprintf("%v", zoneID), "date", date)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	latestByZone := make(map[domain.ZoneID]domain.Prices)
+	prices := make([]domain.Prices, 0, 5)
+
+	for key, p := range r.prices {
+		if key.pricesType != pricesType {
+			continue
+		}
+		if zoneID != nil && p.Zone().ID() != *zoneID {
+			continue
+		}
+		if date != nil {
+			if sameDay(p.Date(), *date) {
+				prices = append(prices, p)
+			}
+			continue
+		}
+		if latest, ok := latestByZone[p.Zone().ID()]; !ok || dayString(p.Date()) > dayString(latest.Date()) {
+			latestByZone[p.Zone().ID()] = p
+		}
+	}
+
+	if date == nil {
+		for _, p := range latestByZone {
+			prices = append(prices, p)
+		}
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].ID().String() < prices[j].ID().String()
+	})
+
+	for i, p := range prices {
+		zone, _ := r.zones.get(p.Zone().ID())
+		stored, err := asStored(p, zone)
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices from memory to domain")
+		}
+		prices[i] = stored
+	}
+
+	return prices, nil
+}
+
+// QueryRange implements the domain.PricesRepository interface.
+func (r *PricesRepository) QueryRange(ctx context.Context, zoneID domain.ZoneID, from, to time.Time) ([]domain.Prices, error) {
+	logger.DebugContext(ctx, "Querying prices range from memory", "zone_id", zoneID.String(), "from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	zone, _ := r.zones.get(zoneID)
+	prices := make([]domain.Prices, 0, 5)
+	for key, p := range r.prices {
+		if key.pricesType != domain.PVPCPrices || p.Zone().ID() != zoneID {
+			continue
+		}
+		if day := dayString(p.Date()); day < dayString(from) || day > dayString(to) {
+			continue
+		}
+		stored, err := asStored(p, zone)
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices from memory to domain")
+		}
+		prices = append(prices, stored)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Date().Before(prices[j].Date())
+	})
+	return prices, nil
+}
+
+// asStored returns the prices as a SQL storage would: joined with the stored zone
+// data and with the date truncated to a UTC day.
+func asStored(prices domain.Prices, zone domain.Zone) (domain.Prices, error) {
+	dto := prices.Serialize()
+	dto.Zone = zone.Serialize()
+	dto.Date = dayString(prices.Date()) + "T00:00:00Z"
+	return domain.NewPrices(dto)
+}
+
+// asRevision returns the stored prices as a revision replaced at revisedAt.
+func asRevision(prices domain.Prices, zone domain.Zone, revisedAt time.Time) (domain.PricesRevision, error) {
+	stored, err := asStored(prices, zone)
+	if err != nil {
+		return domain.PricesRevision{}, err
+	}
+	dto := stored.Serialize()
+	dto.Date = dayString(stored.Date()) + "T00:00:00Z"
+	return domain.NewPricesRevision(domain.PricesRevisionDto{Prices: dto, RevisedAt: revisedAt.Format(time.RFC3339Nano)})
+}
+
+func dayString(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func sameDay(a, b time.Time) bool {
+	return dayString(a) == dayString(b)
+}
@@ -0,0 +1,90 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// QuarantineRepository is an in-memory domain.QuarantineRepository implementation.
+// It mirrors the semantics of the SQL implementations: quarantined prices reference an existing
+// zone and are unique by prices type and ID.
+type QuarantineRepository struct {
+	mu          sync.RWMutex
+	quarantined map[quarantineKey]domain.QuarantinedPrices
+	zones       *ZonesRepository
+}
+
+type quarantineKey struct {
+	pricesType domain.PricesType
+	id         string
+}
+
+// NewQuarantineRepository initializes an in-memory implementation of domain.QuarantineRepository.
+// The given zones repository plays the role of the zones table.
+func NewQuarantineRepository(zones *ZonesRepository) *QuarantineRepository {
+	return &QuarantineRepository{
+		quarantined: make(map[quarantineKey]domain.QuarantinedPrices),
+		zones:       zones,
+	}
+}
+
+// Save implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) Save(ctx context.Context, quarantined domain.QuarantinedPrices) error {
+	prices := quarantined.Prices()
+	logger.DebugContext(ctx, "Saving QuarantinedPrices into memory", "id", prices.ID().String(), "type", prices.Type().String(), "status", quarantined.Status().String())
+	if _, ok := r.zones.get(prices.Zone().ID()); !ok {
+		return errors.NewDomainError(errors.PersistenceError, "error trying to persist QuarantinedPrices into memory: unknown zone %s", prices.Zone().ID().String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.quarantined[quarantineKey{pricesType: prices.Type(), id: prices.ID().String()}] = quarantined
+	return nil
+}
+
+// Get implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) Get(ctx context.Context, pricesType domain.PricesType, id domain.PricesID) (domain.QuarantinedPrices, error) {
+	logger.DebugContext(ctx, "Getting QuarantinedPrices from memory", "id", id.String(), "type", pricesType.String())
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quarantined, ok := r.quarantined[quarantineKey{pricesType: pricesType, id: id.String()}]
+	if !ok {
+		return domain.QuarantinedPrices{}, errors.NewDomainError(errors.QuarantineNotFound, "quarantined %s prices not found: %s", pricesType.String(), id.String())
+	}
+
+	return quarantined, nil
+}
+
+// List implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) List(ctx context.Context, status *domain.QuarantineStatus) ([]domain.QuarantinedPrices, error) {
+	logger.DebugContext(ctx, "Listing QuarantinedPrices from memory", "status", fmt.Sprintf("%v", status))
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quarantined := make([]domain.QuarantinedPrices, 0)
+	for _, q := range r.quarantined {
+		if status == nil || q.Status() == *status {
+			quarantined = append(quarantined, q)
+		}
+	}
+	sort.Slice(quarantined, func(i, j int) bool {
+		a, b := quarantined[i], quarantined[j]
+		if !a.DetectedAt().Equal(b.DetectedAt()) {
+			return a.DetectedAt().After(b.DetectedAt())
+		}
+		if a.Prices().Type() != b.Prices().Type() {
+			return a.Prices().Type() < b.Prices().Type()
+		}
+		return a.Prices().ID().String() < b.Prices().ID().String()
+	})
+
+	return quarantined, nil
+}
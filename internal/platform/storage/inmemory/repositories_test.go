@@ -0,0 +1,73 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/storage/storagetest"
+)
+
+func newTestZonesRepository(t *testing.T) *ZonesRepository {
+	t.Helper()
+	zones := make([]domain.Zone, len(storagetest.SeededZones))
+	for i, dto := range storagetest.SeededZones {
+		zone, err := domain.NewZone(dto)
+		require.NoError(t, err)
+		zones[i] = zone
+	}
+
+	return NewZonesRepository(zones...)
+}
+
+func newTestRepositories(t *testing.T) (domain.PricesRepository, domain.ZonesRepository) {
+	t.Helper()
+	zonesRepository := newTestZonesRepository(t)
+	return NewPricesRepository(zonesRepository), zonesRepository
+}
+
+func Test_PricesRepository_Suite(t *testing.T) {
+	storagetest.RunPricesRepositoryTests(t, newTestRepositories)
+}
+
+func Test_ZonesRepository_Suite(t *testing.T) {
+	storagetest.RunZonesRepositoryTests(t, newTestRepositories)
+}
+
+func Test_IndicatorsRepository_Suite(t *testing.T) {
+	storagetest.RunIndicatorsRepositoryTests(t, func(t *testing.T) domain.IndicatorsRepository {
+		return NewIndicatorsRepository()
+	})
+}
+
+func Test_SpotPricesRepository_Suite(t *testing.T) {
+	storagetest.RunSpotPricesRepositoryTests(t, func(t *testing.T) (domain.SpotPricesRepository, domain.PricesRepository) {
+		zonesRepository := newTestZonesRepository(t)
+		return NewSpotPricesRepository(zonesRepository), NewPricesRepository(zonesRepository)
+	})
+}
+
+func Test_HolidaysRepository_Suite(t *testing.T) {
+	storagetest.RunHolidaysRepositoryTests(t, func(t *testing.T) domain.HolidaysRepository {
+		return NewHolidaysRepository()
+	})
+}
+
+func Test_HouseholdsRepository_Suite(t *testing.T) {
+	storagetest.RunHouseholdsRepositoryTests(t, func(t *testing.T) domain.HouseholdsRepository {
+		return NewHouseholdsRepository(newTestZonesRepository(t))
+	})
+}
+
+func Test_ForecastsRepository_Suite(t *testing.T) {
+	storagetest.RunForecastsRepositoryTests(t, func(t *testing.T) domain.ForecastsRepository {
+		return NewForecastsRepository(newTestZonesRepository(t))
+	})
+}
+
+func Test_QuarantineRepository_Suite(t *testing.T) {
+	storagetest.RunQuarantineRepositoryTests(t, func(t *testing.T) domain.QuarantineRepository {
+		return NewQuarantineRepository(newTestZonesRepository(t))
+	})
+}
@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"pvpc-backend/internal/domain"
+)
+
+// SpotPricesRepository is an in-memory domain.SpotPricesRepository implementation.
+// Spot prices are kept apart from the PVPC ones, with the same semantics.
+type SpotPricesRepository struct {
+	prices *PricesRepository
+}
+
+// NewSpotPricesRepository initializes an in-memory implementation of domain.SpotPricesRepository.
+// The given zones repository plays the role of the zones table.
+func NewSpotPricesRepository(zones *ZonesRepository) *SpotPricesRepository {
+	return &SpotPricesRepository{
+		prices: NewPricesRepository(zones),
+	}
+}
+
+// Save implements the domain.SpotPricesRepository interface.
+func (r *SpotPricesRepository) Save(ctx context.Context, prices []domain.Prices) error {
+	return r.prices.Save(ctx, prices)
+}
+
+// Query implements the domain.SpotPricesRepository interface.
+func (r *SpotPricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	return r.prices.QueryByType(ctx, domain.SpotPrices, zoneID, date)
+}
@@ -0,0 +1,83 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// ZonesRepository is an in-memory domain.ZonesRepository implementation.
+type ZonesRepository struct {
+	mu    sync.RWMutex
+	zones map[domain.ZoneID]domain.Zone
+}
+
+// NewZonesRepository initializes an in-memory implementation of domain.ZonesRepository
+// that contains the given zones.
+func NewZonesRepository(zones ...domain.Zone) *ZonesRepository {
+	r := &ZonesRepository{
+		zones: make(map[domain.ZoneID]domain.Zone, len(zones)),
+	}
+	for _, zone := range zones {
+		r.zones[zone.ID()] = zone
+	}
+	return r
+}
+
+// GetAll implements the domain.ZonesRepository interface.
+func (r *ZonesRepository) GetAll(ctx context.Context) ([]domain.Zone, error) {
+	logger.DebugContext(ctx, "Getting all Zones from memory")
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	zones := make([]domain.Zone, 0, len(r.zones))
+	for _, zone := range r.zones {
+		zones = append(zones, zone)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].ID().String() < zones[j].ID().String()
+	})
+
+	return zones, nil
+}
+
+// GetByID implements the domain.ZonesRepository interface.
+func (r *ZonesRepository) GetByID(ctx context.Context, id domain.ZoneID) (domain.Zone, error) {
+	logger.DebugContext(ctx, "Getting Zone from memory by ID", "id", id.String())
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	zone, ok := r.zones[id]
+	if !ok {
+		return domain.Zone{}, errors.NewDomainError(errors.ZoneNotFound, "Zone with ID %s not found", id.String())
+	}
+
+	return zone, nil
+}
+
+// GetByExternalID implements the domain.ZonesRepository interface.
+func (r *ZonesRepository) GetByExternalID(ctx context.Context, externalID string) (domain.Zone, error) {
+	logger.DebugContext(ctx, "Getting Zone from memory by externalID", "externalID", externalID)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, zone := range r.zones {
+		if zone.ExternalID() == externalID {
+			return zone, nil
+		}
+	}
+
+	return domain.Zone{}, errors.NewDomainError(errors.ZoneNotFound, "Zone with externalID %s not found", externalID)
+}
+
+func (r *ZonesRepository) get(id domain.ZoneID) (domain.Zone, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	zone, ok := r.zones[id]
+	return zone, ok
+}
@@ -0,0 +1,83 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	dErrors "pvpc-backend/internal/domain/errors"
+)
+
+func Test_HolidaysRepository_Save(t *testing.T) {
+	holiday, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-10-12", Name: "Fiesta Nacional de España"})
+	require.NoError(t, err)
+
+	query := "INSERT INTO holidays (date, name) VALUES ($1, $2) ON CONFLICT (date) DO UPDATE SET name = excluded.name"
+
+	t.Run("when db returns error, repository returns error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectExec(query).WithArgs(holiday.Date(), "Fiesta Nacional de España").WillReturnError(errors.New("mock-error"))
+
+		repo := NewHolidaysRepository(db, 1*time.Millisecond)
+
+		err = repo.Save(context.Background(), []domain.Holiday{holiday})
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+		require.Equal(t, dErrors.PersistenceError, dErrors.Code(err))
+	})
+
+	t.Run("when everything goes OK, the holidays are stored", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectExec(query).WithArgs(holiday.Date(), "Fiesta Nacional de España").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewHolidaysRepository(db, 1*time.Millisecond)
+
+		err = repo.Save(context.Background(), []domain.Holiday{holiday})
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+	})
+}
+
+func Test_HolidaysRepository_Delete(t *testing.T) {
+	query := "DELETE FROM holidays WHERE date = $1"
+	date := time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)
+
+	t.Run("when no row is deleted, repository returns a not found error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectExec(query).WithArgs("2023-10-12").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := NewHolidaysRepository(db, 1*time.Millisecond)
+
+		err = repo.Delete(context.Background(), date)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Equal(t, dErrors.HolidayNotFound, dErrors.Code(err))
+	})
+
+	t.Run("when the holiday is deleted, repository returns no error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectExec(query).WithArgs("2023-10-12").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewHolidaysRepository(db, 1*time.Millisecond)
+
+		err = repo.Delete(context.Background(), date)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	dErrors "pvpc-backend/internal/domain/errors"
+)
+
+func Test_IndicatorsRepository_Save(t *testing.T) {
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{
+		ID:     "600",
+		Name:   "Spot",
+		Values: []domain.IndicatorValueDto{{Datetime: "2023-09-08T00:00:00+02:00", GeoID: "3", GeoName: "España", Value: 106.45}},
+	})
+	require.NoError(t, err)
+
+	indicatorQuery := "INSERT INTO indicators (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name"
+	valuesQuery := "INSERT INTO indicator_values (indicator_id, geo_id, geo_name, datetime, value) VALUES ($1, $2, $3, $4, $5) " +
+		"ON CONFLICT (indicator_id, datetime, geo_id) DO UPDATE SET geo_name = excluded.geo_name, value = excluded.value"
+
+	t.Run("when db returns error, repository returns error and rolls back", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(indicatorQuery).WithArgs("600", "Spot").WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(valuesQuery).
+			WithArgs("600", "3", "España", "2023-09-07T22:00:00Z", 106.45).
+			WillReturnError(errors.New("mock-error"))
+		sqlMock.ExpectRollback()
+
+		repo := NewIndicatorsRepository(db, 1*time.Millisecond)
+
+		err = repo.Save(context.Background(), indicator)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("when everything goes OK, the indicator and its values are stored", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(indicatorQuery).WithArgs("600", "Spot").WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(valuesQuery).
+			WithArgs("600", "3", "España", "2023-09-07T22:00:00Z", 106.45).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		repo := NewIndicatorsRepository(db, 1*time.Millisecond)
+
+		err = repo.Save(context.Background(), indicator)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+	})
+}
+
+func Test_IndicatorsRepository_Query(t *testing.T) {
+	indicatorQuery := "SELECT indicators.id, indicators.name FROM indicators WHERE id = $1"
+	valuesQuery := "SELECT indicator_values.indicator_id, indicator_values.geo_id, indicator_values.geo_name, indicator_values.datetime, indicator_values.value " +
+		"FROM indicator_values WHERE indicator_id = $1 AND datetime >= $2 AND datetime < $3 AND geo_id = $4 ORDER BY datetime, geo_id"
+
+	id, err := domain.NewIndicatorID("600")
+	require.NoError(t, err)
+	geoID := "3"
+	from := time.Date(2023, 9, 8, 0, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	to := from.AddDate(0, 0, 1)
+
+	t.Run("when the indicator is not stored, repository returns a not found error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(indicatorQuery).WithArgs("600").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+
+		repo := NewIndicatorsRepository(db, 1*time.Millisecond)
+
+		_, err = repo.Query(context.Background(), id, &geoID, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+		require.Equal(t, dErrors.IndicatorNotFound, dErrors.Code(err))
+	})
+
+	t.Run("when db returns error, repository returns error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(indicatorQuery).WithArgs("600").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("600", "Spot"))
+		sqlMock.ExpectQuery(valuesQuery).
+			WithArgs("600", "2023-09-07T22:00:00Z", "2023-09-08T22:00:00Z", "3").
+			WillReturnError(errors.New("mock-error"))
+
+		repo := NewIndicatorsRepository(db, 1*time.Millisecond)
+
+		_, err = repo.Query(context.Background(), id, &geoID, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("when everything goes OK, repository returns the indicator with its values", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(indicatorQuery).WithArgs("600").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("600", "Spot"))
+		sqlMock.ExpectQuery(valuesQuery).
+			WithArgs("600", "2023-09-07T22:00:00Z", "2023-09-08T22:00:00Z", "3").
+			WillReturnRows(sqlmock.NewRows([]string{"indicator_id", "geo_id", "geo_name", "datetime", "value"}).
+				AddRow("600", "3", "España", "2023-09-07T22:00:00Z", 106.45))
+
+		repo := NewIndicatorsRepository(db, 1*time.Millisecond)
+
+		indicator, err := repo.Query(context.Background(), id, &geoID, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+		require.Equal(t, domain.IndicatorDto{
+			ID:     "600",
+			Name:   "Spot",
+			Values: []domain.IndicatorValueDto{{Datetime: "2023-09-07T22:00:00Z", GeoID: "3", GeoName: "España", Value: 106.45}},
+		}, indicator.Serialize())
+	})
+}
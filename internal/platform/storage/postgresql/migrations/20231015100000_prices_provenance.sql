@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE prices
+    ADD COLUMN IF NOT EXISTS provider    TEXT        NULL, -- provider the prices were fetched from
+    ADD COLUMN IF NOT EXISTS fetched_at  TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS source      TEXT        NULL; -- upstream request used to fetch the prices
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE prices
+    DROP COLUMN IF EXISTS source,
+    DROP COLUMN IF EXISTS fetched_at,
+    DROP COLUMN IF EXISTS provider;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prices_revisions
+(
+    id          BIGSERIAL    PRIMARY KEY,
+    prices_id   CHAR(14)     NOT NULL REFERENCES prices (id) ON DELETE CASCADE,
+    date        DATE         NOT NULL,
+    zone_id     CHAR(3)      NOT NULL REFERENCES zones (id),
+    values      JSONB        NOT NULL,
+    provider    TEXT         NULL,
+    fetched_at  TIMESTAMPTZ  NULL,
+    source      TEXT         NULL,
+    revised_at  TIMESTAMPTZ  NOT NULL DEFAULT now() -- when this version was replaced
+);
+
+CREATE INDEX IF NOT EXISTS prices_revisions_prices_id_index ON prices_revisions (prices_id, revised_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS prices_revisions_prices_id_index;
+DROP TABLE IF EXISTS prices_revisions CASCADE;
+-- +goose StatementEnd
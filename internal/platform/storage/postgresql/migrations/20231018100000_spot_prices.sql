@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS spot_prices
+(
+    id          CHAR(14)     PRIMARY KEY,
+    date        DATE         NOT NULL,
+    zone_id     CHAR(3)      NOT NULL REFERENCES zones (id),
+    values      JSONB        NOT NULL,
+    provider    TEXT         NULL,
+    fetched_at  TIMESTAMPTZ  NULL,
+    source      TEXT         NULL
+);
+
+CREATE INDEX IF NOT EXISTS spot_prices_zone_id_date_index ON spot_prices (zone_id, date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS spot_prices_zone_id_date_index;
+DROP TABLE IF EXISTS spot_prices CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS holidays
+(
+    date  DATE  PRIMARY KEY, -- national holiday, fully in the P3 tariff period
+    name  TEXT  NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS holidays;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS households
+(
+    cups     TEXT     PRIMARY KEY, -- supply point code
+    zone_id  CHAR(3)  NOT NULL REFERENCES zones (id)
+);
+
+CREATE TABLE IF NOT EXISTS household_consumption
+(
+    cups      TEXT              NOT NULL REFERENCES households (cups) ON DELETE CASCADE,
+    datetime  TIMESTAMPTZ       NOT NULL,
+    kwh       DOUBLE PRECISION  NOT NULL,
+    PRIMARY KEY (cups, datetime)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS household_consumption CASCADE;
+DROP TABLE IF EXISTS households CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS forecasts
+(
+    zone_id     CHAR(3)      NOT NULL REFERENCES zones (id),
+    date        DATE         NOT NULL,
+    model       TEXT         NOT NULL, -- seasonal_naive, weekday_profile or exponential_smoothing
+    created_at  TIMESTAMPTZ  NOT NULL,
+    hours       JSONB        NOT NULL,
+    PRIMARY KEY (zone_id, date, model)
+);
+
+CREATE TABLE IF NOT EXISTS forecast_accuracy
+(
+    zone_id      CHAR(3)           NOT NULL REFERENCES zones (id),
+    date         DATE              NOT NULL,
+    model        TEXT              NOT NULL,
+    hours        INTEGER           NOT NULL,
+    mae          DOUBLE PRECISION  NOT NULL,
+    rmse         DOUBLE PRECISION  NOT NULL,
+    bias         DOUBLE PRECISION  NOT NULL,
+    recorded_at  TIMESTAMPTZ       NOT NULL,
+    PRIMARY KEY (zone_id, date, model)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS forecast_accuracy CASCADE;
+DROP TABLE IF EXISTS forecasts CASCADE;
+-- +goose StatementEnd
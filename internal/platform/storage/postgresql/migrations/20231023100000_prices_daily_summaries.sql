@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prices_daily_summaries
+(
+    zone_id     CHAR(3)           NOT NULL REFERENCES zones (id),
+    date        DATE              NOT NULL,
+    hours       INTEGER           NOT NULL,
+    min_value   DOUBLE PRECISION  NOT NULL,
+    max_value   DOUBLE PRECISION  NOT NULL,
+    sum_value   DOUBLE PRECISION  NOT NULL,
+    peak_hours  INTEGER           NOT NULL, -- from 08:00 to 20:00 of Monday to Friday
+    peak_sum    DOUBLE PRECISION  NOT NULL,
+    PRIMARY KEY (zone_id, date)
+);
+
+INSERT INTO prices_daily_summaries (zone_id, date, hours, min_value, max_value, sum_value, peak_hours, peak_sum)
+SELECT zone_id, date, COUNT(*), MIN(value), MAX(value), SUM(value),
+       COUNT(*) FILTER (WHERE peak), COALESCE(SUM(value) FILTER (WHERE peak), 0)
+FROM (
+    SELECT zone_id, date, value, EXTRACT(ISODOW FROM local_datetime) < 6 AND EXTRACT(HOUR FROM local_datetime) BETWEEN 8 AND 19 AS peak
+    FROM (
+        -- zones don't have their own time zone yet, every one is in the default one
+        SELECT prices.zone_id, prices.date, (price->>'value')::DOUBLE PRECISION AS value,
+               (price->>'datetime')::TIMESTAMPTZ AT TIME ZONE 'Europe/Madrid' AS local_datetime
+        FROM prices, jsonb_array_elements(prices.values) AS price
+    ) AS local_prices
+) AS hourly_prices
+GROUP BY zone_id, date;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS prices_daily_summaries CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS quarantined_prices
+(
+    type         TEXT         NOT NULL, -- pvpc or surplus
+    id           CHAR(14)     NOT NULL,
+    date         DATE         NOT NULL,
+    zone_id      CHAR(3)      NOT NULL REFERENCES zones (id),
+    values       JSONB        NOT NULL,
+    provider     TEXT         NULL,
+    fetched_at   TIMESTAMPTZ  NULL,
+    source       TEXT         NULL,
+    anomalies    JSONB        NOT NULL,
+    status       TEXT         NOT NULL, -- pending, approved or rejected
+    detected_at  TIMESTAMPTZ  NOT NULL,
+    reviewed_at  TIMESTAMPTZ  NULL,
+    PRIMARY KEY (type, id)
+);
+
+CREATE INDEX IF NOT EXISTS quarantined_prices_status_index ON quarantined_prices (status, detected_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS quarantined_prices_status_index;
+DROP TABLE IF EXISTS quarantined_prices CASCADE;
+-- +goose StatementEnd
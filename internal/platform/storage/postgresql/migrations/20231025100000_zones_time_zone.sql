@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE zones
+    ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'Europe/Madrid'; -- IANA time zone of the zone local time
+
+UPDATE zones SET time_zone = 'Atlantic/Canary' WHERE id = 'CAN';
+
+-- peak hours are the ones of each zone's local time
+UPDATE prices_daily_summaries AS summaries
+SET peak_hours = peak.hours, peak_sum = peak.sum
+FROM (
+    SELECT zone_id, date, COUNT(*) FILTER (WHERE peak) AS hours, COALESCE(SUM(value) FILTER (WHERE peak), 0) AS sum
+    FROM (
+        SELECT zone_id, date, value, EXTRACT(ISODOW FROM local_datetime) < 6 AND EXTRACT(HOUR FROM local_datetime) BETWEEN 8 AND 19 AS peak
+        FROM (
+            SELECT prices.zone_id, prices.date, (price->>'value')::DOUBLE PRECISION AS value,
+                   (price->>'datetime')::TIMESTAMPTZ AT TIME ZONE zones.time_zone AS local_datetime
+            FROM prices JOIN zones ON zones.id = prices.zone_id, jsonb_array_elements(prices.values) AS price
+        ) AS local_prices
+    ) AS hourly_prices
+    GROUP BY zone_id, date
+) AS peak
+WHERE summaries.zone_id = peak.zone_id AND summaries.date = peak.date;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE zones
+    DROP COLUMN IF EXISTS time_zone;
+-- +goose StatementEnd
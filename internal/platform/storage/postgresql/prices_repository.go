@@ -16,21 +16,33 @@ import (
 )
 
 const (
-	pricesTableName = "prices"
+	pricesTableName          = "prices"
+	pricesRevisionsTableName = "prices_revisions"
+	pricesSummariesTableName = "prices_daily_summaries"
+	surplusPricesTableName   = "surplus_prices"
 )
 
+// pricesTableNames are the tables where the prices of each type are stored.
+var pricesTableNames = map[domain.PricesType]string{
+	domain.PVPCPrices:    pricesTableName,
+	domain.SurplusPrices: surplusPricesTableName,
+}
+
 type pricesSchema struct {
 	ID           string                 `db:"id"`
 	Date         string                 `db:"date"`
 	ZoneID       string                 `db:"zone_id"`
 	HourlyPrices hourlyPriceSchemaSlice `db:"values"`
+	Provider     sql.NullString         `db:"provider"`
+	FetchedAt    sql.NullString         `db:"fetched_at"`
+	Source       sql.NullString         `db:"source"`
 }
 
 type hourlyPriceSchemaSlice []hourlyPriceSchema
 
 type hourlyPriceSchema struct {
-	Datetime string  `json:"datetime"`
-	Price    float64 `json:"value"`
+	Datetime string         `json:"datetime"`
+	Price    domain.Decimal `json:"value"` // in €/MWh, encoded as a JSON number with all its digits
 }
 
 // Make the hourlyPriceSchemaSlice type implement the driver.Value interface.
@@ -65,56 +77,337 @@ func NewPricesRepository(db *sql.DB, dbTimeout time.Duration) *PricesRepository
 }
 
 // Save implements the domain.PricesRepository interface.
+// The prices of each type are stored in their own table, along with the daily summaries of the PVPC ones,
+// in a single transaction.
 func (r *PricesRepository) Save(ctx context.Context, prices []domain.Prices) error {
 	logger.DebugContext(ctx, "Saving Prices into database")
-	pricesSQL := sqlbuilder.NewStruct(new(pricesSchema))
+	var types []domain.PricesType
+	pricesByType := make(map[domain.PricesType][]domain.Prices)
+	for _, p := range prices {
+		if _, ok := pricesByType[p.Type()]; !ok {
+			types = append(types, p.Type())
+		}
+		pricesByType[p.Type()] = append(pricesByType[p.Type()], p)
+	}
 
-	dbPrices := make([]interface{}, len(prices))
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
 
-	for i, p := range prices {
-		values := make([]hourlyPriceSchema, len(p.Values()))
-		for j, v := range p.Values() {
-			values[j] = hourlyPriceSchema{
-				Datetime: v.Datetime().Format(time.RFC3339),
-				Price:    v.Value(),
+	tx, err := r.db.BeginTx(ctxTimeout, nil)
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+	}
+	defer tx.Rollback()
+
+	for _, pricesType := range types {
+		table, err := tableForPricesType(pricesType)
+		if err != nil {
+			return err
+		}
+		query, args := insertPricesQuery(table, pricesByType[pricesType])
+		if _, err := tx.ExecContext(ctxTimeout, query, args...); err != nil {
+			return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+		}
+		if pricesType == domain.PVPCPrices {
+			if err := saveDaySummaries(ctxTimeout, tx, pricesByType[pricesType]); err != nil {
+				return err
 			}
 		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+	}
+	return nil
+}
 
-		dbPrices[i] = pricesSchema{
-			ID:           p.ID().String(),
-			Date:         p.Date().Format("2006-01-02"),
-			ZoneID:       p.Zone().ID().String(),
-			HourlyPrices: values,
-		}
+// Upsert implements the domain.PricesRepository interface.
+func (r *PricesRepository) Upsert(ctx context.Context, prices []domain.Prices) error {
+	logger.DebugContext(ctx, "Upserting Prices into database")
+	if err := onlyPVPCPrices(prices); err != nil {
+		return err
 	}
+	pricesSQL := sqlbuilder.NewStruct(new(pricesSchema))
+
+	dbPrices := mapPricesDomainToSchema(prices)
 
-	query, args := sqlbuilder.WithFlavor(pricesSQL.InsertInto(pricesTableName, dbPrices...), sqlbuilder.PostgreSQL).Build()
+	insert := pricesSQL.InsertInto(pricesTableName, dbPrices...).
+		SQL(`ON CONFLICT (id) DO UPDATE SET date = excluded.date, zone_id = excluded.zone_id, values = excluded.values, provider = excluded.provider, fetched_at = excluded.fetched_at, source = excluded.source`)
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.PostgreSQL).Build()
+
+	ids := make([]interface{}, len(prices))
+	for i, p := range prices {
+		ids[i] = p.ID().String()
+	}
+	// The stored versions of the prices about to be replaced are kept as revisions.
+	revisions := sqlbuilder.NewSelectBuilder()
+	revisions.Select("id", "date", "zone_id", `values`, "provider", "fetched_at", "source").From(pricesTableName).Where(revisions.In("id", ids...))
+	revisionsQuery, revisionsArgs := sqlbuilder.WithFlavor(
+		sqlbuilder.Build("INSERT INTO "+pricesRevisionsTableName+` (prices_id, date, zone_id, values, provider, fetched_at, source) $0`, revisions),
+		sqlbuilder.PostgreSQL,
+	).Build()
 
 	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
 	defer cancel()
 
-	_, err := r.db.ExecContext(ctxTimeout, query, args...)
+	tx, err := r.db.BeginTx(ctxTimeout, nil)
 	if err != nil {
 		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
 	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctxTimeout, revisionsQuery, revisionsArgs...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices revisions into database")
+	}
+	if _, err := tx.ExecContext(ctxTimeout, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+	}
+	if err := saveDaySummaries(ctxTimeout, tx, prices); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+	}
 
 	return nil
 }
 
+// ListRevisions implements the domain.PricesRepository interface.
+func (r *PricesRepository) ListRevisions(ctx context.Context, id domain.PricesID) ([]domain.PricesRevision, error) {
+	logger.DebugContext(ctx, "Listing prices revisions from database", "id", id.String())
+	pricesSQL := sqlbuilder.NewStruct(new(pricesSchema))
+
+	query := sqlbuilder.NewSelectBuilder()
+	query.Select("prices_revisions.prices_id", "prices_revisions.date", "prices_revisions.zone_id", `prices_revisions.values`,
+		"prices_revisions.provider", "prices_revisions.fetched_at", "prices_revisions.source", "prices_revisions.revised_at",
+		"zones.external_id", "zones.name", "zones.time_zone").
+		From(pricesRevisionsTableName).Join(zonesTableName, "prices_revisions.zone_id = zones.id").
+		Where(query.Equal("prices_revisions.prices_id", id.String())).
+		OrderBy("prices_revisions.revised_at", "prices_revisions.id")
+
+	querySQL, args := sqlbuilder.WithFlavor(query, sqlbuilder.PostgreSQL).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, querySQL, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Prices revisions from database")
+	}
+	defer rows.Close()
+
+	revisions := make([]domain.PricesRevision, 0)
+	for rows.Next() {
+		var dbPrices pricesSchema
+		var revisedAt string
+		var zone zoneSchema
+		fields := append(pricesSQL.Addr(&dbPrices), &revisedAt, &zone.ExternalID, &zone.Name, &zone.TimeZone)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices revision from database to schema")
+		}
+
+		revision, err := domain.NewPricesRevision(domain.PricesRevisionDto{
+			Prices:    mapPricesSchemaToDto(dbPrices, zone),
+			RevisedAt: revisedAt,
+		})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices revision from schema to domain")
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
 // Query implements the domain.PricesRepository interface.
 func (r *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
 	logger.DebugContext(ctx, "Querying prices from database", "zoneID", fmt.Sprintf("%v", zoneID), "date", date)
+	return queryPrices(ctx, r.db, r.dbTimeout, pricesTableName, domain.PVPCPrices, zoneID, date)
+}
+
+// QueryByType implements the domain.PricesRepository interface.
+func (r *PricesRepository) QueryByType(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	logger.DebugContext(ctx, "Querying prices from database", "type", pricesType.String(), "zoneID", fmt.Sprintf("%v", zoneID), "date", date)
+	table, err := tableForPricesType(pricesType)
+	if err != nil {
+		return nil, err
+	}
+	return queryPrices(ctx, r.db, r.dbTimeout, table, pricesType, zoneID, date)
+}
+
+// QueryRange implements the domain.PricesRepository interface.
+func (r *PricesRepository) QueryRange(ctx context.Context, zoneID domain.ZoneID, from, to time.Time) ([]domain.Prices, error) {
+	logger.DebugContext(ctx, "Querying prices range from database", "zone_id", zoneID.String(),
+		"from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	query := sqlbuilder.NewSelectBuilder().Select(pricesTableName+".id", pricesTableName+".date", pricesTableName+".zone_id", pricesTableName+".values", pricesTableName+".provider", pricesTableName+".fetched_at", pricesTableName+".source", "zones.external_id", "zones.name", "zones.time_zone").
+		From(pricesTableName).Join(zonesTableName, pricesTableName+".zone_id = zones.id")
+	query = query.Where(
+		query.Equal(pricesTableName+".zone_id", zoneID.String()),
+		query.Between(pricesTableName+".date", from.Format(time.DateOnly), to.Format(time.DateOnly)),
+	).OrderBy(pricesTableName + ".date")
+	return selectPrices(ctx, r.db, r.dbTimeout, query, domain.PVPCPrices)
+}
+
+// Aggregate implements the domain.PricesRepository interface.
+// The prices are aggregated from the daily summaries refreshed on every Save and Upsert.
+func (r *PricesRepository) Aggregate(ctx context.Context, zoneID domain.ZoneID, granularity domain.AggregateGranularity, from, to time.Time) ([]domain.PricesAggregate, error) {
+	logger.DebugContext(ctx, "Aggregating prices from database", "zone_id", zoneID.String(), "granularity", granularity.String(),
+		"from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	periodStart, ok := aggregatePeriodStarts[granularity]
+	if !ok {
+		return nil, errors.NewDomainError(errors.InvalidGranularity, "unknown aggregate granularity: %s", granularity.String())
+	}
+
+	query := sqlbuilder.NewSelectBuilder()
+	query.Select(periodStart+" AS period_start", "COUNT(*)", "SUM(hours)", "MIN(min_value)", "MAX(max_value)",
+		"AVG(sum_value / hours)", "SUM(sum_value) / SUM(hours)",
+		"SUM(peak_sum) / NULLIF(SUM(peak_hours), 0)", "SUM(sum_value - peak_sum) / NULLIF(SUM(hours - peak_hours), 0)").
+		From(pricesSummariesTableName).
+		Where(query.Equal("zone_id", zoneID.String()), query.Between("date", from.Format(time.DateOnly), to.Format(time.DateOnly)), "hours > 0").
+		GroupBy("period_start").
+		OrderBy("period_start")
+
+	querySQL, args := sqlbuilder.WithFlavor(query, sqlbuilder.PostgreSQL).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, querySQL, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error aggregating Prices from database")
+	}
+	defer rows.Close()
+
+	aggregates := make([]domain.PricesAggregate, 0)
+	for rows.Next() {
+		var periodStart time.Time
+		var peakMean, offpeakMean sql.NullFloat64
+		dto := domain.PricesAggregateDto{ZoneID: zoneID.String(), Granularity: granularity.String()}
+		if err := rows.Scan(&periodStart, &dto.Days, &dto.Hours, &dto.Min, &dto.Max, &dto.Mean, &dto.WeightedMean, &peakMean, &offpeakMean); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices aggregate from database")
+		}
+		dto.PeriodStart = periodStart.Format(time.DateOnly)
+		if peakMean.Valid {
+			dto.PeakMean = &peakMean.Float64
+		}
+		if offpeakMean.Valid {
+			dto.OffpeakMean = &offpeakMean.Float64
+		}
+
+		aggregate, err := domain.NewPricesAggregate(dto)
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices aggregate from database to domain")
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+
+	return aggregates, nil
+}
+
+type pricesDaySummarySchema struct {
+	ZoneID    string  `db:"zone_id"`
+	Date      string  `db:"date"`
+	Hours     int     `db:"hours"`
+	Min       float64 `db:"min_value"`
+	Max       float64 `db:"max_value"`
+	Sum       float64 `db:"sum_value"`
+	PeakHours int     `db:"peak_hours"`
+	PeakSum   float64 `db:"peak_sum"`
+}
+
+// aggregatePeriodStarts are the expressions of the first day of the period of a summary date, by granularity.
+var aggregatePeriodStarts = map[domain.AggregateGranularity]string{
+	domain.WeekGranularity:  "date_trunc('week', date)::DATE",
+	domain.MonthGranularity: "date_trunc('month', date)::DATE",
+	domain.YearGranularity:  "date_trunc('year', date)::DATE",
+}
+
+// saveDaySummaries inserts or replaces, within tx, the daily summaries of the given PVPC prices
+// that Aggregate reads.
+func saveDaySummaries(ctx context.Context, tx *sql.Tx, prices []domain.Prices) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	summaries := make([]interface{}, len(prices))
+	for i, p := range prices {
+		summary := domain.NewPricesDaySummary(p)
+		summaries[i] = pricesDaySummarySchema{
+			ZoneID:    summary.ZoneID().String(),
+			Date:      summary.Date().Format(time.DateOnly),
+			Hours:     summary.Hours(),
+			Min:       summary.Min(),
+			Max:       summary.Max(),
+			Sum:       summary.Sum(),
+			PeakHours: summary.PeakHours(),
+			PeakSum:   summary.PeakSum(),
+		}
+	}
+
+	insert := sqlbuilder.NewStruct(new(pricesDaySummarySchema)).InsertInto(pricesSummariesTableName, summaries...).
+		SQL(`ON CONFLICT (zone_id, date) DO UPDATE SET hours = excluded.hours, min_value = excluded.min_value, max_value = excluded.max_value, ` +
+			`sum_value = excluded.sum_value, peak_hours = excluded.peak_hours, peak_sum = excluded.peak_sum`)
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.PostgreSQL).Build()
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices summaries into database")
+	}
+	return nil
+}
+
+// tableForPricesType returns the table where the prices of the given type are stored.
+func tableForPricesType(pricesType domain.PricesType) (string, error) {
+	table, ok := pricesTableNames[pricesType]
+	if !ok {
+		return "", errors.NewDomainError(errors.InvalidPricesType, "unknown Prices type: %s", pricesType.String())
+	}
+	return table, nil
+}
+
+// onlyPVPCPrices returns an error if any of the prices is not a PVPC one, as only those have revisions.
+func onlyPVPCPrices(prices []domain.Prices) error {
+	for _, p := range prices {
+		if p.Type() != domain.PVPCPrices {
+			return errors.NewDomainError(errors.InvalidPricesType, "only %s prices can be upserted, got %s prices: %s", domain.PVPCPrices, p.Type(), p.ID().String())
+		}
+	}
+	return nil
+}
+
+// savePrices inserts the prices into table, which must have the prices table columns.
+func savePrices(ctx context.Context, db *sql.DB, dbTimeout time.Duration, table string, prices []domain.Prices) error {
+	query, args := insertPricesQuery(table, prices)
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, dbTimeout)
+	defer cancel()
+
+	_, err := db.ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Prices into database")
+	}
+
+	return nil
+}
+
+// insertPricesQuery returns the query that inserts the prices into table, which must have the prices table columns.
+func insertPricesQuery(table string, prices []domain.Prices) (string, []interface{}) {
 	pricesSQL := sqlbuilder.NewStruct(new(pricesSchema))
 
-	query := sqlbuilder.NewSelectBuilder().Select("prices.id", "prices.date", "prices.zone_id", "prices.values", "zones.external_id", "zones.name").
-		From(pricesTableName).Join(zonesTableName, "prices.zone_id = zones.id")
+	dbPrices := mapPricesDomainToSchema(prices)
+
+	return sqlbuilder.WithFlavor(pricesSQL.InsertInto(table, dbPrices...), sqlbuilder.PostgreSQL).Build()
+}
+
+// queryPrices queries the prices of pricesType from table, which must have the prices table columns,
+// following the domain.PricesRepository Query semantics.
+func queryPrices(ctx context.Context, db *sql.DB, dbTimeout time.Duration, table string, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	query := sqlbuilder.NewSelectBuilder().Select(table+".id", table+".date", table+".zone_id", table+".values", table+".provider", table+".fetched_at", table+".source", "zones.external_id", "zones.name", "zones.time_zone").
+		From(table).Join(zonesTableName, table+".zone_id = zones.id")
 
 	if date == nil {
 		if zoneID == nil {
 			query = sqlbuilder.NewSelectBuilder().
-				Select("DISTINCT ON (prices.zone_id) prices.id", "prices.date", "prices.zone_id", "prices.values", "zones.external_id", "zones.name").
-				From(pricesTableName).Join(zonesTableName, "prices.zone_id = zones.id").
-				OrderBy("prices.zone_id", "prices.date").Desc()
+				Select("DISTINCT ON ("+table+".zone_id) "+table+".id", table+".date", table+".zone_id", table+".values", table+".provider", table+".fetched_at", table+".source", "zones.external_id", "zones.name", "zones.time_zone").
+				From(table).Join(zonesTableName, table+".zone_id = zones.id").
+				OrderBy(table+".zone_id", table+".date").Desc()
 		} else {
 			query = query.Where((fmt.Sprintf("zone_id = '%s'", zoneID.String()))).OrderBy("date").Desc().Limit(1)
 		}
@@ -126,13 +419,20 @@ func (r *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, dat
 		}
 	}
 
+	return selectPrices(ctx, db, dbTimeout, query, pricesType)
+}
+
+// selectPrices runs query, a select of the prices table columns joined with the zone ones,
+// and maps the rows into prices of pricesType.
+func selectPrices(ctx context.Context, db *sql.DB, dbTimeout time.Duration, query *sqlbuilder.SelectBuilder, pricesType domain.PricesType) ([]domain.Prices, error) {
+	pricesSQL := sqlbuilder.NewStruct(new(pricesSchema))
 	querySQL, args := sqlbuilder.WithFlavor(query, sqlbuilder.PostgreSQL).Build()
 	logger.DebugContext(ctx, "Querying prices from database", "query", querySQL, "args", args)
 
-	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	ctxTimeout, cancel := context.WithTimeout(ctx, dbTimeout)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctxTimeout, querySQL, args...)
+	rows, err := db.QueryContext(ctxTimeout, querySQL, args...)
 	if err != nil {
 		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Prices from database")
 	}
@@ -141,14 +441,16 @@ func (r *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, dat
 	prices := make([]domain.Prices, 0, 5)
 	for rows.Next() {
 		var dbPrices pricesSchema
-		var zoneExternalID, zoneName string
-		fields := append(pricesSQL.Addr(&dbPrices), &zoneExternalID, &zoneName)
+		var zone zoneSchema
+		fields := append(pricesSQL.Addr(&dbPrices), &zone.ExternalID, &zone.Name, &zone.TimeZone)
 		err := rows.Scan(fields...)
 		if err != nil {
 			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices from database to schema")
 		}
 
-		domainPrices, err := mapPricesSchemaToDomain(dbPrices, zoneExternalID, zoneName)
+		dto := mapPricesSchemaToDto(dbPrices, zone)
+		dto.Type = pricesType.String()
+		domainPrices, err := domain.NewPrices(dto)
 		if err != nil {
 			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Prices from schema to domain")
 		}
@@ -158,22 +460,68 @@ func (r *PricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, dat
 	return prices, nil
 }
 
-func mapPricesSchemaToDomain(priceSchema pricesSchema, zoneExternalID, zoneName string) (domain.Prices, error) {
+func mapPricesDomainToSchema(prices []domain.Prices) []interface{} {
+	dbPrices := make([]interface{}, len(prices))
+
+	for i, p := range prices {
+		values := make([]hourlyPriceSchema, len(p.Values()))
+		for j, v := range p.Values() {
+			values[j] = hourlyPriceSchema{
+				Datetime: v.Datetime().Format(time.RFC3339),
+				Price:    v.Price().In(domain.MWh).Amount(),
+			}
+		}
+
+		schema := pricesSchema{
+			ID:           p.ID().String(),
+			Date:         p.Date().Format("2006-01-02"),
+			ZoneID:       p.Zone().ID().String(),
+			HourlyPrices: values,
+		}
+		if provenance := p.Provenance(); provenance != nil {
+			schema = withProvenance(schema, *provenance)
+		}
+		dbPrices[i] = schema
+	}
+
+	return dbPrices
+}
+
+// mapPricesSchemaToDto maps the prices with the zone's columns they were joined with. The zone ID is the prices' one.
+func mapPricesSchemaToDto(priceSchema pricesSchema, zone zoneSchema) domain.PricesDto {
 	var hourlyPrices []domain.HourlyPriceDto
 
 	for _, v := range priceSchema.HourlyPrices {
 		hourlyPrice := domain.HourlyPriceDto{
 			Datetime: v.Datetime,
-			Value:    v.Price,
+			Value:    v.Price.Float64(),
+			Amount:   v.Price.String(),
 		}
 
 		hourlyPrices = append(hourlyPrices, hourlyPrice)
 	}
 
-	return domain.NewPrices(domain.PricesDto{
-		ID:     priceSchema.ID,
-		Date:   priceSchema.Date,
-		Zone:   domain.ZoneDto{ID: priceSchema.ZoneID, ExternalID: zoneExternalID, Name: zoneName},
-		Values: hourlyPrices,
-	})
+	var provenance *domain.ProvenanceDto
+	if priceSchema.Provider.Valid {
+		provenance = &domain.ProvenanceDto{
+			Provider:  priceSchema.Provider.String,
+			FetchedAt: priceSchema.FetchedAt.String,
+			Source:    priceSchema.Source.String,
+		}
+	}
+
+	return domain.PricesDto{
+		ID:         priceSchema.ID,
+		Date:       priceSchema.Date,
+		Zone:       domain.ZoneDto{ID: priceSchema.ZoneID, ExternalID: zone.ExternalID, Name: zone.Name, TimeZone: zone.TimeZone},
+		Values:     hourlyPrices,
+		Provenance: provenance,
+	}
+}
+
+func withProvenance(priceSchema pricesSchema, provenance domain.Provenance) pricesSchema {
+	priceSchema.Provider = sql.NullString{String: provenance.Provider(), Valid: true}
+	priceSchema.FetchedAt = sql.NullString{String: provenance.FetchedAt().UTC().Format(time.RFC3339Nano), Valid: true}
+	priceSchema.Source = sql.NullString{String: provenance.Source(), Valid: true}
+	return priceSchema
 }
@@ -2,6 +2,7 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -39,12 +40,14 @@ func Test_PricesRepository_Save(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: value}, {Datetime: datetime, Price: value}}
+		values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}, {Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}}
 
+		sqlMock.ExpectBegin()
 		sqlMock.ExpectExec(
-			"INSERT INTO prices (id, date, zone_id, values) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)").
-			WithArgs(id1, date1, zoneID, values, id2, date2, zoneID, values).
+			"INSERT INTO prices (id, date, zone_id, values, provider, fetched_at, source) VALUES ($1, $2, $3, $4, $5, $6, $7), ($8, $9, $10, $11, $12, $13, $14)").
+			WithArgs(id1, date1, zoneID, values, sql.NullString{}, sql.NullString{}, sql.NullString{}, id2, date2, zoneID, values, sql.NullString{}, sql.NullString{}, sql.NullString{}).
 			WillReturnError(errors.New("mock-error"))
+		sqlMock.ExpectRollback()
 
 		repo := NewPricesRepository(db, 1*time.Millisecond)
 
@@ -79,12 +82,17 @@ func Test_PricesRepository_Save(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: value}, {Datetime: datetime, Price: value}}
+		values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}, {Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}}
 
+		sqlMock.ExpectBegin()
 		sqlMock.ExpectExec(
-			"INSERT INTO prices (id, date, zone_id, values) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)").
-			WithArgs(id1, date1, zoneID, values, id2, date2, zoneID, values).
+			"INSERT INTO prices (id, date, zone_id, values, provider, fetched_at, source) VALUES ($1, $2, $3, $4, $5, $6, $7), ($8, $9, $10, $11, $12, $13, $14)").
+			WithArgs(id1, date1, zoneID, values, sql.NullString{}, sql.NullString{}, sql.NullString{}, id2, date2, zoneID, values, sql.NullString{}, sql.NullString{}, sql.NullString{}).
 			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectExec(summariesQuery+", ($9, $10, $11, $12, $13, $14, $15, $16)"+summariesConflict).
+			WithArgs(zoneID, date1, 2, value, value, 2*value, 0, 0.0, zoneID, date2, 2, value, value, 2*value, 0, 0.0).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		sqlMock.ExpectCommit()
 
 		repo := NewPricesRepository(db, 1*time.Millisecond)
 
@@ -94,8 +102,50 @@ func Test_PricesRepository_Save(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("when saving the summaries fails, the prices are rolled back", func(t *testing.T) {
+		id, date, dateRFC3339 := "ZON-2023-08-10", "2023-08-10", "2023-08-10T00:00:00+02:00"
+		zoneID, zoneExternalID, zoneName := "ZON", "123", "Test zone"
+		datetime, value := "2023-08-10T00:00:00+02:00", float64(0.1234)
+
+		prices, err := domain.NewPrices(domain.PricesDto{
+			ID:     id,
+			Date:   dateRFC3339,
+			Zone:   domain.ZoneDto{ID: zoneID, ExternalID: zoneExternalID, Name: zoneName},
+			Values: []domain.HourlyPriceDto{{Datetime: datetime, Value: value}},
+		})
+		require.NoError(t, err)
+
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}}
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(
+			"INSERT INTO prices (id, date, zone_id, values, provider, fetched_at, source) VALUES ($1, $2, $3, $4, $5, $6, $7)").
+			WithArgs(id, date, zoneID, values, sql.NullString{}, sql.NullString{}, sql.NullString{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(summariesQuery+summariesConflict).
+			WithArgs(zoneID, date, 1, value, value, value, 0, 0.0).
+			WillReturnError(errors.New("mock-error"))
+		sqlMock.ExpectRollback()
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		err = repo.Save(context.Background(), []domain.Prices{prices})
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
 }
 
+const (
+	summariesQuery = "INSERT INTO prices_daily_summaries (zone_id, date, hours, min_value, max_value, sum_value, peak_hours, peak_sum) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	summariesConflict = " ON CONFLICT (zone_id, date) DO UPDATE SET hours = excluded.hours, min_value = excluded.min_value, max_value = excluded.max_value, " +
+		"sum_value = excluded.sum_value, peak_hours = excluded.peak_hours, peak_sum = excluded.peak_sum"
+)
+
 func Test_PricesRepository_Query(t *testing.T) {
 
 	t.Run("when db returns error, repository returns error", func(t *testing.T) {
@@ -103,7 +153,7 @@ func Test_PricesRepository_Query(t *testing.T) {
 		require.NoError(t, err)
 
 		sqlMock.ExpectQuery(
-			"SELECT DISTINCT ON (prices.zone_id) prices.id, prices.date, prices.zone_id, prices.values, zones.external_id, zones.name FROM prices JOIN zones ON prices.zone_id = zones.id ORDER BY prices.zone_id, prices.date DESC").
+			"SELECT DISTINCT ON (prices.zone_id) prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone FROM prices JOIN zones ON prices.zone_id = zones.id ORDER BY prices.zone_id, prices.date DESC").
 			WillReturnError(errors.New("mock-error"))
 
 		repo := NewPricesRepository(db, 1*time.Millisecond)
@@ -127,11 +177,11 @@ func Test_PricesRepository_Query(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "external_id", "name"}).
-			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: float64(0.1234)}}, externalZoneID, zoneName)
+		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "provider", "fetched_at", "source", "external_id", "name", "time_zone"}).
+			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, externalZoneID, zoneName, "Europe/Madrid")
 
 		sqlMock.ExpectQuery(
-			"SELECT DISTINCT ON (prices.zone_id) prices.id, prices.date, prices.zone_id, prices.values, zones.external_id, zones.name FROM prices JOIN zones ON prices.zone_id = zones.id ORDER BY prices.zone_id, prices.date DESC").
+			"SELECT DISTINCT ON (prices.zone_id) prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone FROM prices JOIN zones ON prices.zone_id = zones.id ORDER BY prices.zone_id, prices.date DESC").
 			WillReturnRows(rows)
 
 		repo := NewPricesRepository(db, 1*time.Millisecond)
@@ -142,7 +192,7 @@ func Test_PricesRepository_Query(t *testing.T) {
 		prices, err := domain.NewPrices(domain.PricesDto{
 			ID:     id.String(),
 			Date:   date,
-			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName},
+			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName, TimeZone: "Europe/Madrid"},
 			Values: []domain.HourlyPriceDto{{Datetime: date, Value: float64(0.1234)}}},
 		)
 		require.NoError(t, err)
@@ -166,11 +216,11 @@ func Test_PricesRepository_Query(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "external_id", "name"}).
-			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: float64(0.1234)}}, externalZoneID, zoneName)
+		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "provider", "fetched_at", "source", "external_id", "name", "time_zone"}).
+			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, externalZoneID, zoneName, "Europe/Madrid")
 
 		sqlMock.ExpectQuery(
-			"SELECT prices.id, prices.date, prices.zone_id, prices.values, zones.external_id, zones.name FROM prices JOIN zones ON prices.zone_id = zones.id WHERE zone_id = 'ZON' ORDER BY date DESC LIMIT 1").
+			"SELECT prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone FROM prices JOIN zones ON prices.zone_id = zones.id WHERE zone_id = 'ZON' ORDER BY date DESC LIMIT 1").
 			WillReturnRows(rows)
 
 		repo := NewPricesRepository(db, 1*time.Millisecond)
@@ -181,7 +231,7 @@ func Test_PricesRepository_Query(t *testing.T) {
 		prices, err := domain.NewPrices(domain.PricesDto{
 			ID:     id.String(),
 			Date:   date,
-			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName},
+			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName, TimeZone: "Europe/Madrid"},
 			Values: []domain.HourlyPriceDto{{Datetime: date, Value: float64(0.1234)}}},
 		)
 		require.NoError(t, err)
@@ -208,11 +258,11 @@ func Test_PricesRepository_Query(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "external_id", "name"}).
-			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: float64(0.1234)}}, externalZoneID, zoneName)
+		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "provider", "fetched_at", "source", "external_id", "name", "time_zone"}).
+			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, externalZoneID, zoneName, "Europe/Madrid")
 
 		sqlMock.ExpectQuery(
-			"SELECT prices.id, prices.date, prices.zone_id, prices.values, zones.external_id, zones.name FROM prices JOIN zones ON prices.zone_id = zones.id WHERE date = $1").
+			"SELECT prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone FROM prices JOIN zones ON prices.zone_id = zones.id WHERE date = $1").
 			WithArgs(dateTime.Format("2006-01-02")).
 			WillReturnRows(rows)
 
@@ -224,7 +274,7 @@ func Test_PricesRepository_Query(t *testing.T) {
 		prices, err := domain.NewPrices(domain.PricesDto{
 			ID:     id.String(),
 			Date:   date,
-			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName},
+			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName, TimeZone: "Europe/Madrid"},
 			Values: []domain.HourlyPriceDto{{Datetime: date, Value: float64(0.1234)}}},
 		)
 		require.NoError(t, err)
@@ -251,11 +301,11 @@ func Test_PricesRepository_Query(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "external_id", "name"}).
-			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: float64(0.1234)}}, externalZoneID, zoneName)
+		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "provider", "fetched_at", "source", "external_id", "name", "time_zone"}).
+			AddRow(id.String(), date, zoneID.String(), hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, externalZoneID, zoneName, "Europe/Madrid")
 
 		sqlMock.ExpectQuery(
-			"SELECT prices.id, prices.date, prices.zone_id, prices.values, zones.external_id, zones.name FROM prices JOIN zones ON prices.zone_id = zones.id WHERE (date = $1) AND zone_id = 'ZON'").
+			"SELECT prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone FROM prices JOIN zones ON prices.zone_id = zones.id WHERE (date = $1) AND zone_id = 'ZON'").
 			WithArgs(dateTime.Format("2006-01-02")).
 			WillReturnRows(rows)
 
@@ -267,7 +317,7 @@ func Test_PricesRepository_Query(t *testing.T) {
 		prices, err := domain.NewPrices(domain.PricesDto{
 			ID:     id.String(),
 			Date:   date,
-			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName},
+			Zone:   domain.ZoneDto{ID: zoneID.String(), ExternalID: externalZoneID, Name: zoneName, TimeZone: "Europe/Madrid"},
 			Values: []domain.HourlyPriceDto{{Datetime: date, Value: float64(0.1234)}}},
 		)
 		require.NoError(t, err)
@@ -279,3 +329,203 @@ func Test_PricesRepository_Query(t *testing.T) {
 	})
 
 }
+
+func Test_PricesRepository_QueryRange(t *testing.T) {
+	zoneID, err := domain.NewZoneID("ZON")
+	require.NoError(t, err)
+	from, to := time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC), time.Date(2023, 8, 11, 0, 0, 0, 0, time.UTC)
+	rangeQuery := "SELECT prices.id, prices.date, prices.zone_id, prices.values, prices.provider, prices.fetched_at, prices.source, zones.external_id, zones.name, zones.time_zone " +
+		"FROM prices JOIN zones ON prices.zone_id = zones.id WHERE prices.zone_id = $1 AND prices.date BETWEEN $2 AND $3 ORDER BY prices.date"
+
+	t.Run("when db returns error, repository returns error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(rangeQuery).WithArgs("ZON", "2023-08-10", "2023-08-11").WillReturnError(errors.New("mock-error"))
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		_, err = repo.QueryRange(context.Background(), zoneID, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("queries the days of the zone", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		rows := sqlmock.NewRows([]string{"id", "date", "zone_id", "values", "provider", "fetched_at", "source", "external_id", "name", "time_zone"})
+		for _, date := range []string{"2023-08-10T00:00:00+02:00", "2023-08-11T00:00:00+02:00"} {
+			rows.AddRow("ZON-"+date[:10], date, "ZON", hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, "123", "Test zone", "Europe/Madrid")
+		}
+		sqlMock.ExpectQuery(rangeQuery).WithArgs("ZON", "2023-08-10", "2023-08-11").WillReturnRows(rows)
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		result, err := repo.QueryRange(context.Background(), zoneID, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.Equal(t, "ZON-2023-08-10", result[0].ID().String())
+		require.Equal(t, "ZON-2023-08-11", result[1].ID().String())
+	})
+}
+
+func Test_PricesRepository_Upsert(t *testing.T) {
+	id, date, dateRFC3339 := "ZON-2023-08-10", "2023-08-10", "2023-08-10T00:00:00+02:00"
+	zoneID, zoneExternalID, zoneName := "ZON", "123", "Test zone"
+	datetime, value := "2023-08-10T00:00:00+02:00", float64(0.1234)
+
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:         id,
+		Date:       dateRFC3339,
+		Zone:       domain.ZoneDto{ID: zoneID, ExternalID: zoneExternalID, Name: zoneName},
+		Values:     []domain.HourlyPriceDto{{Datetime: datetime, Value: value}},
+		Provenance: &domain.ProvenanceDto{Provider: "esios", FetchedAt: "2023-08-09T20:30:00Z", Source: "/indicators/1001"},
+	})
+	require.NoError(t, err)
+
+	values := hourlyPriceSchemaSlice{{Datetime: datetime, Price: domain.NewDecimalFromFloat(value)}}
+	provider := sql.NullString{String: "esios", Valid: true}
+	fetchedAt := sql.NullString{String: "2023-08-09T20:30:00Z", Valid: true}
+	source := sql.NullString{String: "/indicators/1001", Valid: true}
+	revisionsQuery := "INSERT INTO prices_revisions (prices_id, date, zone_id, values, provider, fetched_at, source) " +
+		"SELECT id, date, zone_id, values, provider, fetched_at, source FROM prices WHERE id IN ($1)"
+	upsertQuery := "INSERT INTO prices (id, date, zone_id, values, provider, fetched_at, source) VALUES ($1, $2, $3, $4, $5, $6, $7) " +
+		"ON CONFLICT (id) DO UPDATE SET date = excluded.date, zone_id = excluded.zone_id, values = excluded.values, provider = excluded.provider, fetched_at = excluded.fetched_at, source = excluded.source"
+
+	t.Run("when db returns error, repository returns error and rolls back", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(revisionsQuery).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(upsertQuery).
+			WithArgs(id, date, zoneID, values, provider, fetchedAt, source).
+			WillReturnError(errors.New("mock-error"))
+		sqlMock.ExpectRollback()
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		err = repo.Upsert(context.Background(), []domain.Prices{prices})
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("when everything goes OK, the replaced prices are kept as revisions", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(revisionsQuery).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(upsertQuery).
+			WithArgs(id, date, zoneID, values, provider, fetchedAt, source).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(summariesQuery+summariesConflict).
+			WithArgs(zoneID, date, 1, value, value, value, 0, 0.0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		err = repo.Upsert(context.Background(), []domain.Prices{prices})
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+	})
+}
+
+func Test_PricesRepository_ListRevisions(t *testing.T) {
+	query := "SELECT prices_revisions.prices_id, prices_revisions.date, prices_revisions.zone_id, prices_revisions.values, " +
+		"prices_revisions.provider, prices_revisions.fetched_at, prices_revisions.source, prices_revisions.revised_at, zones.external_id, zones.name, zones.time_zone " +
+		"FROM prices_revisions JOIN zones ON prices_revisions.zone_id = zones.id WHERE prices_revisions.prices_id = $1 " +
+		"ORDER BY prices_revisions.revised_at, prices_revisions.id"
+
+	id, err := domain.NewPricesID("ZON-2023-08-10")
+	require.NoError(t, err)
+
+	t.Run("when db returns error, repository returns error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(query).WithArgs(id.String()).WillReturnError(errors.New("mock-error"))
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		_, err = repo.ListRevisions(context.Background(), id)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("returns the revisions", func(t *testing.T) {
+		date := "2023-08-10T00:00:00+02:00"
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		rows := sqlmock.NewRows([]string{"prices_id", "date", "zone_id", "values", "provider", "fetched_at", "source", "revised_at", "external_id", "name", "time_zone"}).
+			AddRow(id.String(), date, "ZON", hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.1234)}}, nil, nil, nil, "2023-08-10T10:00:00Z", "123", "Test zone", "Europe/Madrid").
+			AddRow(id.String(), date, "ZON", hourlyPriceSchemaSlice{{Datetime: date, Price: domain.NewDecimalFromFloat(0.2)}}, "esios", "2023-08-10T09:00:00Z", "/indicators/1001", "2023-08-11T10:00:00Z", "123", "Test zone", "Europe/Madrid")
+		sqlMock.ExpectQuery(query).WithArgs(id.String()).WillReturnRows(rows)
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		revisions, err := repo.ListRevisions(context.Background(), id)
+		require.NoError(t, err)
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Len(t, revisions, 2)
+		require.Equal(t, "2023-08-10T10:00:00Z", revisions[0].RevisedAt().Format(time.RFC3339))
+		require.Nil(t, revisions[0].Prices().Provenance())
+		require.Equal(t, 0.2, revisions[1].Prices().Values()[0].Value())
+		require.Equal(t, "esios", revisions[1].Prices().Provenance().Provider())
+	})
+}
+
+func Test_PricesRepository_Aggregate(t *testing.T) {
+	zoneID, err := domain.NewZoneID("PEN")
+	require.NoError(t, err)
+	from, to := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 31, 0, 0, 0, 0, time.UTC)
+	aggregateQuery := "SELECT date_trunc('month', date)::DATE AS period_start, COUNT(*), SUM(hours), MIN(min_value), MAX(max_value), " +
+		"AVG(sum_value / hours), SUM(sum_value) / SUM(hours), SUM(peak_sum) / NULLIF(SUM(peak_hours), 0), " +
+		"SUM(sum_value - peak_sum) / NULLIF(SUM(hours - peak_hours), 0) FROM prices_daily_summaries " +
+		"WHERE zone_id = $1 AND date BETWEEN $2 AND $3 AND hours > 0 GROUP BY period_start ORDER BY period_start"
+
+	t.Run("when db returns error, repository returns error", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(aggregateQuery).WithArgs("PEN", "2023-10-01", "2023-10-31").WillReturnError(errors.New("mock-error"))
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		_, err = repo.Aggregate(context.Background(), zoneID, domain.MonthGranularity, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.Error(t, err)
+	})
+
+	t.Run("aggregates the daily summaries by period", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+
+		sqlMock.ExpectQuery(aggregateQuery).WithArgs("PEN", "2023-10-01", "2023-10-31").
+			WillReturnRows(sqlmock.NewRows([]string{"period_start", "count", "sum", "min", "max", "avg", "weighted", "peak", "offpeak"}).
+				AddRow(from, 2, 48, 10.5, 200, 100, 101.5, nil, 101.5))
+
+		repo := NewPricesRepository(db, 1*time.Millisecond)
+
+		aggregates, err := repo.Aggregate(context.Background(), zoneID, domain.MonthGranularity, from, to)
+
+		require.NoError(t, sqlMock.ExpectationsWereMet())
+		require.NoError(t, err)
+		require.Len(t, aggregates, 1)
+		offpeakMean := 101.5
+		require.Equal(t, domain.PricesAggregateDto{
+			ZoneID: "PEN", Granularity: "month", PeriodStart: "2023-10-01", Days: 2, Hours: 48,
+			Min: 10.5, Max: 200, Mean: 100, WeightedMean: 101.5, OffpeakMean: &offpeakMean,
+		}, aggregates[0].Serialize())
+	})
+}
@@ -0,0 +1,42 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	spotPricesTableName = "spot_prices"
+)
+
+// SpotPricesRepository is a PostgreSQL domain.SpotPricesRepository implementation.
+// Spot prices are stored in their own table, with the same columns as the prices one.
+type SpotPricesRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewSpotPricesRepository initializes a PostgreSQL-based implementation of domain.SpotPricesRepository.
+func NewSpotPricesRepository(db *sql.DB, dbTimeout time.Duration) *SpotPricesRepository {
+	return &SpotPricesRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Save implements the domain.SpotPricesRepository interface.
+func (r *SpotPricesRepository) Save(ctx context.Context, prices []domain.Prices) error {
+	logger.DebugContext(ctx, "Saving spot prices into database")
+	return savePrices(ctx, r.db, r.dbTimeout, spotPricesTableName, prices)
+}
+
+// Query implements the domain.SpotPricesRepository interface.
+func (r *SpotPricesRepository) Query(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	logger.DebugContext(ctx, "Querying spot prices from database", "zoneID", fmt.Sprintf("%v", zoneID), "date", date)
+	return queryPrices(ctx, r.db, r.dbTimeout, spotPricesTableName, domain.SpotPrices, zoneID, date)
+}
@@ -20,6 +20,7 @@ type zoneSchema struct {
 	ID         string `db:"id"`
 	ExternalID string `db:"external_id"`
 	Name       string `db:"name"`
+	TimeZone   string `db:"time_zone"`
 }
 
 // ZonesRepository is a PostgreSQL domain.ZonesRepository implementation.
@@ -132,5 +133,6 @@ func mapZoneSchemaToDomain(zoneSchema zoneSchema) (domain.Zone, error) {
 		ID:         zoneSchema.ID,
 		ExternalID: zoneSchema.ExternalID,
 		Name:       zoneSchema.Name,
+		TimeZone:   zoneSchema.TimeZone,
 	})
 }
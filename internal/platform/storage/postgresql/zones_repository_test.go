@@ -19,7 +19,7 @@ func Test_ZonesRepository_GetAll(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones").
 			WillReturnError(errors.New("mock-error"))
 
 		repo := NewZonesRepository(db, 1*time.Millisecond)
@@ -37,11 +37,11 @@ func Test_ZonesRepository_GetAll(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"}).
-			AddRow(id1, externalID1, name1).
-			AddRow(id2, externalID2, name2)
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"}).
+			AddRow(id1, externalID1, name1, "Europe/Madrid").
+			AddRow(id2, externalID2, name2, "Atlantic/Canary")
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones").
 			WillReturnRows(rows)
 
 		repo := NewZonesRepository(db, 1*time.Millisecond)
@@ -49,10 +49,10 @@ func Test_ZonesRepository_GetAll(t *testing.T) {
 		result, err := repo.GetAll(context.Background())
 		require.NoError(t, err)
 
-		expected1, err := domain.NewZone(domain.ZoneDto{ID: id1, ExternalID: externalID1, Name: name1})
+		expected1, err := domain.NewZone(domain.ZoneDto{ID: id1, ExternalID: externalID1, Name: name1, TimeZone: "Europe/Madrid"})
 		require.NoError(t, err)
 
-		expected2, err := domain.NewZone(domain.ZoneDto{ID: id2, ExternalID: externalID2, Name: name2})
+		expected2, err := domain.NewZone(domain.ZoneDto{ID: id2, ExternalID: externalID2, Name: name2, TimeZone: "Atlantic/Canary"})
 		require.NoError(t, err)
 
 		require.NoError(t, sqlMock.ExpectationsWereMet())
@@ -66,9 +66,9 @@ func Test_ZonesRepository_GetAll(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"})
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"})
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones").
 			WillReturnRows(rows)
 
 		repo := NewZonesRepository(db, 1*time.Millisecond)
@@ -92,7 +92,7 @@ func Test_ZonesRepository_GetByID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE id = $1").
 			WithArgs(zoneIDString).
 			WillReturnError(errors.New("mock-error"))
 
@@ -112,10 +112,10 @@ func Test_ZonesRepository_GetByID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"}).
-			AddRow(id, externalID, name)
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"}).
+			AddRow(id, externalID, name, "Atlantic/Canary")
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE id = $1").
 			WithArgs(id).
 			WillReturnRows(rows)
 
@@ -124,7 +124,7 @@ func Test_ZonesRepository_GetByID(t *testing.T) {
 		result, err := repo.GetByID(context.Background(), zoneID)
 		require.NoError(t, err)
 
-		expected, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: externalID, Name: name})
+		expected, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: externalID, Name: name, TimeZone: "Atlantic/Canary"})
 		require.NoError(t, err)
 
 		require.NoError(t, sqlMock.ExpectationsWereMet())
@@ -141,9 +141,9 @@ func Test_ZonesRepository_GetByID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"})
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"})
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE id = $1").
 			WithArgs(id).
 			WillReturnRows(rows)
 
@@ -166,7 +166,7 @@ func Test_ZonesRepository_GetByExternalID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE external_id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE external_id = $1").
 			WithArgs(zoneExternalID).
 			WillReturnError(errors.New("mock-error"))
 
@@ -184,10 +184,10 @@ func Test_ZonesRepository_GetByExternalID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"}).
-			AddRow(id, externalID, name)
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"}).
+			AddRow(id, externalID, name, "Atlantic/Canary")
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE external_id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE external_id = $1").
 			WithArgs(externalID).
 			WillReturnRows(rows)
 
@@ -196,7 +196,7 @@ func Test_ZonesRepository_GetByExternalID(t *testing.T) {
 		result, err := repo.GetByExternalID(context.Background(), externalID)
 		require.NoError(t, err)
 
-		expected, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: externalID, Name: name})
+		expected, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: externalID, Name: name, TimeZone: "Atlantic/Canary"})
 		require.NoError(t, err)
 
 		require.NoError(t, sqlMock.ExpectationsWereMet())
@@ -209,9 +209,9 @@ func Test_ZonesRepository_GetByExternalID(t *testing.T) {
 		db, sqlMock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 		require.NoError(t, err)
 
-		rows := sqlmock.NewRows([]string{"id", "external_id", "name"})
+		rows := sqlmock.NewRows([]string{"id", "external_id", "name", "time_zone"})
 
-		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name FROM zones WHERE external_id = $1").
+		sqlMock.ExpectQuery("SELECT zones.id, zones.external_id, zones.name, zones.time_zone FROM zones WHERE external_id = $1").
 			WithArgs(externalID).
 			WillReturnRows(rows)
 
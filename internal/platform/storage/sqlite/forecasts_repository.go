@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	forecastsTableName        = "forecasts"
+	forecastAccuracyTableName = "forecast_accuracy"
+)
+
+type forecastSchema struct {
+	ZoneID    string                   `db:"zone_id"`
+	Date      string                   `db:"date"`
+	Model     string                   `db:"model"`
+	CreatedAt string                   `db:"created_at"`
+	Values    forecastValueSchemaSlice `db:"hours"`
+}
+
+type forecastValueSchemaSlice []forecastValueSchema
+
+type forecastValueSchema struct {
+	Datetime string  `json:"datetime"`
+	Value    float64 `json:"value"`
+	Lower    float64 `json:"lower"`
+	Upper    float64 `json:"upper"`
+}
+
+// Make the forecastValueSchemaSlice type implement the driver.Value interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (vs forecastValueSchemaSlice) Value() (driver.Value, error) {
+	return json.Marshal(vs)
+}
+
+// Make the forecastValueSchemaSlice type implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+// SQLite may return the column either as TEXT or as BLOB, so both are accepted.
+func (vs *forecastValueSchemaSlice) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &vs)
+	case string:
+		return json.Unmarshal([]byte(v), &vs)
+	default:
+		return errors.NewDomainError(errors.PersistenceError, "sql.Scanner Scan() custom implementation: type assertion to []byte or string failed")
+	}
+}
+
+type forecastAccuracySchema struct {
+	ZoneID     string  `db:"zone_id"`
+	Date       string  `db:"date"`
+	Model      string  `db:"model"`
+	Hours      int     `db:"hours"`
+	MAE        float64 `db:"mae"`
+	RMSE       float64 `db:"rmse"`
+	Bias       float64 `db:"bias"`
+	RecordedAt string  `db:"recorded_at"`
+}
+
+// ForecastsRepository is a SQLite domain.ForecastsRepository implementation.
+type ForecastsRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewForecastsRepository initializes a SQLite-based implementation of domain.ForecastsRepository.
+func NewForecastsRepository(db *sql.DB, dbTimeout time.Duration) *ForecastsRepository {
+	return &ForecastsRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Save implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) Save(ctx context.Context, forecast domain.Forecast) error {
+	dto := forecast.Serialize()
+	logger.DebugContext(ctx, "Saving Forecast into database", "zone_id", dto.ZoneID, "date", dto.Date, "model", dto.Model)
+	forecastSQL := sqlbuilder.NewStruct(new(forecastSchema))
+
+	values := make(forecastValueSchemaSlice, len(forecast.Values()))
+	for i, v := range forecast.Values() {
+		values[i] = forecastValueSchema{
+			Datetime: v.Datetime().UTC().Format(time.RFC3339),
+			Value:    v.Value(),
+			Lower:    v.Lower(),
+			Upper:    v.Upper(),
+		}
+	}
+	dbForecast := forecastSchema{ZoneID: dto.ZoneID, Date: dto.Date, Model: dto.Model, CreatedAt: dto.CreatedAt, Values: values}
+
+	insert := forecastSQL.InsertInto(forecastsTableName, dbForecast).
+		SQL("ON CONFLICT (zone_id, date, model) DO UPDATE SET created_at = excluded.created_at, hours = excluded.hours")
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctxTimeout, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Forecast into database")
+	}
+
+	return nil
+}
+
+// Query implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) Query(ctx context.Context, zoneID domain.ZoneID, date time.Time) ([]domain.Forecast, error) {
+	logger.DebugContext(ctx, "Querying Forecasts from database", "zone_id", zoneID.String(), "date", date.Format(time.DateOnly))
+	forecastSQL := sqlbuilder.NewStruct(new(forecastSchema))
+
+	selectForecasts := forecastSQL.SelectFrom(forecastsTableName)
+	selectForecasts.Where(
+		selectForecasts.Equal("zone_id", zoneID.String()),
+		selectForecasts.Equal("date", date.Format(time.DateOnly)),
+	)
+	query, args := sqlbuilder.WithFlavor(selectForecasts.OrderBy("model"), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Forecasts from database")
+	}
+	defer rows.Close()
+
+	forecasts := make([]domain.Forecast, 0)
+	for rows.Next() {
+		var dbForecast forecastSchema
+		if err := rows.Scan(forecastSQL.Addr(&dbForecast)...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Forecast from database to schema")
+		}
+
+		values := make([]domain.ForecastValueDto, len(dbForecast.Values))
+		for i, v := range dbForecast.Values {
+			values[i] = domain.ForecastValueDto{Datetime: v.Datetime, Value: v.Value, Lower: v.Lower, Upper: v.Upper}
+		}
+		forecast, err := domain.NewForecast(domain.ForecastDto{
+			ZoneID:    dbForecast.ZoneID,
+			Date:      dbForecast.Date,
+			Model:     dbForecast.Model,
+			CreatedAt: dbForecast.CreatedAt,
+			Values:    values,
+		})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Forecast from schema to domain")
+		}
+		forecasts = append(forecasts, forecast)
+	}
+
+	return forecasts, nil
+}
+
+// SaveAccuracy implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) SaveAccuracy(ctx context.Context, accuracy domain.ForecastAccuracy) error {
+	dto := accuracy.Serialize()
+	logger.DebugContext(ctx, "Saving ForecastAccuracy into database", "zone_id", dto.ZoneID, "date", dto.Date, "model", dto.Model)
+	accuracySQL := sqlbuilder.NewStruct(new(forecastAccuracySchema))
+
+	dbAccuracy := forecastAccuracySchema{
+		ZoneID:     dto.ZoneID,
+		Date:       dto.Date,
+		Model:      dto.Model,
+		Hours:      dto.Hours,
+		MAE:        dto.MAE,
+		RMSE:       dto.RMSE,
+		Bias:       dto.Bias,
+		RecordedAt: dto.RecordedAt,
+	}
+	insert := accuracySQL.InsertInto(forecastAccuracyTableName, dbAccuracy).
+		SQL("ON CONFLICT (zone_id, date, model) DO UPDATE SET hours = excluded.hours, mae = excluded.mae, rmse = excluded.rmse, bias = excluded.bias, recorded_at = excluded.recorded_at")
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctxTimeout, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist ForecastAccuracy into database")
+	}
+
+	return nil
+}
+
+// ListAccuracy implements the domain.ForecastsRepository interface.
+func (r *ForecastsRepository) ListAccuracy(ctx context.Context, zoneID domain.ZoneID, from, to time.Time) ([]domain.ForecastAccuracy, error) {
+	logger.DebugContext(ctx, "Listing ForecastAccuracy from database", "zone_id", zoneID.String(), "from", from.Format(time.DateOnly), "to", to.Format(time.DateOnly))
+	accuracySQL := sqlbuilder.NewStruct(new(forecastAccuracySchema))
+
+	selectAccuracy := accuracySQL.SelectFrom(forecastAccuracyTableName)
+	selectAccuracy.Where(
+		selectAccuracy.Equal("zone_id", zoneID.String()),
+		selectAccuracy.Between("date", from.Format(time.DateOnly), to.Format(time.DateOnly)),
+	)
+	query, args := sqlbuilder.WithFlavor(selectAccuracy.OrderBy("date", "model"), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying ForecastAccuracy from database")
+	}
+	defer rows.Close()
+
+	accuracies := make([]domain.ForecastAccuracy, 0)
+	for rows.Next() {
+		var dbAccuracy forecastAccuracySchema
+		if err := rows.Scan(accuracySQL.Addr(&dbAccuracy)...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping ForecastAccuracy from database to schema")
+		}
+
+		accuracy, err := domain.NewForecastAccuracy(domain.ForecastAccuracyDto{
+			ZoneID:     dbAccuracy.ZoneID,
+			Date:       dbAccuracy.Date,
+			Model:      dbAccuracy.Model,
+			Hours:      dbAccuracy.Hours,
+			MAE:        dbAccuracy.MAE,
+			RMSE:       dbAccuracy.RMSE,
+			Bias:       dbAccuracy.Bias,
+			RecordedAt: dbAccuracy.RecordedAt,
+		})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping ForecastAccuracy from schema to domain")
+		}
+		accuracies = append(accuracies, accuracy)
+	}
+
+	return accuracies, nil
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	holidaysTableName = "holidays"
+)
+
+type holidaySchema struct {
+	Date string `db:"date"`
+	Name string `db:"name"`
+}
+
+// HolidaysRepository is a SQLite domain.HolidaysRepository implementation.
+type HolidaysRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewHolidaysRepository initializes a SQLite-based implementation of domain.HolidaysRepository.
+func NewHolidaysRepository(db *sql.DB, dbTimeout time.Duration) *HolidaysRepository {
+	return &HolidaysRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// GetAll implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) GetAll(ctx context.Context) ([]domain.Holiday, error) {
+	logger.DebugContext(ctx, "Getting all Holidays from database")
+	holidaySQL := sqlbuilder.NewStruct(new(holidaySchema))
+
+	query, args := sqlbuilder.WithFlavor(holidaySQL.SelectFrom(holidaysTableName).OrderBy("date"), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Holidays from database")
+	}
+	defer rows.Close()
+
+	holidays := make([]domain.Holiday, 0)
+	for rows.Next() {
+		var dbHoliday holidaySchema
+		if err := rows.Scan(holidaySQL.Addr(&dbHoliday)...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Holiday from database to schema")
+		}
+
+		holiday, err := domain.NewHoliday(domain.HolidayDto{Date: dbHoliday.Date, Name: dbHoliday.Name})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Holiday from schema to domain")
+		}
+		holidays = append(holidays, holiday)
+	}
+
+	return holidays, nil
+}
+
+// Save implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) Save(ctx context.Context, holidays []domain.Holiday) error {
+	logger.DebugContext(ctx, "Saving Holidays into database", "count", len(holidays))
+	if len(holidays) == 0 {
+		return nil
+	}
+	holidaySQL := sqlbuilder.NewStruct(new(holidaySchema))
+
+	dbHolidays := make([]interface{}, len(holidays))
+	for i, holiday := range holidays {
+		dbHolidays[i] = holidaySchema{Date: holiday.Date().Format(time.DateOnly), Name: holiday.Name()}
+	}
+
+	insert := holidaySQL.InsertInto(holidaysTableName, dbHolidays...).SQL("ON CONFLICT (date) DO UPDATE SET name = excluded.name")
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctxTimeout, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Holidays into database")
+	}
+
+	return nil
+}
+
+// Delete implements the domain.HolidaysRepository interface.
+func (r *HolidaysRepository) Delete(ctx context.Context, date time.Time) error {
+	logger.DebugContext(ctx, "Deleting Holiday from database", "date", date.Format(time.DateOnly))
+	deleteBuilder := sqlbuilder.NewDeleteBuilder()
+	deleteBuilder.DeleteFrom(holidaysTableName).Where(deleteBuilder.Equal("date", date.Format(time.DateOnly)))
+	query, args := sqlbuilder.WithFlavor(deleteBuilder, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctxTimeout, query, args...)
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to delete Holiday from database")
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to delete Holiday from database")
+	}
+	if deleted == 0 {
+		return errors.NewDomainError(errors.HolidayNotFound, "Holiday on %s not found", date.Format(time.DateOnly))
+	}
+
+	return nil
+}
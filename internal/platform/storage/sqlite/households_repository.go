@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	householdsTableName           = "households"
+	householdConsumptionTableName = "household_consumption"
+	// householdConsumptionBatchSize is the number of hours inserted per statement, to stay below the database parameters limit.
+	householdConsumptionBatchSize = 1000
+)
+
+type householdSchema struct {
+	CUPS   string `db:"cups"`
+	ZoneID string `db:"zone_id"`
+}
+
+type householdConsumptionSchema struct {
+	CUPS     string  `db:"cups"`
+	Datetime string  `db:"datetime"`
+	Kwh      float64 `db:"kwh"`
+}
+
+// HouseholdsRepository is a SQLite domain.HouseholdsRepository implementation.
+type HouseholdsRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewHouseholdsRepository initializes a SQLite-based implementation of domain.HouseholdsRepository.
+func NewHouseholdsRepository(db *sql.DB, dbTimeout time.Duration) *HouseholdsRepository {
+	return &HouseholdsRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Save implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) Save(ctx context.Context, household domain.Household, consumption []domain.HourlyConsumption) error {
+	logger.DebugContext(ctx, "Saving Household into database", "cups", household.CUPS().String(), "hours", len(consumption))
+	householdSQL := sqlbuilder.NewStruct(new(householdSchema))
+	consumptionSQL := sqlbuilder.NewStruct(new(householdConsumptionSchema))
+
+	insertHousehold := householdSQL.InsertInto(householdsTableName, householdSchema{CUPS: household.CUPS().String(), ZoneID: household.ZoneID().String()}).
+		SQL("ON CONFLICT (cups) DO UPDATE SET zone_id = excluded.zone_id")
+	householdQuery, householdArgs := sqlbuilder.WithFlavor(insertHousehold, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctxTimeout, nil)
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Household into database")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctxTimeout, householdQuery, householdArgs...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Household into database")
+	}
+
+	dbConsumption := mapHouseholdConsumptionDomainToSchema(household.CUPS(), consumption)
+	for start := 0; start < len(dbConsumption); start += householdConsumptionBatchSize {
+		end := start + householdConsumptionBatchSize
+		if end > len(dbConsumption) {
+			end = len(dbConsumption)
+		}
+		insertConsumption := consumptionSQL.InsertInto(householdConsumptionTableName, dbConsumption[start:end]...).
+			SQL("ON CONFLICT (cups, datetime) DO UPDATE SET kwh = excluded.kwh")
+		consumptionQuery, consumptionArgs := sqlbuilder.WithFlavor(insertConsumption, sqlbuilder.SQLite).Build()
+
+		if _, err := tx.ExecContext(ctxTimeout, consumptionQuery, consumptionArgs...); err != nil {
+			return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Household consumption into database")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Household into database")
+	}
+
+	return nil
+}
+
+// Get implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) Get(ctx context.Context, cups domain.CUPS) (domain.Household, error) {
+	logger.DebugContext(ctx, "Getting Household from database", "cups", cups.String())
+	householdSQL := sqlbuilder.NewStruct(new(householdSchema))
+
+	selectHousehold := householdSQL.SelectFrom(householdsTableName)
+	query, args := sqlbuilder.WithFlavor(selectHousehold.Where(selectHousehold.Equal("cups", cups.String())), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var dbHousehold householdSchema
+	if err := r.db.QueryRowContext(ctxTimeout, query, args...).Scan(householdSQL.Addr(&dbHousehold)...); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Household{}, errors.NewDomainError(errors.HouseholdNotFound, "Household with CUPS %s not found", cups.String())
+		}
+		return domain.Household{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Household from database to schema")
+	}
+
+	household, err := domain.NewHousehold(domain.HouseholdDto{CUPS: dbHousehold.CUPS, ZoneID: dbHousehold.ZoneID})
+	if err != nil {
+		return domain.Household{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Household from schema to domain")
+	}
+
+	return household, nil
+}
+
+// QueryConsumption implements the domain.HouseholdsRepository interface.
+func (r *HouseholdsRepository) QueryConsumption(ctx context.Context, cups domain.CUPS, from, to time.Time) ([]domain.HourlyConsumption, error) {
+	if _, err := r.Get(ctx, cups); err != nil {
+		return nil, err
+	}
+
+	logger.DebugContext(ctx, "Querying Household consumption from database", "cups", cups.String(), "from", from, "to", to)
+	consumptionSQL := sqlbuilder.NewStruct(new(householdConsumptionSchema))
+
+	selectConsumption := consumptionSQL.SelectFrom(householdConsumptionTableName)
+	selectConsumption.Where(
+		selectConsumption.Equal("cups", cups.String()),
+		selectConsumption.GreaterEqualThan("datetime", from.UTC().Format(time.RFC3339)),
+		selectConsumption.LessThan("datetime", to.UTC().Format(time.RFC3339)),
+	)
+	query, args := sqlbuilder.WithFlavor(selectConsumption.OrderBy("datetime"), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, query, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Household consumption from database")
+	}
+	defer rows.Close()
+
+	consumption := make([]domain.HourlyConsumption, 0)
+	for rows.Next() {
+		var dbConsumption householdConsumptionSchema
+		if err := rows.Scan(consumptionSQL.Addr(&dbConsumption)...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Household consumption from database to schema")
+		}
+
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: dbConsumption.Datetime, Kwh: dbConsumption.Kwh})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Household consumption from schema to domain")
+		}
+		consumption = append(consumption, c)
+	}
+
+	return consumption, nil
+}
+
+func mapHouseholdConsumptionDomainToSchema(cups domain.CUPS, consumption []domain.HourlyConsumption) []interface{} {
+	dbConsumption := make([]interface{}, len(consumption))
+	for i, c := range consumption {
+		dbConsumption[i] = householdConsumptionSchema{
+			CUPS:     cups.String(),
+			Datetime: c.Datetime().UTC().Format(time.RFC3339),
+			Kwh:      c.Kwh(),
+		}
+	}
+	return dbConsumption
+}
@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	indicatorsTableName      = "indicators"
+	indicatorValuesTableName = "indicator_values"
+)
+
+type indicatorSchema struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+type indicatorValueSchema struct {
+	IndicatorID string  `db:"indicator_id"`
+	GeoID       string  `db:"geo_id"`
+	GeoName     string  `db:"geo_name"`
+	Datetime    string  `db:"datetime"`
+	Value       float64 `db:"value"`
+}
+
+// IndicatorsRepository is a SQLite domain.IndicatorsRepository implementation.
+type IndicatorsRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewIndicatorsRepository initializes a SQLite-based implementation of domain.IndicatorsRepository.
+func NewIndicatorsRepository(db *sql.DB, dbTimeout time.Duration) *IndicatorsRepository {
+	return &IndicatorsRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Save implements the domain.IndicatorsRepository interface.
+func (r *IndicatorsRepository) Save(ctx context.Context, indicator domain.Indicator) error {
+	logger.DebugContext(ctx, "Saving Indicator into database", "id", indicator.ID().String())
+	indicatorSQL := sqlbuilder.NewStruct(new(indicatorSchema))
+	valueSQL := sqlbuilder.NewStruct(new(indicatorValueSchema))
+
+	insertIndicator := indicatorSQL.InsertInto(indicatorsTableName, indicatorSchema{ID: indicator.ID().String(), Name: indicator.Name()}).
+		SQL("ON CONFLICT (id) DO UPDATE SET name = excluded.name")
+	indicatorQuery, indicatorArgs := sqlbuilder.WithFlavor(insertIndicator, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctxTimeout, nil)
+	if err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Indicator into database")
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctxTimeout, indicatorQuery, indicatorArgs...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Indicator into database")
+	}
+
+	if len(indicator.Values()) > 0 {
+		insertValues := valueSQL.InsertInto(indicatorValuesTableName, mapIndicatorValuesDomainToSchema(indicator)...).
+			SQL("ON CONFLICT (indicator_id, datetime, geo_id) DO UPDATE SET geo_name = excluded.geo_name, value = excluded.value")
+		valuesQuery, valuesArgs := sqlbuilder.WithFlavor(insertValues, sqlbuilder.SQLite).Build()
+
+		if _, err := tx.ExecContext(ctxTimeout, valuesQuery, valuesArgs...); err != nil {
+			return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Indicator values into database")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist Indicator into database")
+	}
+
+	return nil
+}
+
+// Query implements the domain.IndicatorsRepository interface.
+func (r *IndicatorsRepository) Query(ctx context.Context, id domain.IndicatorID, geoID *string, from, to time.Time) (domain.Indicator, error) {
+	logger.DebugContext(ctx, "Querying Indicator from database", "id", id.String(), "geoID", fmt.Sprintf("%v", geoID), "from", from, "to", to)
+	indicatorSQL := sqlbuilder.NewStruct(new(indicatorSchema))
+	valueSQL := sqlbuilder.NewStruct(new(indicatorValueSchema))
+
+	selectIndicator := indicatorSQL.SelectFrom(indicatorsTableName)
+	indicatorQuery, indicatorArgs := sqlbuilder.WithFlavor(selectIndicator.Where(selectIndicator.Equal("id", id.String())), sqlbuilder.SQLite).Build()
+
+	selectValues := valueSQL.SelectFrom(indicatorValuesTableName)
+	selectValues.Where(
+		selectValues.Equal("indicator_id", id.String()),
+		selectValues.GreaterEqualThan("datetime", from.UTC().Format(time.RFC3339)),
+		selectValues.LessThan("datetime", to.UTC().Format(time.RFC3339)),
+	)
+	if geoID != nil {
+		selectValues.Where(selectValues.Equal("geo_id", *geoID))
+	}
+	valuesQuery, valuesArgs := sqlbuilder.WithFlavor(selectValues.OrderBy("datetime", "geo_id"), sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	var dbIndicator indicatorSchema
+	if err := r.db.QueryRowContext(ctxTimeout, indicatorQuery, indicatorArgs...).Scan(indicatorSQL.Addr(&dbIndicator)...); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Indicator{}, errors.NewDomainError(errors.IndicatorNotFound, "Indicator with ID %s not found", id.String())
+		}
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Indicator from database to schema")
+	}
+
+	rows, err := r.db.QueryContext(ctxTimeout, valuesQuery, valuesArgs...)
+	if err != nil {
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying Indicator values from database")
+	}
+	defer rows.Close()
+
+	var dbValues []indicatorValueSchema
+	for rows.Next() {
+		var dbValue indicatorValueSchema
+		if err := rows.Scan(valueSQL.Addr(&dbValue)...); err != nil {
+			return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Indicator value from database to schema")
+		}
+		dbValues = append(dbValues, dbValue)
+	}
+
+	indicator, err := mapIndicatorSchemaToDomain(dbIndicator, dbValues)
+	if err != nil {
+		return domain.Indicator{}, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping Indicator from schema to domain")
+	}
+
+	return indicator, nil
+}
+
+func mapIndicatorValuesDomainToSchema(indicator domain.Indicator) []interface{} {
+	dbValues := make([]interface{}, len(indicator.Values()))
+	for i, v := range indicator.Values() {
+		dbValues[i] = indicatorValueSchema{
+			IndicatorID: indicator.ID().String(),
+			GeoID:       v.GeoID(),
+			GeoName:     v.GeoName(),
+			Datetime:    v.Datetime().UTC().Format(time.RFC3339),
+			Value:       v.Value(),
+		}
+	}
+	return dbValues
+}
+
+func mapIndicatorSchemaToDomain(dbIndicator indicatorSchema, dbValues []indicatorValueSchema) (domain.Indicator, error) {
+	values := make([]domain.IndicatorValueDto, len(dbValues))
+	for i, v := range dbValues {
+		values[i] = domain.IndicatorValueDto{
+			Datetime: v.Datetime,
+			GeoID:    v.GeoID,
+			GeoName:  v.GeoName,
+			Value:    v.Value,
+		}
+	}
+
+	return domain.NewIndicator(domain.IndicatorDto{
+		ID:     dbIndicator.ID,
+		Name:   dbIndicator.Name,
+		Values: values,
+	})
+}
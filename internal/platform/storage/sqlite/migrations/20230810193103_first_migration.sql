@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS zones
+(
+    id           TEXT  PRIMARY KEY, -- THREE UPPERCASE LETTERS
+    external_id  TEXT  UNIQUE NOT NULL,
+    name         TEXT  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS prices
+(
+    id        TEXT  PRIMARY KEY, -- ZONE_ID-YYYY-MM-DD
+    date      DATE  NOT NULL,
+    zone_id   TEXT  NOT NULL REFERENCES zones (id),
+    "values"  TEXT  NOT NULL  -- JSON encoded hourly prices
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS zone_external_id_uniq_index ON zones (external_id);
+
+INSERT INTO zones (id, external_id, name) VALUES
+('PEN', '8741', 'Península'),
+('CAN', '8742', 'Canarias'),
+('BAL', '8743', 'Baleares'),
+('CEU', '8744', 'Ceuta'),
+('MEL', '8745', 'Melilla');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS zone_external_id_uniq_index;
+DROP TABLE IF EXISTS prices;
+DROP TABLE IF EXISTS zones;
+-- +goose StatementEnd
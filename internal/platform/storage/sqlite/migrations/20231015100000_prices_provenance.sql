@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE prices ADD COLUMN provider TEXT NULL; -- provider the prices were fetched from
+ALTER TABLE prices ADD COLUMN fetched_at TEXT NULL; -- RFC3339 timestamp
+ALTER TABLE prices ADD COLUMN source TEXT NULL; -- upstream request used to fetch the prices
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE prices DROP COLUMN source;
+ALTER TABLE prices DROP COLUMN fetched_at;
+ALTER TABLE prices DROP COLUMN provider;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prices_revisions
+(
+    id          INTEGER  PRIMARY KEY AUTOINCREMENT,
+    prices_id   TEXT     NOT NULL REFERENCES prices (id) ON DELETE CASCADE,
+    date        DATE     NOT NULL,
+    zone_id     TEXT     NOT NULL REFERENCES zones (id),
+    "values"    TEXT     NOT NULL, -- JSON encoded hourly prices
+    provider    TEXT     NULL,
+    fetched_at  TEXT     NULL,
+    source      TEXT     NULL,
+    revised_at  TEXT     NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now')) -- when this version was replaced
+);
+
+CREATE INDEX IF NOT EXISTS prices_revisions_prices_id_index ON prices_revisions (prices_id, revised_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS prices_revisions_prices_id_index;
+DROP TABLE IF EXISTS prices_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS indicators
+(
+    id    TEXT  PRIMARY KEY, -- Esios indicator number
+    name  TEXT  NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS indicator_values
+(
+    indicator_id  TEXT  NOT NULL REFERENCES indicators (id) ON DELETE CASCADE,
+    geo_id        TEXT  NOT NULL,
+    geo_name      TEXT  NOT NULL,
+    datetime      TEXT  NOT NULL, -- UTC RFC3339, so it sorts and compares as text
+    value         REAL  NOT NULL,
+    PRIMARY KEY (indicator_id, datetime, geo_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS indicator_values;
+DROP TABLE IF EXISTS indicators;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS households
+(
+    cups     TEXT     PRIMARY KEY, -- supply point code
+    zone_id  CHAR(3)  NOT NULL REFERENCES zones (id)
+);
+
+CREATE TABLE IF NOT EXISTS household_consumption
+(
+    cups      TEXT  NOT NULL REFERENCES households (cups) ON DELETE CASCADE,
+    datetime  TEXT  NOT NULL, -- UTC RFC3339, so it sorts and compares as text
+    kwh       REAL  NOT NULL,
+    PRIMARY KEY (cups, datetime)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS household_consumption;
+DROP TABLE IF EXISTS households;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS forecasts
+(
+    zone_id     TEXT  NOT NULL REFERENCES zones (id),
+    date        TEXT  NOT NULL, -- YYYY-MM-DD
+    model       TEXT  NOT NULL, -- seasonal_naive, weekday_profile or exponential_smoothing
+    created_at  TEXT  NOT NULL, -- UTC RFC3339
+    hours       TEXT  NOT NULL, -- JSON encoded hourly forecast values
+    PRIMARY KEY (zone_id, date, model)
+);
+
+CREATE TABLE IF NOT EXISTS forecast_accuracy
+(
+    zone_id      TEXT     NOT NULL REFERENCES zones (id),
+    date         TEXT     NOT NULL, -- YYYY-MM-DD
+    model        TEXT     NOT NULL,
+    hours        INTEGER  NOT NULL,
+    mae          REAL     NOT NULL,
+    rmse         REAL     NOT NULL,
+    bias         REAL     NOT NULL,
+    recorded_at  TEXT     NOT NULL, -- UTC RFC3339
+    PRIMARY KEY (zone_id, date, model)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS forecast_accuracy;
+DROP TABLE IF EXISTS forecasts;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prices_daily_summaries
+(
+    zone_id     TEXT     NOT NULL REFERENCES zones (id),
+    date        TEXT     NOT NULL, -- YYYY-MM-DD
+    hours       INTEGER  NOT NULL,
+    min_value   REAL     NOT NULL,
+    max_value   REAL     NOT NULL,
+    sum_value   REAL     NOT NULL,
+    peak_hours  INTEGER  NOT NULL, -- from 08:00 to 20:00 of Monday to Friday
+    peak_sum    REAL     NOT NULL,
+    PRIMARY KEY (zone_id, date)
+);
+
+INSERT INTO prices_daily_summaries (zone_id, date, hours, min_value, max_value, sum_value, peak_hours, peak_sum)
+SELECT zone_id, date, COUNT(*), MIN(value), MAX(value), SUM(value),
+       SUM(peak), SUM(CASE WHEN peak THEN value ELSE 0 END)
+FROM (
+    SELECT prices.zone_id, substr(prices.date, 1, 10) AS date, json_extract(price.value, '$.value') AS value,
+           strftime('%w', substr(json_extract(price.value, '$.datetime'), 1, 10)) NOT IN ('0', '6')
+               AND CAST(substr(json_extract(price.value, '$.datetime'), 12, 2) AS INTEGER) BETWEEN 8 AND 19 AS peak
+    FROM prices, json_each(prices."values") AS price
+)
+GROUP BY zone_id, date;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS prices_daily_summaries;
+-- +goose StatementEnd
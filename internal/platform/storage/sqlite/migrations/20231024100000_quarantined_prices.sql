@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS quarantined_prices
+(
+    type         TEXT  NOT NULL, -- pvpc or surplus
+    id           TEXT  NOT NULL,
+    date         DATE  NOT NULL,
+    zone_id      TEXT  NOT NULL REFERENCES zones (id),
+    "values"     TEXT  NOT NULL, -- JSON encoded hourly prices
+    provider     TEXT  NULL,
+    fetched_at   TEXT  NULL,
+    source       TEXT  NULL,
+    anomalies    TEXT  NOT NULL, -- JSON encoded failed checks
+    status       TEXT  NOT NULL, -- pending, approved or rejected
+    detected_at  TEXT  NOT NULL, -- UTC RFC3339
+    reviewed_at  TEXT  NULL,     -- UTC RFC3339
+    PRIMARY KEY (type, id)
+);
+
+CREATE INDEX IF NOT EXISTS quarantined_prices_status_index ON quarantined_prices (status, detected_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS quarantined_prices_status_index;
+DROP TABLE IF EXISTS quarantined_prices;
+-- +goose StatementEnd
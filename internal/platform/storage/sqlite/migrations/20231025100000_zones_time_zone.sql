@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE zones ADD COLUMN time_zone TEXT NOT NULL DEFAULT 'Europe/Madrid'; -- IANA time zone of the zone local time
+
+UPDATE zones SET time_zone = 'Atlantic/Canary' WHERE id = 'CAN';
+
+-- peak hours are the ones of each zone's local time. SQLite doesn't know IANA time zones, so local datetimes
+-- are computed from the UTC ones with the UTC offsets of both zones' time zones, which follow the EU summer time:
+-- from 01:00 UTC of the last Sunday of March to 01:00 UTC of the last Sunday of October.
+UPDATE prices_daily_summaries
+SET (peak_hours, peak_sum) = (
+    SELECT COALESCE(SUM(peak), 0), COALESCE(SUM(CASE WHEN peak THEN value ELSE 0 END), 0)
+    FROM (
+        SELECT value, strftime('%w', local_datetime) NOT IN ('0', '6')
+                   AND CAST(strftime('%H', local_datetime) AS INTEGER) BETWEEN 8 AND 19 AS peak
+        FROM (
+            SELECT value, datetime(utc_datetime, (standard_offset
+                       + (utc_datetime >= date(strftime('%Y', utc_datetime) || '-03-31', '-6 days', 'weekday 0') || ' 01:00:00'
+                           AND utc_datetime < date(strftime('%Y', utc_datetime) || '-10-31', '-6 days', 'weekday 0') || ' 01:00:00')
+                   ) || ' hours') AS local_datetime
+            FROM (
+                SELECT json_extract(price.value, '$.value') AS value,
+                       datetime(json_extract(price.value, '$.datetime')) AS utc_datetime,
+                       CASE zones.time_zone WHEN 'Atlantic/Canary' THEN 0 ELSE 1 END AS standard_offset
+                FROM prices JOIN zones ON zones.id = prices.zone_id, json_each(prices."values") AS price
+                WHERE prices.zone_id = prices_daily_summaries.zone_id AND substr(prices.date, 1, 10) = prices_daily_summaries.date
+            )
+        )
+    )
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE zones DROP COLUMN time_zone;
+-- +goose StatementEnd
@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const quarantineTableName = "quarantined_prices"
+
+type quarantinedPricesSchema struct {
+	Type         string                 `db:"type"`
+	ID           string                 `db:"id"`
+	Date         string                 `db:"date"`
+	ZoneID       string                 `db:"zone_id"`
+	HourlyPrices hourlyPriceSchemaSlice `db:"values" fieldopt:"withquote"`
+	Provider     sql.NullString         `db:"provider"`
+	FetchedAt    sql.NullString         `db:"fetched_at"`
+	Source       sql.NullString         `db:"source"`
+	Anomalies    anomalySchemaSlice     `db:"anomalies"`
+	Status       string                 `db:"status"`
+	DetectedAt   string                 `db:"detected_at"`
+	ReviewedAt   sql.NullString         `db:"reviewed_at"`
+}
+
+type anomalySchemaSlice []anomalySchema
+
+type anomalySchema struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail"`
+}
+
+// Make the anomalySchemaSlice type implement the driver.Value interface.
+// This method simply returns the JSON-encoded representation of the struct.
+func (as anomalySchemaSlice) Value() (driver.Value, error) {
+	return json.Marshal(as)
+}
+
+// Make the anomalySchemaSlice type implement the sql.Scanner interface.
+// This method simply decodes a JSON-encoded value into the struct fields.
+// SQLite may return the column either as TEXT or as BLOB, so both are accepted.
+func (as *anomalySchemaSlice) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, &as)
+	case string:
+		return json.Unmarshal([]byte(v), &as)
+	default:
+		return errors.NewDomainError(errors.PersistenceError, "sql.Scanner Scan() custom implementation: type assertion to []byte or string failed")
+	}
+}
+
+// QuarantineRepository is a SQLite domain.QuarantineRepository implementation.
+type QuarantineRepository struct {
+	db        *sql.DB
+	dbTimeout time.Duration
+}
+
+// NewQuarantineRepository initializes a SQLite-based implementation of domain.QuarantineRepository.
+func NewQuarantineRepository(db *sql.DB, dbTimeout time.Duration) *QuarantineRepository {
+	return &QuarantineRepository{
+		db:        db,
+		dbTimeout: dbTimeout,
+	}
+}
+
+// Save implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) Save(ctx context.Context, quarantined domain.QuarantinedPrices) error {
+	prices := quarantined.Prices()
+	logger.DebugContext(ctx, "Saving QuarantinedPrices into database", "id", prices.ID().String(), "type", prices.Type().String(), "status", quarantined.Status().String())
+	quarantineSQL := sqlbuilder.NewStruct(new(quarantinedPricesSchema))
+
+	dbPrices := mapPricesDomainToSchema([]domain.Prices{prices})[0].(pricesSchema)
+	anomalies := make(anomalySchemaSlice, len(quarantined.Anomalies()))
+	for i, a := range quarantined.Anomalies() {
+		anomalies[i] = anomalySchema{Kind: a.Kind().String(), Detail: a.Detail()}
+	}
+	dbQuarantined := quarantinedPricesSchema{
+		Type:         prices.Type().String(),
+		ID:           dbPrices.ID,
+		Date:         dbPrices.Date,
+		ZoneID:       dbPrices.ZoneID,
+		HourlyPrices: dbPrices.HourlyPrices,
+		Provider:     dbPrices.Provider,
+		FetchedAt:    dbPrices.FetchedAt,
+		Source:       dbPrices.Source,
+		Anomalies:    anomalies,
+		Status:       quarantined.Status().String(),
+		DetectedAt:   quarantined.DetectedAt().UTC().Format(time.RFC3339),
+	}
+	if reviewedAt := quarantined.ReviewedAt(); reviewedAt != nil {
+		dbQuarantined.ReviewedAt = sql.NullString{String: reviewedAt.UTC().Format(time.RFC3339), Valid: true}
+	}
+
+	insert := quarantineSQL.InsertInto(quarantineTableName, dbQuarantined).
+		SQL(`ON CONFLICT (type, id) DO UPDATE SET date = excluded.date, zone_id = excluded.zone_id, "values" = excluded."values",
+			provider = excluded.provider, fetched_at = excluded.fetched_at, source = excluded.source, anomalies = excluded.anomalies,
+			status = excluded.status, detected_at = excluded.detected_at, reviewed_at = excluded.reviewed_at`)
+	query, args := sqlbuilder.WithFlavor(insert, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	if _, err := r.db.ExecContext(ctxTimeout, query, args...); err != nil {
+		return errors.WrapIntoDomainError(err, errors.PersistenceError, "error trying to persist QuarantinedPrices into database")
+	}
+
+	return nil
+}
+
+// Get implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) Get(ctx context.Context, pricesType domain.PricesType, id domain.PricesID) (domain.QuarantinedPrices, error) {
+	logger.DebugContext(ctx, "Getting QuarantinedPrices from database", "id", id.String(), "type", pricesType.String())
+	query := selectQuarantinedPrices()
+	query.Where(query.Equal(quarantineTableName+".type", pricesType.String()), query.Equal(quarantineTableName+".id", id.String()))
+
+	quarantined, err := r.query(ctx, query)
+	if err != nil {
+		return domain.QuarantinedPrices{}, err
+	}
+	if len(quarantined) == 0 {
+		return domain.QuarantinedPrices{}, errors.NewDomainError(errors.QuarantineNotFound, "quarantined %s prices not found: %s", pricesType.String(), id.String())
+	}
+
+	return quarantined[0], nil
+}
+
+// List implements the domain.QuarantineRepository interface.
+func (r *QuarantineRepository) List(ctx context.Context, status *domain.QuarantineStatus) ([]domain.QuarantinedPrices, error) {
+	logger.DebugContext(ctx, "Listing QuarantinedPrices from database", "status", fmt.Sprintf("%v", status))
+	query := selectQuarantinedPrices()
+	if status != nil {
+		query.Where(query.Equal(quarantineTableName+".status", status.String()))
+	}
+	query.OrderBy(quarantineTableName+".detected_at DESC", quarantineTableName+".type", quarantineTableName+".id")
+
+	return r.query(ctx, query)
+}
+
+func selectQuarantinedPrices() *sqlbuilder.SelectBuilder {
+	t := quarantineTableName
+	return sqlbuilder.NewSelectBuilder().
+		Select(t+".type", t+".id", t+".date", t+".zone_id", t+`."values"`, t+".provider", t+".fetched_at", t+".source",
+			t+".anomalies", t+".status", t+".detected_at", t+".reviewed_at", "zones.external_id", "zones.name", "zones.time_zone").
+		From(t).Join(zonesTableName, t+".zone_id = zones.id")
+}
+
+func (r *QuarantineRepository) query(ctx context.Context, query *sqlbuilder.SelectBuilder) ([]domain.QuarantinedPrices, error) {
+	quarantineSQL := sqlbuilder.NewStruct(new(quarantinedPricesSchema))
+	querySQL, args := sqlbuilder.WithFlavor(query, sqlbuilder.SQLite).Build()
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, r.dbTimeout)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctxTimeout, querySQL, args...)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error querying QuarantinedPrices from database")
+	}
+	defer rows.Close()
+
+	quarantined := make([]domain.QuarantinedPrices, 0)
+	for rows.Next() {
+		var dbQuarantined quarantinedPricesSchema
+		var zone zoneSchema
+		fields := append(quarantineSQL.Addr(&dbQuarantined), &zone.ExternalID, &zone.Name, &zone.TimeZone)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping QuarantinedPrices from database to schema")
+		}
+
+		pricesDto := mapPricesSchemaToDto(pricesSchema{
+			ID:           dbQuarantined.ID,
+			Date:         dbQuarantined.Date,
+			ZoneID:       dbQuarantined.ZoneID,
+			HourlyPrices: dbQuarantined.HourlyPrices,
+			Provider:     dbQuarantined.Provider,
+			FetchedAt:    dbQuarantined.FetchedAt,
+			Source:       dbQuarantined.Source,
+		}, zone)
+		pricesDto.Type = dbQuarantined.Type
+		anomalies := make([]domain.PricesAnomalyDto, len(dbQuarantined.Anomalies))
+		for i, a := range dbQuarantined.Anomalies {
+			anomalies[i] = domain.PricesAnomalyDto{Kind: a.Kind, Detail: a.Detail}
+		}
+
+		q, err := domain.NewQuarantinedPrices(domain.QuarantinedPricesDto{
+			Prices:     pricesDto,
+			Anomalies:  anomalies,
+			Status:     dbQuarantined.Status,
+			DetectedAt: dbQuarantined.DetectedAt,
+			ReviewedAt: dbQuarantined.ReviewedAt.String,
+		})
+		if err != nil {
+			return nil, errors.WrapIntoDomainError(err, errors.PersistenceError, "error mapping QuarantinedPrices from schema to domain")
+		}
+		quarantined = append(quarantined, q)
+	}
+
+	return quarantined, nil
+}
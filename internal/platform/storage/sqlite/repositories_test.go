@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/platform/storage/storagetest"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "pvpc.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	require.NoError(t, goose.SetDialect("sqlite3"))
+	goose.SetLogger(goose.NopLogger())
+	require.NoError(t, goose.Up(db, "migrations"))
+
+	return db
+}
+
+func newTestRepositories(t *testing.T) (domain.PricesRepository, domain.ZonesRepository) {
+	t.Helper()
+	db := newTestDB(t)
+	return NewPricesRepository(db, 1*time.Second), NewZonesRepository(db, 1*time.Second)
+}
+
+func Test_PricesRepository_Suite(t *testing.T) {
+	storagetest.RunPricesRepositoryTests(t, newTestRepositories)
+}
+
+func Test_ZonesRepository_Suite(t *testing.T) {
+	storagetest.RunZonesRepositoryTests(t, newTestRepositories)
+}
+
+func Test_IndicatorsRepository_Suite(t *testing.T) {
+	storagetest.RunIndicatorsRepositoryTests(t, func(t *testing.T) domain.IndicatorsRepository {
+		return NewIndicatorsRepository(newTestDB(t), 1*time.Second)
+	})
+}
+
+func Test_SpotPricesRepository_Suite(t *testing.T) {
+	storagetest.RunSpotPricesRepositoryTests(t, func(t *testing.T) (domain.SpotPricesRepository, domain.PricesRepository) {
+		db := newTestDB(t)
+		return NewSpotPricesRepository(db, 1*time.Second), NewPricesRepository(db, 1*time.Second)
+	})
+}
+
+func Test_HolidaysRepository_Suite(t *testing.T) {
+	storagetest.RunHolidaysRepositoryTests(t, func(t *testing.T) domain.HolidaysRepository {
+		return NewHolidaysRepository(newTestDB(t), 1*time.Second)
+	})
+}
+
+func Test_HouseholdsRepository_Suite(t *testing.T) {
+	storagetest.RunHouseholdsRepositoryTests(t, func(t *testing.T) domain.HouseholdsRepository {
+		return NewHouseholdsRepository(newTestDB(t), 1*time.Second)
+	})
+}
+
+func Test_ForecastsRepository_Suite(t *testing.T) {
+	storagetest.RunForecastsRepositoryTests(t, func(t *testing.T) domain.ForecastsRepository {
+		return NewForecastsRepository(newTestDB(t), 1*time.Second)
+	})
+}
+
+func Test_QuarantineRepository_Suite(t *testing.T) {
+	storagetest.RunQuarantineRepositoryTests(t, func(t *testing.T) domain.QuarantineRepository {
+		return NewQuarantineRepository(newTestDB(t), 1*time.Second)
+	})
+}
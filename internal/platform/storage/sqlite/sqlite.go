@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// DriverName is the database/sql driver name registered by the embedded SQLite driver.
+const DriverName = "sqlite"
+
+// Open opens the SQLite database stored at the given path, creating it if it does not exist.
+//
+// Foreign keys are enforced and the pool is limited to a single connection, as SQLite
+// serializes writes anyway and concurrent writers would otherwise fail with SQLITE_BUSY.
+func Open(path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path)
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
@@ -0,0 +1,122 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+)
+
+// ForecastsRepositoryFactory returns a fresh forecasts repository backed by a storage
+// where only the default zones are present. It is called once per test case.
+type ForecastsRepositoryFactory func(t *testing.T) domain.ForecastsRepository
+
+// RunForecastsRepositoryTests runs the domain.ForecastsRepository test suite against the
+// repositories returned by newRepository.
+func RunForecastsRepositoryTests(t *testing.T, newRepository ForecastsRepositoryFactory) {
+	day := time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)
+	zoneID, err := domain.NewZoneID("PEN")
+	require.NoError(t, err)
+
+	t.Run("query of a day without forecasts returns an empty list", func(t *testing.T) {
+		repository := newRepository(t)
+
+		forecasts, err := repository.Query(context.Background(), zoneID, day)
+		require.NoError(t, err)
+		require.Empty(t, forecasts)
+
+		accuracies, err := repository.ListAccuracy(context.Background(), zoneID, day, day)
+		require.NoError(t, err)
+		require.Empty(t, accuracies)
+	})
+
+	t.Run("saved forecasts are returned sorted by model with UTC datetimes", func(t *testing.T) {
+		repository := newRepository(t)
+		smoothing := newTestForecast(t, "PEN", "2023-10-12", "exponential_smoothing", 100)
+		naive := newTestForecast(t, "PEN", "2023-10-12", "seasonal_naive", 90)
+		require.NoError(t, repository.Save(context.Background(), naive))
+		require.NoError(t, repository.Save(context.Background(), smoothing))
+		require.NoError(t, repository.Save(context.Background(), newTestForecast(t, "PEN", "2023-10-13", "seasonal_naive", 80)))
+		require.NoError(t, repository.Save(context.Background(), newTestForecast(t, "BAL", "2023-10-12", "seasonal_naive", 70)))
+
+		forecasts, err := repository.Query(context.Background(), zoneID, day)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ForecastDto{smoothing.Serialize(), naive.Serialize()}, serializeForecasts(forecasts...))
+	})
+
+	t.Run("saving a forecast again replaces the stored one", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), newTestForecast(t, "PEN", "2023-10-12", "seasonal_naive", 90)))
+		replacement := newTestForecast(t, "PEN", "2023-10-12", "seasonal_naive", 95)
+
+		require.NoError(t, repository.Save(context.Background(), replacement))
+
+		forecasts, err := repository.Query(context.Background(), zoneID, day)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ForecastDto{replacement.Serialize()}, serializeForecasts(forecasts...))
+	})
+
+	t.Run("saving a forecast of an unknown zone fails", func(t *testing.T) {
+		repository := newRepository(t)
+
+		require.Error(t, repository.Save(context.Background(), newTestForecast(t, "XXX", "2023-10-12", "seasonal_naive", 90)))
+	})
+
+	t.Run("saved accuracies are listed in the range sorted by day and model, replacing the previous ones", func(t *testing.T) {
+		repository := newRepository(t)
+		for _, dto := range []domain.ForecastAccuracyDto{
+			{ZoneID: "PEN", Date: "2023-10-13", Model: "seasonal_naive", Hours: 24, MAE: 1, RMSE: 1, Bias: 1, RecordedAt: "2023-10-12T20:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-12", Model: "seasonal_naive", Hours: 24, MAE: 9, RMSE: 9, Bias: 9, RecordedAt: "2023-10-11T20:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-12", Model: "seasonal_naive", Hours: 24, MAE: 2, RMSE: 3, Bias: -1, RecordedAt: "2023-10-11T21:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-12", Model: "exponential_smoothing", Hours: 23, MAE: 4, RMSE: 5, Bias: 0.5, RecordedAt: "2023-10-11T20:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-14", Model: "seasonal_naive", Hours: 24, MAE: 1, RMSE: 1, Bias: 1, RecordedAt: "2023-10-13T20:30:00Z"},
+			{ZoneID: "BAL", Date: "2023-10-12", Model: "seasonal_naive", Hours: 24, MAE: 1, RMSE: 1, Bias: 1, RecordedAt: "2023-10-11T20:30:00Z"},
+		} {
+			accuracy, err := domain.NewForecastAccuracy(dto)
+			require.NoError(t, err)
+			require.NoError(t, repository.SaveAccuracy(context.Background(), accuracy))
+		}
+
+		accuracies, err := repository.ListAccuracy(context.Background(), zoneID, day, day.AddDate(0, 0, 1))
+		require.NoError(t, err)
+
+		dtos := make([]domain.ForecastAccuracyDto, len(accuracies))
+		for i, accuracy := range accuracies {
+			dtos[i] = accuracy.Serialize()
+		}
+		require.Equal(t, []domain.ForecastAccuracyDto{
+			{ZoneID: "PEN", Date: "2023-10-12", Model: "exponential_smoothing", Hours: 23, MAE: 4, RMSE: 5, Bias: 0.5, RecordedAt: "2023-10-11T20:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-12", Model: "seasonal_naive", Hours: 24, MAE: 2, RMSE: 3, Bias: -1, RecordedAt: "2023-10-11T21:30:00Z"},
+			{ZoneID: "PEN", Date: "2023-10-13", Model: "seasonal_naive", Hours: 24, MAE: 1, RMSE: 1, Bias: 1, RecordedAt: "2023-10-12T20:30:00Z"},
+		}, dtos)
+	})
+}
+
+// newTestForecast returns a forecast of the first two hours of date with the given value
+// and a band of ±10 around it.
+func newTestForecast(t *testing.T, zoneID, date, model string, value float64) domain.Forecast {
+	t.Helper()
+	forecast, err := domain.NewForecast(domain.ForecastDto{
+		ZoneID:    zoneID,
+		Date:      date,
+		Model:     model,
+		CreatedAt: "2023-10-11T08:00:00Z",
+		Values: []domain.ForecastValueDto{
+			{Datetime: date + "T00:00:00Z", Value: value, Lower: value - 10, Upper: value + 10},
+			{Datetime: date + "T01:00:00Z", Value: value + 1, Lower: value - 9, Upper: value + 11},
+		},
+	})
+	require.NoError(t, err)
+	return forecast
+}
+
+func serializeForecasts(forecasts ...domain.Forecast) []domain.ForecastDto {
+	dtos := make([]domain.ForecastDto, len(forecasts))
+	for i, forecast := range forecasts {
+		dtos[i] = forecast.Serialize()
+	}
+	return dtos
+}
@@ -0,0 +1,80 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// HolidaysRepositoryFactory returns a fresh holidays repository backed by an empty storage.
+// It is called once per test case.
+type HolidaysRepositoryFactory func(t *testing.T) domain.HolidaysRepository
+
+// RunHolidaysRepositoryTests runs the domain.HolidaysRepository test suite against the
+// repositories returned by newRepository.
+func RunHolidaysRepositoryTests(t *testing.T, newRepository HolidaysRepositoryFactory) {
+	t.Run("saved holidays are returned sorted by date", func(t *testing.T) {
+		repository := newRepository(t)
+		christmas := newTestHoliday(t, "2023-12-25", "Natividad del Señor")
+		newYear := newTestHoliday(t, "2023-01-01", "Año Nuevo")
+
+		require.NoError(t, repository.Save(context.Background(), []domain.Holiday{christmas, newYear}))
+
+		result, err := repository.GetAll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, serializeHolidays(newYear, christmas), serializeHolidays(result...))
+	})
+
+	t.Run("saving a holiday again replaces the stored one for the same day", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), []domain.Holiday{newTestHoliday(t, "2023-08-15", "Asunción")}))
+		renamed := newTestHoliday(t, "2023-08-15", "Asunción de la Virgen")
+
+		require.NoError(t, repository.Save(context.Background(), []domain.Holiday{renamed}))
+
+		result, err := repository.GetAll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, serializeHolidays(renamed), serializeHolidays(result...))
+	})
+
+	t.Run("deleted holidays are not returned", func(t *testing.T) {
+		repository := newRepository(t)
+		assumption := newTestHoliday(t, "2023-08-15", "Asunción")
+		hispanicDay := newTestHoliday(t, "2023-10-12", "Fiesta Nacional de España")
+		require.NoError(t, repository.Save(context.Background(), []domain.Holiday{assumption, hispanicDay}))
+
+		require.NoError(t, repository.Delete(context.Background(), assumption.Date()))
+
+		result, err := repository.GetAll(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, serializeHolidays(hispanicDay), serializeHolidays(result...))
+	})
+
+	t.Run("deleting a day without holiday returns a not found error", func(t *testing.T) {
+		repository := newRepository(t)
+
+		err := repository.Delete(context.Background(), time.Date(2023, 8, 16, 0, 0, 0, 0, time.UTC))
+		require.Error(t, err)
+		require.Equal(t, errors.HolidayNotFound, errors.Code(err))
+	})
+}
+
+func newTestHoliday(t *testing.T, date, name string) domain.Holiday {
+	t.Helper()
+	holiday, err := domain.NewHoliday(domain.HolidayDto{Date: date, Name: name})
+	require.NoError(t, err)
+	return holiday
+}
+
+func serializeHolidays(holidays ...domain.Holiday) []domain.HolidayDto {
+	dtos := make([]domain.HolidayDto, len(holidays))
+	for i, holiday := range holidays {
+		dtos[i] = holiday.Serialize()
+	}
+	return dtos
+}
@@ -0,0 +1,129 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// HouseholdsRepositoryFactory returns a fresh households repository backed by a storage
+// where only the default zones are present. It is called once per test case.
+type HouseholdsRepositoryFactory func(t *testing.T) domain.HouseholdsRepository
+
+// RunHouseholdsRepositoryTests runs the domain.HouseholdsRepository test suite against the
+// repositories returned by newRepository.
+func RunHouseholdsRepositoryTests(t *testing.T, newRepository HouseholdsRepositoryFactory) {
+	day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+	cups := "ES0021000000000001AA"
+
+	t.Run("get of an unknown household returns a not found error", func(t *testing.T) {
+		repository := newRepository(t)
+
+		_, err := repository.Get(context.Background(), newTestHousehold(t, cups, "PEN").CUPS())
+		require.Equal(t, errors.HouseholdNotFound, errors.Code(err))
+
+		_, err = repository.QueryConsumption(context.Background(), newTestHousehold(t, cups, "PEN").CUPS(), day, nextDay)
+		require.Equal(t, errors.HouseholdNotFound, errors.Code(err))
+	})
+
+	t.Run("saved consumption is returned sorted by datetime and in UTC", func(t *testing.T) {
+		repository := newRepository(t)
+		household := newTestHousehold(t, cups, "PEN")
+
+		require.NoError(t, repository.Save(context.Background(), household, newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T03:00:00+02:00", Kwh: 0.2},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T02:00:00+02:00", Kwh: 0.1},
+		)))
+
+		stored, err := repository.Get(context.Background(), household.CUPS())
+		require.NoError(t, err)
+		require.Equal(t, household.Serialize(), stored.Serialize())
+
+		result, err := repository.QueryConsumption(context.Background(), household.CUPS(), day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, []domain.HourlyConsumptionDto{
+			{Datetime: "2023-10-11T00:00:00Z", Kwh: 0.1},
+			{Datetime: "2023-10-11T01:00:00Z", Kwh: 0.2},
+		}, serializeConsumption(result...))
+	})
+
+	t.Run("saving again replaces the zone and the consumption of the same hours", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), newTestHousehold(t, cups, "PEN"), newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T00:00:00Z", Kwh: 0.1},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T01:00:00Z", Kwh: 0.2},
+		)))
+		moved := newTestHousehold(t, cups, "BAL")
+
+		require.NoError(t, repository.Save(context.Background(), moved, newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T01:00:00Z", Kwh: 0.3},
+		)))
+
+		stored, err := repository.Get(context.Background(), moved.CUPS())
+		require.NoError(t, err)
+		require.Equal(t, moved.Serialize(), stored.Serialize())
+
+		result, err := repository.QueryConsumption(context.Background(), moved.CUPS(), day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, []domain.HourlyConsumptionDto{
+			{Datetime: "2023-10-11T00:00:00Z", Kwh: 0.1},
+			{Datetime: "2023-10-11T01:00:00Z", Kwh: 0.3},
+		}, serializeConsumption(result...))
+	})
+
+	t.Run("query returns only the consumption in the range", func(t *testing.T) {
+		repository := newRepository(t)
+		household := newTestHousehold(t, cups, "PEN")
+		require.NoError(t, repository.Save(context.Background(), household, newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-10T23:00:00Z", Kwh: 1},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T00:00:00Z", Kwh: 2},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T23:00:00Z", Kwh: 3},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-12T00:00:00Z", Kwh: 4},
+		)))
+
+		result, err := repository.QueryConsumption(context.Background(), household.CUPS(), day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, []domain.HourlyConsumptionDto{
+			{Datetime: "2023-10-11T00:00:00Z", Kwh: 2},
+			{Datetime: "2023-10-11T23:00:00Z", Kwh: 3},
+		}, serializeConsumption(result...))
+	})
+
+	t.Run("saving a household of an unknown zone fails", func(t *testing.T) {
+		repository := newRepository(t)
+
+		require.Error(t, repository.Save(context.Background(), newTestHousehold(t, cups, "XXX"), nil))
+	})
+}
+
+func newTestHousehold(t *testing.T, cups, zoneID string) domain.Household {
+	t.Helper()
+	household, err := domain.NewHousehold(domain.HouseholdDto{CUPS: cups, ZoneID: zoneID})
+	require.NoError(t, err)
+	return household
+}
+
+func newTestConsumption(t *testing.T, dtos ...domain.HourlyConsumptionDto) []domain.HourlyConsumption {
+	t.Helper()
+	consumption := make([]domain.HourlyConsumption, len(dtos))
+	for i, dto := range dtos {
+		c, err := domain.NewHourlyConsumption(dto)
+		require.NoError(t, err)
+		consumption[i] = c
+	}
+	return consumption
+}
+
+func serializeConsumption(consumption ...domain.HourlyConsumption) []domain.HourlyConsumptionDto {
+	dtos := make([]domain.HourlyConsumptionDto, len(consumption))
+	for i, c := range consumption {
+		dtos[i] = c.Serialize()
+	}
+	return dtos
+}
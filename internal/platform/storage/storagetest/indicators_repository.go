@@ -0,0 +1,132 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// IndicatorsRepositoryFactory returns a fresh indicators repository backed by an empty storage.
+// It is called once per test case.
+type IndicatorsRepositoryFactory func(t *testing.T) domain.IndicatorsRepository
+
+// RunIndicatorsRepositoryTests runs the domain.IndicatorsRepository test suite against the
+// repositories returned by newRepository.
+func RunIndicatorsRepositoryTests(t *testing.T, newRepository IndicatorsRepositoryFactory) {
+	day := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+	nextDay := day.AddDate(0, 0, 1)
+
+	t.Run("query of an unknown indicator returns a not found error", func(t *testing.T) {
+		repository := newRepository(t)
+
+		_, err := repository.Query(context.Background(), newTestIndicatorID(t, "600"), nil, day, nextDay)
+		require.Error(t, err)
+		require.Equal(t, errors.IndicatorNotFound, errors.Code(err))
+	})
+
+	t.Run("saved values are returned sorted by datetime and geo", func(t *testing.T) {
+		repository := newRepository(t)
+		indicator := newTestIndicator(t, "600", "Spot", []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T01:00:00Z", GeoID: "3", GeoName: "España", Value: 101.3},
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 106.45},
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "1", GeoName: "Portugal", Value: 106.45},
+		})
+
+		require.NoError(t, repository.Save(context.Background(), indicator))
+
+		result, err := repository.Query(context.Background(), indicator.ID(), nil, day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, domain.IndicatorDto{ID: "600", Name: "Spot", Values: []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "1", GeoName: "Portugal", Value: 106.45},
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 106.45},
+			{Datetime: "2023-09-08T01:00:00Z", GeoID: "3", GeoName: "España", Value: 101.3},
+		}}, serializeIndicator(result))
+	})
+
+	t.Run("saving values again replaces the stored ones for the same datetime and geo", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "600", "Spot", []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 100},
+			{Datetime: "2023-09-08T01:00:00Z", GeoID: "3", GeoName: "España", Value: 110},
+		})))
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "600", "Spot price", []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T01:00:00+01:00", GeoID: "3", GeoName: "España", Value: 105},
+		})))
+
+		result, err := repository.Query(context.Background(), newTestIndicatorID(t, "600"), nil, day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, domain.IndicatorDto{ID: "600", Name: "Spot price", Values: []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 105},
+			{Datetime: "2023-09-08T01:00:00Z", GeoID: "3", GeoName: "España", Value: 110},
+		}}, serializeIndicator(result))
+	})
+
+	t.Run("query returns only the values in the range and geo", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "600", "Spot", []domain.IndicatorValueDto{
+			{Datetime: "2023-09-07T23:00:00Z", GeoID: "3", GeoName: "España", Value: 1},
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 2},
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "1", GeoName: "Portugal", Value: 3},
+			{Datetime: "2023-09-08T23:00:00Z", GeoID: "3", GeoName: "España", Value: 4},
+			{Datetime: "2023-09-09T00:00:00Z", GeoID: "3", GeoName: "España", Value: 5},
+		})))
+
+		geoID := "3"
+		result, err := repository.Query(context.Background(), newTestIndicatorID(t, "600"), &geoID, day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, []domain.IndicatorValueDto{
+			{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 2},
+			{Datetime: "2023-09-08T23:00:00Z", GeoID: "3", GeoName: "España", Value: 4},
+		}, serializeIndicator(result).Values)
+	})
+
+	t.Run("query of a stored indicator without values in the range returns no values", func(t *testing.T) {
+		repository := newRepository(t)
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "1293", "Demanda real", nil)))
+
+		result, err := repository.Query(context.Background(), newTestIndicatorID(t, "1293"), nil, day, nextDay)
+		require.NoError(t, err)
+		require.Equal(t, "Demanda real", result.Name())
+		require.Empty(t, result.Values())
+	})
+
+	t.Run("indicators are stored independently", func(t *testing.T) {
+		repository := newRepository(t)
+		value := []domain.IndicatorValueDto{{Datetime: "2023-09-08T00:00:00Z", GeoID: "3", GeoName: "España", Value: 1}}
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "600", "Spot", value)))
+		require.NoError(t, repository.Save(context.Background(), newTestIndicator(t, "1293", "Demanda real", nil)))
+
+		result, err := repository.Query(context.Background(), newTestIndicatorID(t, "1293"), nil, day, nextDay)
+		require.NoError(t, err)
+		require.Empty(t, result.Values())
+	})
+}
+
+func newTestIndicatorID(t *testing.T, id string) domain.IndicatorID {
+	t.Helper()
+	indicatorID, err := domain.NewIndicatorID(id)
+	require.NoError(t, err)
+	return indicatorID
+}
+
+func newTestIndicator(t *testing.T, id, name string, values []domain.IndicatorValueDto) domain.Indicator {
+	t.Helper()
+	indicator, err := domain.NewIndicator(domain.IndicatorDto{ID: id, Name: name, Values: values})
+	require.NoError(t, err)
+	return indicator
+}
+
+// serializeIndicator serializes the indicator with its datetimes in UTC, as every storage may
+// return them in a different location.
+func serializeIndicator(indicator domain.Indicator) domain.IndicatorDto {
+	dto := indicator.Serialize()
+	for i, v := range indicator.Values() {
+		dto.Values[i].Datetime = v.Datetime().UTC().Format(time.RFC3339)
+	}
+	return dto
+}
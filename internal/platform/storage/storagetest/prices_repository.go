@@ -0,0 +1,481 @@
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// RunPricesRepositoryTests runs the domain.PricesRepository test suite against the
+// repositories returned by newRepositories.
+func RunPricesRepositoryTests(t *testing.T, newRepositories RepositoriesFactory) {
+	pen, can := SeededZones[0], SeededZones[1]
+
+	t.Run("query on an empty storage returns no prices", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+
+		result, err := pricesRepository.Query(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("saved prices are returned when querying by zone ID and date", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		prices := NewTestPrices(t, pen, "2023-08-10")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+		zoneID, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), &zoneID, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(prices), serialize(result...))
+	})
+
+	t.Run("saved prices keep their provenance", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		dto := NewTestPrices(t, pen, "2023-08-10").Serialize()
+		dto.Date += "T00:00:00Z"
+		dto.Provenance = &domain.ProvenanceDto{Provider: "esios", FetchedAt: "2023-08-09T20:30:00Z", Source: "/indicators/1001?geo_ids[]=8741"}
+		withProvenance, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+		withoutProvenance := NewTestPrices(t, can, "2023-08-10")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{withProvenance, withoutProvenance}))
+
+		_, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), nil, &date)
+		require.NoError(t, err)
+		require.ElementsMatch(t, serialize(withProvenance, withoutProvenance), serialize(result...))
+	})
+
+	t.Run("saved prices keep every digit of their values", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		dto := NewTestPrices(t, pen, "2023-08-10").Serialize()
+		dto.Date += "T00:00:00Z"
+		dto.Values[0].Amount = "123.456789012345678"
+		dto.Values[1].Amount = "-0.000000000000000001"
+		prices, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+		zoneID, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), &zoneID, &date)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "123.456789012345678", result[0].Values()[0].Price().Amount().String())
+		require.Equal(t, serialize(prices), serialize(result...))
+	})
+
+	t.Run("query by date returns the prices of every zone for that date", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		canDay1 := NewTestPrices(t, can, "2023-08-10")
+		penDay2 := NewTestPrices(t, pen, "2023-08-11")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay1, canDay1, penDay2}))
+
+		_, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), nil, &date)
+		require.NoError(t, err)
+		require.ElementsMatch(t, serialize(penDay1, canDay1), serialize(result...))
+	})
+
+	t.Run("query without date returns the latest prices of every zone", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		penDay2 := NewTestPrices(t, pen, "2023-08-11")
+		canDay1 := NewTestPrices(t, can, "2023-08-10")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay1, penDay2, canDay1}))
+
+		result, err := pricesRepository.Query(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.ElementsMatch(t, serialize(penDay2, canDay1), serialize(result...))
+	})
+
+	t.Run("query by zone ID without date returns the latest prices of that zone", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		penDay2 := NewTestPrices(t, pen, "2023-08-11")
+		canDay3 := NewTestPrices(t, can, "2023-08-12")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay2, penDay1, canDay3}))
+
+		zoneID, _ := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), &zoneID, nil)
+		require.NoError(t, err)
+		require.Equal(t, serialize(penDay2), serialize(result...))
+	})
+
+	t.Run("query range returns the PVPC prices of the zone within the days, from the oldest one", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		penDay2 := NewTestPrices(t, pen, "2023-08-11")
+		penDay4 := NewTestPrices(t, pen, "2023-08-13")
+		penDay5 := NewTestPrices(t, pen, "2023-08-14")
+		canDay2 := NewTestPrices(t, can, "2023-08-11")
+		penSurplusDay2 := withType(t, penDay2, domain.SurplusPrices)
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay5, penDay4, penDay2, penDay1, canDay2, penSurplusDay2}))
+
+		zoneID, from := zoneIDAndDate(t, pen, "2023-08-11")
+		_, to := zoneIDAndDate(t, pen, "2023-08-13")
+		result, err := pricesRepository.QueryRange(context.Background(), zoneID, from, to)
+		require.NoError(t, err)
+		require.Equal(t, serialize(penDay2, penDay4), serialize(result...))
+	})
+
+	t.Run("query for a zone without prices returns no prices", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{NewTestPrices(t, pen, "2023-08-10")}))
+
+		zoneID, date := zoneIDAndDate(t, can, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), &zoneID, nil)
+		require.NoError(t, err)
+		require.Empty(t, result)
+
+		result, err = pricesRepository.Query(context.Background(), &zoneID, &date)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("saving prices with an already stored ID fails and stores nothing", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		canDay1 := NewTestPrices(t, can, "2023-08-10")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay1}))
+
+		err := pricesRepository.Save(context.Background(), []domain.Prices{canDay1, penDay1})
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+
+		_, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), nil, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(penDay1), serialize(result...))
+	})
+
+	t.Run("saving prices with duplicated IDs in the same batch fails", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+
+		err := pricesRepository.Save(context.Background(), []domain.Prices{penDay1, penDay1})
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+
+		result, err := pricesRepository.Query(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("saving prices for an unknown zone fails", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		unknown := domain.ZoneDto{ID: "UNK", ExternalID: "0000", Name: "Unknown"}
+
+		err := pricesRepository.Save(context.Background(), []domain.Prices{NewTestPrices(t, unknown, "2023-08-10")})
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+	})
+
+	t.Run("upsert stores new prices and replaces the stored ones", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+		canDay1 := NewTestPrices(t, can, "2023-08-10")
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{penDay1}))
+
+		dto := penDay1.Serialize()
+		dto.Date += "T00:00:00Z"
+		dto.Values[0].Value = 0.9999
+		dto.Provenance = &domain.ProvenanceDto{Provider: "redata", FetchedAt: "2023-08-10T10:00:00Z", Source: "/es/datos"}
+		penDay1Revised, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+
+		require.NoError(t, pricesRepository.Upsert(context.Background(), []domain.Prices{penDay1Revised, canDay1}))
+
+		_, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), nil, &date)
+		require.NoError(t, err)
+		require.ElementsMatch(t, serialize(penDay1Revised, canDay1), serialize(result...))
+	})
+
+	t.Run("upsert keeps the replaced versions as revisions, from the oldest to the newest", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		version1 := NewTestPrices(t, pen, "2023-08-10")
+		version2 := reviseTestPrices(t, version1, 0.2, "esios")
+		version3 := reviseTestPrices(t, version1, 0.3, "redata")
+		canDay1 := NewTestPrices(t, can, "2023-08-10")
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{version1}))
+		require.NoError(t, pricesRepository.Upsert(context.Background(), []domain.Prices{version2, canDay1}))
+		require.NoError(t, pricesRepository.Upsert(context.Background(), []domain.Prices{version3}))
+
+		revisions, err := pricesRepository.ListRevisions(context.Background(), version1.ID())
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		require.Equal(t, serialize(version1, version2), serialize(revisions[0].Prices(), revisions[1].Prices()))
+		require.False(t, revisions[0].RevisedAt().IsZero())
+		require.False(t, revisions[1].RevisedAt().Before(revisions[0].RevisedAt()))
+
+		revisions, err = pricesRepository.ListRevisions(context.Background(), canDay1.ID())
+		require.NoError(t, err)
+		require.Empty(t, revisions)
+	})
+
+	t.Run("listing revisions of unknown prices returns no revisions", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+
+		revisions, err := pricesRepository.ListRevisions(context.Background(), NewTestPrices(t, pen, "2023-08-10").ID())
+		require.NoError(t, err)
+		require.Empty(t, revisions)
+	})
+
+	t.Run("upsert with duplicated IDs in the same batch fails", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		penDay1 := NewTestPrices(t, pen, "2023-08-10")
+
+		err := pricesRepository.Upsert(context.Background(), []domain.Prices{penDay1, penDay1})
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+	})
+
+	t.Run("upsert for an unknown zone fails", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		unknown := domain.ZoneDto{ID: "UNK", ExternalID: "0000", Name: "Unknown"}
+
+		err := pricesRepository.Upsert(context.Background(), []domain.Prices{NewTestPrices(t, unknown, "2023-08-10")})
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+	})
+
+	t.Run("prices of different types with the same ID are stored apart", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		pvpc := NewTestPrices(t, pen, "2023-08-10")
+		surplus := withType(t, NewTestPrices(t, pen, "2023-08-10"), domain.SurplusPrices)
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{pvpc, surplus}))
+
+		zoneID, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := pricesRepository.Query(context.Background(), &zoneID, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(pvpc), serialize(result...))
+
+		result, err = pricesRepository.QueryByType(context.Background(), domain.SurplusPrices, &zoneID, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(surplus), serialize(result...))
+	})
+
+	t.Run("query by type without date returns the latest prices of that type", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		pvpcDay2 := NewTestPrices(t, pen, "2023-08-11")
+		surplusDay1 := withType(t, NewTestPrices(t, pen, "2023-08-10"), domain.SurplusPrices)
+
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{pvpcDay2, surplusDay1}))
+
+		result, err := pricesRepository.QueryByType(context.Background(), domain.SurplusPrices, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, serialize(surplusDay1), serialize(result...))
+
+		result, err = pricesRepository.QueryByType(context.Background(), domain.PVPCPrices, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, serialize(pvpcDay2), serialize(result...))
+	})
+
+	t.Run("upsert of non PVPC prices fails", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		surplus := withType(t, NewTestPrices(t, pen, "2023-08-10"), domain.SurplusPrices)
+
+		err := pricesRepository.Upsert(context.Background(), []domain.Prices{surplus})
+		require.Error(t, err)
+		require.Equal(t, errors.InvalidPricesType, errors.Code(err))
+	})
+
+	t.Run("aggregate returns the statistics of the saved PVPC prices of the zone by period", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{
+			newTestDayPrices(t, pen, "2023-10-09", 100, 24), // Monday
+			newTestDayPrices(t, pen, "2023-10-10", 200, 23),
+			newTestDayPrices(t, pen, "2023-10-15", 50, 24), // Sunday
+			newTestDayPrices(t, pen, "2023-10-16", 10, 24),
+			newTestDayPrices(t, pen, "2023-10-28", 30, 24), // Saturday
+			newTestDayPrices(t, pen, "2023-11-01", 40, 24),
+			newTestDayPrices(t, can, "2023-10-09", 1000, 24),
+			withType(t, newTestDayPrices(t, pen, "2023-10-11", 5000, 24), domain.SurplusPrices),
+		}))
+		zoneID, from := zoneIDAndDate(t, pen, "2023-10-01")
+		_, to := zoneIDAndDate(t, pen, "2023-10-31")
+
+		aggregates, err := pricesRepository.Aggregate(context.Background(), zoneID, domain.WeekGranularity, from, to)
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesAggregateDto{
+			{
+				ZoneID: "PEN", Granularity: "week", PeriodStart: "2023-10-09", Days: 3, Hours: 71, Min: 50, Max: 222,
+				Mean: 128, WeightedMean: 126.830986, PeakMean: floatPointer(163.5), OffpeakMean: floatPointer(108.106383),
+			},
+			{
+				ZoneID: "PEN", Granularity: "week", PeriodStart: "2023-10-16", Days: 1, Hours: 24, Min: 10, Max: 33,
+				Mean: 21.5, WeightedMean: 21.5, PeakMean: floatPointer(23.5), OffpeakMean: floatPointer(19.5),
+			},
+			{
+				ZoneID: "PEN", Granularity: "week", PeriodStart: "2023-10-23", Days: 1, Hours: 24, Min: 30, Max: 53,
+				Mean: 41.5, WeightedMean: 41.5, OffpeakMean: floatPointer(41.5),
+			},
+		}, serializeAggregates(aggregates...))
+
+		aggregates, err = pricesRepository.Aggregate(context.Background(), zoneID, domain.MonthGranularity, from.AddDate(0, 0, 9), to.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesAggregateDto{
+			{
+				ZoneID: "PEN", Granularity: "month", PeriodStart: "2023-10-01", Days: 4, Hours: 95, Min: 10, Max: 222,
+				Mean: 83.875, WeightedMean: 82.536842, PeakMean: floatPointer(118.5), OffpeakMean: floatPointer(70.380282),
+			},
+			{
+				ZoneID: "PEN", Granularity: "month", PeriodStart: "2023-11-01", Days: 1, Hours: 24, Min: 40, Max: 63,
+				Mean: 51.5, WeightedMean: 51.5, PeakMean: floatPointer(53.5), OffpeakMean: floatPointer(49.5),
+			},
+		}, serializeAggregates(aggregates...))
+	})
+
+	t.Run("aggregate uses the prices replaced by upsert", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{newTestDayPrices(t, pen, "2023-10-09", 100, 24)}))
+		require.NoError(t, pricesRepository.Upsert(context.Background(), []domain.Prices{
+			newTestDayPrices(t, pen, "2023-10-09", 200, 24),
+			newTestDayPrices(t, pen, "2024-02-10", 300, 24),
+		}))
+		zoneID, from := zoneIDAndDate(t, pen, "2023-01-01")
+		_, to := zoneIDAndDate(t, pen, "2024-12-31")
+
+		aggregates, err := pricesRepository.Aggregate(context.Background(), zoneID, domain.YearGranularity, from, to)
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesAggregateDto{
+			{
+				ZoneID: "PEN", Granularity: "year", PeriodStart: "2023-01-01", Days: 1, Hours: 24, Min: 200, Max: 223,
+				Mean: 211.5, WeightedMean: 211.5, PeakMean: floatPointer(213.5), OffpeakMean: floatPointer(209.5),
+			},
+			{
+				ZoneID: "PEN", Granularity: "year", PeriodStart: "2024-01-01", Days: 1, Hours: 24, Min: 300, Max: 323,
+				Mean: 311.5, WeightedMean: 311.5, OffpeakMean: floatPointer(311.5),
+			},
+		}, serializeAggregates(aggregates...))
+	})
+
+	t.Run("aggregate of a range without prices returns no aggregates", func(t *testing.T) {
+		pricesRepository, _ := newRepositories(t)
+		zoneID, from := zoneIDAndDate(t, pen, "2023-10-01")
+
+		aggregates, err := pricesRepository.Aggregate(context.Background(), zoneID, domain.MonthGranularity, from, from.AddDate(0, 1, 0))
+		require.NoError(t, err)
+		require.Empty(t, aggregates)
+	})
+}
+
+// NewTestPrices builds a domain.Prices for the given zone and date (YYYY-MM-DD)
+// with two hourly values.
+func NewTestPrices(t *testing.T, zone domain.ZoneDto, date string) domain.Prices {
+	t.Helper()
+	prices, err := domain.NewPrices(domain.PricesDto{
+		ID:   fmt.Sprintf("%s-%s", zone.ID, date),
+		Date: date + "T00:00:00Z",
+		Zone: zone,
+		Values: []domain.HourlyPriceDto{
+			{Datetime: date + "T00:00:00+02:00", Value: 0.1234},
+			{Datetime: date + "T01:00:00+02:00", Value: 0.5678},
+		},
+	})
+	require.NoError(t, err)
+	return prices
+}
+
+// newTestDayPrices builds a domain.Prices for the given zone and Europe/Madrid date (YYYY-MM-DD) with
+// the given number of hourly values from 00:00, each one the base value plus its hour.
+func newTestDayPrices(t *testing.T, zone domain.ZoneDto, date string, base float64, hours int) domain.Prices {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	day, err := time.ParseInLocation(time.DateOnly, date, loc)
+	require.NoError(t, err)
+	dto := domain.PricesDto{ID: fmt.Sprintf("%s-%s", zone.ID, date), Date: date + "T00:00:00Z", Zone: zone}
+	for hour := 0; hour < hours; hour++ {
+		datetime := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, loc)
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: base + float64(hour)})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return prices
+}
+
+// reviseTestPrices returns a copy of prices with its first hourly value changed to value
+// and fetched from provider, as a republished version of it.
+func reviseTestPrices(t *testing.T, prices domain.Prices, value float64, provider string) domain.Prices {
+	t.Helper()
+	dto := prices.Serialize()
+	dto.Date += "T00:00:00Z"
+	dto.Values[0].Value = value
+	dto.Provenance = &domain.ProvenanceDto{Provider: provider, FetchedAt: "2023-08-10T10:00:00Z", Source: "/" + provider}
+	revised, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return revised
+}
+
+// withType returns a copy of prices with the given type.
+func withType(t *testing.T, prices domain.Prices, pricesType domain.PricesType) domain.Prices {
+	t.Helper()
+	dto := prices.Serialize()
+	dto.Date += "T00:00:00Z"
+	dto.Type = pricesType.String()
+	typed, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return typed
+}
+
+func zoneIDAndDate(t *testing.T, zone domain.ZoneDto, date string) (domain.ZoneID, time.Time) {
+	t.Helper()
+	zoneID, err := domain.NewZoneID(zone.ID)
+	require.NoError(t, err)
+	parsedDate, err := time.Parse("2006-01-02", date)
+	require.NoError(t, err)
+	return zoneID, parsedDate
+}
+
+// serializeAggregates serializes the aggregates with their means rounded to 6 decimals,
+// as storages may sum the prices in different orders.
+func serializeAggregates(aggregates ...domain.PricesAggregate) []domain.PricesAggregateDto {
+	round := func(x float64) float64 { return math.Round(x*1e6) / 1e6 }
+	dtos := make([]domain.PricesAggregateDto, len(aggregates))
+	for i, aggregate := range aggregates {
+		dto := aggregate.Serialize()
+		dto.Mean, dto.WeightedMean = round(dto.Mean), round(dto.WeightedMean)
+		if dto.PeakMean != nil {
+			dto.PeakMean = floatPointer(round(*dto.PeakMean))
+		}
+		if dto.OffpeakMean != nil {
+			dto.OffpeakMean = floatPointer(round(*dto.OffpeakMean))
+		}
+		dtos[i] = dto
+	}
+	return dtos
+}
+
+func floatPointer(x float64) *float64 {
+	return &x
+}
+
+// serialize maps prices to DTOs, so they can be compared regardless of the
+// time.Location instances their times were parsed into.
+func serialize(prices ...domain.Prices) []domain.PricesDto {
+	dtos := make([]domain.PricesDto, len(prices))
+	for i, p := range prices {
+		dtos[i] = p.Serialize()
+	}
+	return dtos
+}
@@ -0,0 +1,116 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// QuarantineRepositoryFactory returns a fresh quarantine repository backed by a storage
+// where only the default zones are present. It is called once per test case.
+type QuarantineRepositoryFactory func(t *testing.T) domain.QuarantineRepository
+
+// RunQuarantineRepositoryTests runs the domain.QuarantineRepository test suite against the
+// repositories returned by newRepository.
+func RunQuarantineRepositoryTests(t *testing.T, newRepository QuarantineRepositoryFactory) {
+	pen, bal := SeededZones[0], SeededZones[2]
+	detectedAt := time.Date(2023, 10, 11, 20, 30, 0, 0, time.UTC)
+
+	t.Run("get of prices never quarantined returns a not found error", func(t *testing.T) {
+		repository := newRepository(t)
+		prices := NewTestPrices(t, pen, "2023-10-12")
+
+		_, err := repository.Get(context.Background(), domain.PVPCPrices, prices.ID())
+		require.Error(t, err)
+		require.Equal(t, errors.QuarantineNotFound, errors.Code(err))
+
+		quarantined, err := repository.List(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, quarantined)
+	})
+
+	t.Run("saved quarantined prices are got by type and ID", func(t *testing.T) {
+		repository := newRepository(t)
+		prices := reviseTestPrices(t, NewTestPrices(t, pen, "2023-10-12"), 0, "esios")
+		quarantined := newTestQuarantinedPrices(t, prices, detectedAt)
+		require.NoError(t, repository.Save(context.Background(), quarantined))
+
+		got, err := repository.Get(context.Background(), domain.PVPCPrices, prices.ID())
+		require.NoError(t, err)
+		require.Equal(t, quarantined.Serialize(), got.Serialize())
+
+		_, err = repository.Get(context.Background(), domain.SurplusPrices, prices.ID())
+		require.Equal(t, errors.QuarantineNotFound, errors.Code(err))
+	})
+
+	t.Run("saving quarantined prices again replaces the stored ones", func(t *testing.T) {
+		repository := newRepository(t)
+		prices := NewTestPrices(t, pen, "2023-10-12")
+		pending := newTestQuarantinedPrices(t, prices, detectedAt)
+		require.NoError(t, repository.Save(context.Background(), pending))
+		approved, err := pending.Review(domain.ApprovedQuarantine, detectedAt.Add(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, repository.Save(context.Background(), approved))
+
+		got, err := repository.Get(context.Background(), domain.PVPCPrices, prices.ID())
+		require.NoError(t, err)
+		require.Equal(t, approved.Serialize(), got.Serialize())
+	})
+
+	t.Run("quarantined prices are listed by status from the most recently detected", func(t *testing.T) {
+		repository := newRepository(t)
+		older := newTestQuarantinedPrices(t, NewTestPrices(t, pen, "2023-10-11"), detectedAt.AddDate(0, 0, -1))
+		surplus := newTestQuarantinedPrices(t, withType(t, NewTestPrices(t, pen, "2023-10-12"), domain.SurplusPrices), detectedAt)
+		pvpc := newTestQuarantinedPrices(t, NewTestPrices(t, pen, "2023-10-12"), detectedAt)
+		rejected, err := newTestQuarantinedPrices(t, NewTestPrices(t, bal, "2023-10-12"), detectedAt).Review(domain.RejectedQuarantine, detectedAt.Add(time.Hour))
+		require.NoError(t, err)
+		for _, q := range []domain.QuarantinedPrices{older, surplus, rejected, pvpc} {
+			require.NoError(t, repository.Save(context.Background(), q))
+		}
+
+		all, err := repository.List(context.Background(), nil)
+		require.NoError(t, err)
+		require.Equal(t, serializeQuarantined(rejected, pvpc, surplus, older), serializeQuarantined(all...))
+
+		status := domain.PendingQuarantine
+		pending, err := repository.List(context.Background(), &status)
+		require.NoError(t, err)
+		require.Equal(t, serializeQuarantined(pvpc, surplus, older), serializeQuarantined(pending...))
+	})
+
+	t.Run("saving quarantined prices of an unknown zone fails", func(t *testing.T) {
+		repository := newRepository(t)
+		prices := NewTestPrices(t, domain.ZoneDto{ID: "XXX", ExternalID: "0000", Name: "Unknown"}, "2023-10-12")
+
+		require.Error(t, repository.Save(context.Background(), newTestQuarantinedPrices(t, prices, detectedAt)))
+	})
+}
+
+// newTestQuarantinedPrices returns prices quarantined at detectedAt as out of bounds.
+func newTestQuarantinedPrices(t *testing.T, prices domain.Prices, detectedAt time.Time) domain.QuarantinedPrices {
+	t.Helper()
+	dto := prices.Serialize()
+	dto.Date += "T00:00:00Z"
+	quarantined, err := domain.NewQuarantinedPrices(domain.QuarantinedPricesDto{
+		Prices:     dto,
+		Anomalies:  []domain.PricesAnomalyDto{{Kind: "out_of_bounds", Detail: "2 of 2 hourly prices are out of [1, 10]"}},
+		Status:     "pending",
+		DetectedAt: detectedAt.Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+	return quarantined
+}
+
+func serializeQuarantined(quarantined ...domain.QuarantinedPrices) []domain.QuarantinedPricesDto {
+	dtos := make([]domain.QuarantinedPricesDto, len(quarantined))
+	for i, q := range quarantined {
+		dtos[i] = q.Serialize()
+	}
+	return dtos
+}
@@ -0,0 +1,73 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+)
+
+// SpotPricesRepositoriesFactory returns a fresh spot prices repository and a prices repository sharing
+// the same storage, where only the default zones are present. It is called once per test case.
+type SpotPricesRepositoriesFactory func(t *testing.T) (domain.SpotPricesRepository, domain.PricesRepository)
+
+// RunSpotPricesRepositoryTests runs the domain.SpotPricesRepository test suite against the
+// repositories returned by newRepositories.
+func RunSpotPricesRepositoryTests(t *testing.T, newRepositories SpotPricesRepositoriesFactory) {
+	pen, can := SeededZones[0], SeededZones[1]
+
+	t.Run("saved spot prices are returned when querying by zone ID and date", func(t *testing.T) {
+		spotPricesRepository, _ := newRepositories(t)
+		prices := newTestSpotPrices(t, pen, "2023-08-10")
+
+		require.NoError(t, spotPricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+		zoneID, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := spotPricesRepository.Query(context.Background(), &zoneID, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(prices), serialize(result...))
+	})
+
+	t.Run("query without date returns the latest spot prices of every zone", func(t *testing.T) {
+		spotPricesRepository, _ := newRepositories(t)
+		penDay1 := newTestSpotPrices(t, pen, "2023-08-10")
+		penDay2 := newTestSpotPrices(t, pen, "2023-08-11")
+		canDay1 := newTestSpotPrices(t, can, "2023-08-10")
+
+		require.NoError(t, spotPricesRepository.Save(context.Background(), []domain.Prices{penDay1, penDay2, canDay1}))
+
+		result, err := spotPricesRepository.Query(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.ElementsMatch(t, serialize(penDay2, canDay1), serialize(result...))
+	})
+
+	t.Run("saving spot prices with an already stored ID fails", func(t *testing.T) {
+		spotPricesRepository, _ := newRepositories(t)
+		prices := newTestSpotPrices(t, pen, "2023-08-10")
+
+		require.NoError(t, spotPricesRepository.Save(context.Background(), []domain.Prices{prices}))
+		require.Error(t, spotPricesRepository.Save(context.Background(), []domain.Prices{prices}))
+	})
+
+	t.Run("spot prices and PVPC prices are stored apart", func(t *testing.T) {
+		spotPricesRepository, pricesRepository := newRepositories(t)
+		spot := newTestSpotPrices(t, pen, "2023-08-10")
+		pvpc := NewTestPrices(t, can, "2023-08-10")
+
+		require.NoError(t, spotPricesRepository.Save(context.Background(), []domain.Prices{spot}))
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{pvpc, NewTestPrices(t, pen, "2023-08-10")}))
+
+		_, date := zoneIDAndDate(t, pen, "2023-08-10")
+		result, err := spotPricesRepository.Query(context.Background(), nil, &date)
+		require.NoError(t, err)
+		require.Equal(t, serialize(spot), serialize(result...))
+	})
+}
+
+// newTestSpotPrices builds the domain.Prices of NewTestPrices as spot prices.
+func newTestSpotPrices(t *testing.T, zone domain.ZoneDto, date string) domain.Prices {
+	t.Helper()
+	return withType(t, NewTestPrices(t, zone, date), domain.SpotPrices)
+}
@@ -0,0 +1,23 @@
+// Package storagetest provides the repository test suites that every storage
+// backend must pass, so all implementations behave exactly the same way.
+package storagetest
+
+import (
+	"testing"
+
+	"pvpc-backend/internal/domain"
+)
+
+// RepositoriesFactory returns fresh repositories backed by an empty storage
+// where only the default zones (PEN, CAN, BAL, CEU and MEL) are present.
+// It is called once per test case.
+type RepositoriesFactory func(t *testing.T) (domain.PricesRepository, domain.ZonesRepository)
+
+// SeededZones are the zones every storage must contain before running the suites.
+var SeededZones = []domain.ZoneDto{
+	{ID: "PEN", ExternalID: "8741", Name: "Península", TimeZone: "Europe/Madrid"},
+	{ID: "CAN", ExternalID: "8742", Name: "Canarias", TimeZone: "Atlantic/Canary"},
+	{ID: "BAL", ExternalID: "8743", Name: "Baleares", TimeZone: "Europe/Madrid"},
+	{ID: "CEU", ExternalID: "8744", Name: "Ceuta", TimeZone: "Europe/Madrid"},
+	{ID: "MEL", ExternalID: "8745", Name: "Melilla", TimeZone: "Europe/Madrid"},
+}
@@ -0,0 +1,66 @@
+package storagetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// RunZonesRepositoryTests runs the domain.ZonesRepository test suite against the
+// repositories returned by newRepositories.
+func RunZonesRepositoryTests(t *testing.T, newRepositories RepositoriesFactory) {
+	t.Run("get all returns the seeded zones", func(t *testing.T) {
+		_, zonesRepository := newRepositories(t)
+
+		result, err := zonesRepository.GetAll(context.Background())
+		require.NoError(t, err)
+
+		dtos := make([]domain.ZoneDto, len(result))
+		for i, zone := range result {
+			dtos[i] = zone.Serialize()
+		}
+		require.ElementsMatch(t, SeededZones, dtos)
+	})
+
+	t.Run("get by ID returns the zone", func(t *testing.T) {
+		_, zonesRepository := newRepositories(t)
+		zoneID, err := domain.NewZoneID(SeededZones[0].ID)
+		require.NoError(t, err)
+
+		result, err := zonesRepository.GetByID(context.Background(), zoneID)
+		require.NoError(t, err)
+		require.Equal(t, SeededZones[0], result.Serialize())
+	})
+
+	t.Run("get by ID of an unknown zone returns a not found error", func(t *testing.T) {
+		_, zonesRepository := newRepositories(t)
+		zoneID, err := domain.NewZoneID("UNK")
+		require.NoError(t, err)
+
+		result, err := zonesRepository.GetByID(context.Background(), zoneID)
+		require.Error(t, err)
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+		require.Equal(t, domain.Zone{}, result)
+	})
+
+	t.Run("get by external ID returns the zone", func(t *testing.T) {
+		_, zonesRepository := newRepositories(t)
+
+		result, err := zonesRepository.GetByExternalID(context.Background(), SeededZones[1].ExternalID)
+		require.NoError(t, err)
+		require.Equal(t, SeededZones[1], result.Serialize())
+	})
+
+	t.Run("get by external ID of an unknown zone returns a not found error", func(t *testing.T) {
+		_, zonesRepository := newRepositories(t)
+
+		result, err := zonesRepository.GetByExternalID(context.Background(), "0000")
+		require.Error(t, err)
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+		require.Equal(t, domain.Zone{}, result)
+	})
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// maxAppliances is the maximum number of appliances that can be scheduled together.
+const maxAppliances = 10
+
+// maxScheduleSearchNodes bounds the search of the cheapest schedule. When it is reached, the cheapest
+// schedule found so far is returned or, if none was found yet, a greedy one.
+var maxScheduleSearchNodes = 1000000
+
+// Appliance is an appliance to schedule: it runs without interruption for Duration drawing PowerKw,
+// starting not before EarliestStart and ending not after LatestEnd. Zero times don't restrict the run
+// besides the prices horizon.
+type Appliance struct {
+	Name          string
+	Duration      time.Duration
+	PowerKw       float64
+	EarliestStart time.Time
+	LatestEnd     time.Time
+}
+
+// AppliancesScheduling is the input of an appliances schedule: the appliances to run in a zone from
+// Start (now, if it is zero) on, and the maximum power (kW) they can draw at the same time.
+type AppliancesScheduling struct {
+	ZoneID     domain.ZoneID
+	Start      time.Time
+	MaxPowerKw float64
+	Appliances []Appliance
+}
+
+// AppliancesPlan is the cheapest schedule of the appliances, along with its cost and the naive
+// schedule of running every appliance as soon as allowed, ignoring the power cap.
+type AppliancesPlan struct {
+	ZoneID    domain.ZoneID
+	Runs      []ApplianceRun
+	Cost      float64
+	NaiveRuns []ApplianceRun
+	NaiveCost float64
+	Savings   float64
+}
+
+// ApplianceRun is when an appliance runs and what it costs.
+type ApplianceRun struct {
+	Name    string
+	Start   time.Time
+	End     time.Time
+	PowerKw float64
+	Kwh     float64
+	Cost    float64
+}
+
+// applianceCandidates are the runs an appliance can be scheduled at, sorted by cost.
+type applianceCandidates struct {
+	appliance Appliance
+	runs      []ApplianceRun
+}
+
+// PlanAppliances returns the schedule of the appliances over the published prices horizon that minimizes
+// their total cost without exceeding the power cap. Appliances start when allowed or at o'clock hours.
+// It returns an InvalidAppliancesPlan error if the appliances can't be scheduled, and an AppliancesPlanNotFound
+// error if the search is cut before finding a schedule and the greedy one exceeds the power cap.
+func (s PlansService) PlanAppliances(ctx context.Context, scheduling AppliancesScheduling) (AppliancesPlan, error) {
+	if scheduling.Start.IsZero() {
+		scheduling.Start = now()
+	}
+	if err := validateAppliancesScheduling(scheduling); err != nil {
+		return AppliancesPlan{}, err
+	}
+	zone, err := s.zonesRepository.GetByID(ctx, scheduling.ZoneID)
+	if err != nil {
+		return AppliancesPlan{}, err
+	}
+
+	slots, _, err := s.priceSlots(ctx, zone, scheduling.Start, startOfDay(scheduling.Start, zone.Location()).AddDate(0, 0, 2))
+	if err != nil {
+		return AppliancesPlan{}, err
+	}
+	horizon := pricesHorizon(slots, scheduling.Start)
+	if !horizon.After(scheduling.Start) {
+		return AppliancesPlan{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s at %s", scheduling.ZoneID.String(), scheduling.Start.Format(time.RFC3339))
+	}
+
+	candidates := make([]applianceCandidates, len(scheduling.Appliances))
+	naive := make([]ApplianceRun, len(scheduling.Appliances))
+	for i, appliance := range scheduling.Appliances {
+		earliest, latest := applianceWindow(appliance, scheduling.Start, horizon)
+		candidates[i] = applianceCandidates{appliance: appliance}
+		for start := earliest; !start.Add(appliance.Duration).After(latest); start = start.Truncate(time.Hour).Add(time.Hour) {
+			candidates[i].runs = append(candidates[i].runs, applianceRun(appliance, start, slots))
+		}
+		if len(candidates[i].runs) == 0 {
+			return AppliancesPlan{}, errors.NewDomainError(errors.InvalidAppliancesPlan, "appliance %s can't run between %s and %s, the end of the published prices",
+				appliance.Name, earliest.Format(time.RFC3339), latest.Format(time.RFC3339))
+		}
+		naive[i] = candidates[i].runs[0]
+		sort.SliceStable(candidates[i].runs, func(a, b int) bool {
+			return candidates[i].runs[a].Cost < candidates[i].runs[b].Cost
+		})
+	}
+
+	runs, err := cheapestSchedule(candidates, scheduling.MaxPowerKw)
+	if err != nil {
+		return AppliancesPlan{}, err
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].Start.Before(runs[j].Start)
+	})
+
+	cost, naiveCost := runsCost(runs).Round(2), runsCost(naive).Round(2)
+	plan := AppliancesPlan{
+		ZoneID:    scheduling.ZoneID,
+		Runs:      runs,
+		Cost:      cost.Float64(),
+		NaiveRuns: naive,
+		NaiveCost: naiveCost.Float64(),
+		Savings:   naiveCost.Sub(cost).Float64(),
+	}
+	for _, runs := range [][]ApplianceRun{plan.Runs, plan.NaiveRuns} {
+		for i := range runs {
+			runs[i].Kwh = round(runs[i].Kwh, 3)
+		}
+	}
+
+	return plan, nil
+}
+
+func validateAppliancesScheduling(scheduling AppliancesScheduling) error {
+	if len(scheduling.Appliances) == 0 || len(scheduling.Appliances) > maxAppliances {
+		return errors.NewDomainError(errors.InvalidAppliancesPlan, "there must be from 1 to %d appliances, there are %d", maxAppliances, len(scheduling.Appliances))
+	}
+	if scheduling.MaxPowerKw <= 0 {
+		return errors.NewDomainError(errors.InvalidAppliancesPlan, "maximum power must be positive: %v kW", scheduling.MaxPowerKw)
+	}
+
+	names := make(map[string]bool, len(scheduling.Appliances))
+	for _, appliance := range scheduling.Appliances {
+		if appliance.Name == "" || names[appliance.Name] {
+			return errors.NewDomainError(errors.InvalidAppliancesPlan, "appliances must have unique names: %q", appliance.Name)
+		}
+		names[appliance.Name] = true
+		if appliance.Duration <= 0 || appliance.PowerKw <= 0 {
+			return errors.NewDomainError(errors.InvalidAppliancesPlan, "appliance %s duration and power must be positive", appliance.Name)
+		}
+		if appliance.PowerKw > scheduling.MaxPowerKw {
+			return errors.NewDomainError(errors.InvalidAppliancesPlan, "appliance %s draws %v kW, more than the maximum power %v kW", appliance.Name, appliance.PowerKw, scheduling.MaxPowerKw)
+		}
+	}
+	return nil
+}
+
+// pricesHorizon returns the end of the priced hours following start without gaps.
+func pricesHorizon(slots []priceSlot, start time.Time) time.Time {
+	horizon := start
+	for _, slot := range slots {
+		if slot.start.After(horizon) {
+			break
+		}
+		if slot.end.After(horizon) {
+			horizon = slot.end
+		}
+	}
+	return horizon
+}
+
+// applianceWindow returns when the appliance can run, limited to the prices horizon.
+func applianceWindow(appliance Appliance, start, horizon time.Time) (time.Time, time.Time) {
+	earliest, latest := start, horizon
+	if appliance.EarliestStart.After(earliest) {
+		earliest = appliance.EarliestStart
+	}
+	if !appliance.LatestEnd.IsZero() && appliance.LatestEnd.Before(latest) {
+		latest = appliance.LatestEnd
+	}
+	return earliest, latest
+}
+
+// applianceRun returns the run of the appliance from start, costed at the prices of the slots it overlaps.
+// Its cost is exact to 6 decimals.
+func applianceRun(appliance Appliance, start time.Time, slots []priceSlot) ApplianceRun {
+	run := ApplianceRun{Name: appliance.Name, Start: start, End: start.Add(appliance.Duration), PowerKw: appliance.PowerKw}
+	var cost domain.Decimal
+	for _, slot := range slots {
+		from, to := slot.start, slot.end
+		if run.Start.After(from) {
+			from = run.Start
+		}
+		if run.End.Before(to) {
+			to = run.End
+		}
+		if !from.Before(to) {
+			continue
+		}
+		kwh := to.Sub(from).Hours() * appliance.PowerKw
+		run.Kwh += kwh
+		cost = cost.Add(slot.price.CostOf(domain.NewDecimalFromFloat(kwh)))
+	}
+	run.Cost = cost.Round(6).Float64()
+	return run
+}
+
+// cheapestSchedule returns the run of every appliance that minimizes the total cost without drawing more
+// than maxPowerKw at the same time, by a branch and bound search over the candidates. If the search reaches
+// maxScheduleSearchNodes before finding any schedule, it falls back to greedySchedule.
+func cheapestSchedule(candidates []applianceCandidates, maxPowerKw float64) ([]ApplianceRun, error) {
+	// Appliances using more energy are scheduled first, as they constrain the rest the most.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].runs[0].Kwh > candidates[j].runs[0].Kwh
+	})
+	// lowerBounds[i] is the cost of running the appliances from i on at their cheapest, ignoring the power cap.
+	lowerBounds := make([]float64, len(candidates)+1)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		lowerBounds[i] = lowerBounds[i+1] + candidates[i].runs[0].Cost
+	}
+
+	best, bestCost := []ApplianceRun(nil), math.Inf(1)
+	chosen := make([]ApplianceRun, 0, len(candidates))
+	nodes := 0
+	var search func(i int, cost float64)
+	search = func(i int, cost float64) {
+		if i == len(candidates) {
+			best, bestCost = append([]ApplianceRun(nil), chosen...), cost
+			return
+		}
+		for _, run := range candidates[i].runs {
+			nodes++
+			if nodes > maxScheduleSearchNodes || cost+run.Cost+lowerBounds[i+1] >= bestCost-planEnergyTolerance {
+				return
+			}
+			if !fitsPowerCap(chosen, run, maxPowerKw) {
+				continue
+			}
+			chosen = append(chosen, run)
+			search(i+1, cost+run.Cost)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	search(0, 0)
+
+	if best != nil {
+		return best, nil
+	}
+	if nodes <= maxScheduleSearchNodes {
+		return nil, errors.NewDomainError(errors.InvalidAppliancesPlan, "appliances can't be scheduled drawing up to %v kW at the same time", maxPowerKw)
+	}
+	if runs, ok := greedySchedule(candidates, maxPowerKw); ok {
+		return runs, nil
+	}
+	return nil, errors.NewDomainError(errors.AppliancesPlanNotFound, "no schedule of the appliances drawing up to %v kW at the same time was found within %d search steps", maxPowerKw, maxScheduleSearchNodes)
+}
+
+// greedySchedule returns, appliance by appliance in the candidates order, the cheapest run that fits the
+// power cap with the runs already chosen. It reports false if some appliance has no run that fits.
+func greedySchedule(candidates []applianceCandidates, maxPowerKw float64) ([]ApplianceRun, bool) {
+	runs := make([]ApplianceRun, 0, len(candidates))
+	for _, c := range candidates {
+		fits := false
+		for _, run := range c.runs {
+			if fitsPowerCap(runs, run, maxPowerKw) {
+				runs = append(runs, run)
+				fits = true
+				break
+			}
+		}
+		if !fits {
+			return nil, false
+		}
+	}
+	return runs, true
+}
+
+// fitsPowerCap returns whether run can be added to the runs without drawing more than maxPowerKw at
+// the same time. The power drawn is the highest at the start of some run, so only those instants are checked.
+func fitsPowerCap(runs []ApplianceRun, run ApplianceRun, maxPowerKw float64) bool {
+	all := append([]ApplianceRun{run}, runs...)
+	for _, instant := range all {
+		var power float64
+		for _, r := range all {
+			if !r.Start.After(instant.Start) && r.End.After(instant.Start) {
+				power += r.PowerKw
+			}
+		}
+		if power > maxPowerKw+planEnergyTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// runsCost returns the exact sum of the costs of the runs.
+func runsCost(runs []ApplianceRun) domain.Decimal {
+	var cost domain.Decimal
+	for _, run := range runs {
+		cost = cost.Add(domain.NewDecimalFromFloat(run.Cost))
+	}
+	return cost
+}
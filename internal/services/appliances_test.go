@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_PlansService_PlanAppliances(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	// 2023-10-11 prices: 100 €/MWh every hour except 03:00 (20), 04:00 (30) and 05:00 (40).
+	service, zoneID := newTestPlansService(t)
+	start := parseTestDatetime(t, "2023-10-11T00:30:00+02:00")
+
+	t.Run("runs every appliance at its cheapest hours within the power cap", func(t *testing.T) {
+		plan, err := service.PlanAppliances(context.Background(), AppliancesScheduling{
+			ZoneID: zoneID, Start: start, MaxPowerKw: 3,
+			Appliances: []Appliance{
+				{Name: "washing machine", Duration: 2 * time.Hour, PowerKw: 2},
+				{Name: "dishwasher", Duration: time.Hour, PowerKw: 1.5},
+				{Name: "dryer", Duration: 90 * time.Minute, PowerKw: 1, EarliestStart: parseTestDatetime(t, "2023-10-11T10:00:00+02:00")},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []ApplianceRun{
+			{Name: "washing machine", Start: parseTestDatetime(t, "2023-10-11T03:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), PowerKw: 2, Kwh: 4, Cost: 0.1},
+			{Name: "dishwasher", Start: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T06:00:00+02:00"), PowerKw: 1.5, Kwh: 1.5, Cost: 0.06},
+			{Name: "dryer", Start: parseTestDatetime(t, "2023-10-11T10:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T11:30:00+02:00"), PowerKw: 1, Kwh: 1.5, Cost: 0.15},
+		}, plan.Runs)
+		require.Equal(t, 0.31, plan.Cost)
+		require.Equal(t, start, plan.NaiveRuns[0].Start)
+		require.Equal(t, 0.7, plan.NaiveCost)
+		require.Equal(t, 0.39, plan.Savings)
+	})
+
+	t.Run("when the search limit is reached before finding a schedule, it returns the greedy one", func(t *testing.T) {
+		defer func(nodes int) { maxScheduleSearchNodes = nodes }(maxScheduleSearchNodes)
+		maxScheduleSearchNodes = 1
+
+		plan, err := service.PlanAppliances(context.Background(), AppliancesScheduling{
+			ZoneID: zoneID, Start: start, MaxPowerKw: 3,
+			Appliances: []Appliance{
+				{Name: "washing machine", Duration: 2 * time.Hour, PowerKw: 2},
+				{Name: "dishwasher", Duration: time.Hour, PowerKw: 1.5},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []ApplianceRun{
+			{Name: "washing machine", Start: parseTestDatetime(t, "2023-10-11T03:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), PowerKw: 2, Kwh: 4, Cost: 0.1},
+			{Name: "dishwasher", Start: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T06:00:00+02:00"), PowerKw: 1.5, Kwh: 1.5, Cost: 0.06},
+		}, plan.Runs)
+	})
+
+	t.Run("when the appliances can't run within their windows, it returns an invalid appliances plan error", func(t *testing.T) {
+		_, err := service.PlanAppliances(context.Background(), AppliancesScheduling{
+			ZoneID: zoneID, Start: start, MaxPowerKw: 3,
+			Appliances: []Appliance{
+				{Name: "washing machine", Duration: 2 * time.Hour, PowerKw: 2, LatestEnd: parseTestDatetime(t, "2023-10-11T02:00:00+02:00")},
+			},
+		})
+		require.Equal(t, errors.InvalidAppliancesPlan, errors.Code(err))
+
+		_, err = service.PlanAppliances(context.Background(), AppliancesScheduling{
+			ZoneID: zoneID, Start: start, MaxPowerKw: 3,
+			Appliances: []Appliance{
+				{Name: "washing machine", Duration: 2 * time.Hour, PowerKw: 2, LatestEnd: parseTestDatetime(t, "2023-10-11T03:00:00+02:00")},
+				{Name: "dishwasher", Duration: 2 * time.Hour, PowerKw: 2, LatestEnd: parseTestDatetime(t, "2023-10-11T03:00:00+02:00")},
+			},
+		})
+		require.Equal(t, errors.InvalidAppliancesPlan, errors.Code(err))
+	})
+
+	t.Run("when the input is invalid, it returns an invalid appliances plan error", func(t *testing.T) {
+		for _, scheduling := range []AppliancesScheduling{
+			{ZoneID: zoneID, Start: start, MaxPowerKw: 3},
+			{ZoneID: zoneID, Start: start, MaxPowerKw: 0, Appliances: []Appliance{{Name: "oven", Duration: time.Hour, PowerKw: 2}}},
+			{ZoneID: zoneID, Start: start, MaxPowerKw: 3, Appliances: []Appliance{{Name: "oven", Duration: time.Hour, PowerKw: 4}}},
+			{ZoneID: zoneID, Start: start, MaxPowerKw: 3, Appliances: []Appliance{{Name: "oven", Duration: 0, PowerKw: 2}}},
+			{ZoneID: zoneID, Start: start, MaxPowerKw: 3, Appliances: []Appliance{{Name: "oven", Duration: time.Hour, PowerKw: 1}, {Name: "oven", Duration: time.Hour, PowerKw: 1}}},
+		} {
+			_, err := service.PlanAppliances(context.Background(), scheduling)
+
+			require.Equal(t, errors.InvalidAppliancesPlan, errors.Code(err))
+		}
+	})
+
+	t.Run("when there are no prices from the start, it returns a prices not found error", func(t *testing.T) {
+		_, err := service.PlanAppliances(context.Background(), AppliancesScheduling{
+			ZoneID: zoneID, Start: parseTestDatetime(t, "2023-10-13T00:00:00+02:00"), MaxPowerKw: 3,
+			Appliances: []Appliance{{Name: "oven", Duration: time.Hour, PowerKw: 2}},
+		})
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+}
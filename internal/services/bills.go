@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// maxBillDays is the longest billing period that can be simulated.
+const maxBillDays = 366
+
+// BillsService is the domain service that simulates PVPC bills from the stored prices.
+type BillsService struct {
+	pricesRepository domain.PricesRepository
+	zonesRepository  domain.ZonesRepository
+	ratesSchedule    domain.BillRatesSchedule
+}
+
+// NewBillsService returns a new BillsService, which bills with the rates of ratesSchedule.
+func NewBillsService(pricesRepository domain.PricesRepository, zonesRepository domain.ZonesRepository, ratesSchedule domain.BillRatesSchedule) BillsService {
+	return BillsService{
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
+		ratesSchedule:    ratesSchedule,
+	}
+}
+
+// BillSimulation is the input of a bill simulation: the supply's zone, contracted power and
+// hourly consumption during the billing period, from From to To (YYYY-MM-DD days in the zone local time, both included).
+type BillSimulation struct {
+	ZoneID          domain.ZoneID
+	From            time.Time
+	To              time.Time
+	ContractedPower domain.ContractedPower
+	Consumption     []domain.HourlyConsumption
+}
+
+// billSegment is a run of consecutive billing days with the same rates.
+type billSegment struct {
+	rates  domain.BillRates
+	from   time.Time
+	to     time.Time
+	days   int
+	kwh    domain.Decimal
+	energy domain.Decimal // exact, in €, so it's only rounded once to cents
+}
+
+// SimulateBill returns the bill of the given simulation, with a line per concept and run of days
+// with the same rates. Every hour with consumption must have its PVPC price stored.
+func (s BillsService) SimulateBill(ctx context.Context, simulation BillSimulation) (domain.Bill, error) {
+	if simulation.To.Before(simulation.From) {
+		return domain.Bill{}, errors.NewDomainError(errors.InvalidDateRange, "bill from %s is after to %s", simulation.From.Format(time.DateOnly), simulation.To.Format(time.DateOnly))
+	}
+	if days := simulation.To.Sub(simulation.From).Hours()/24 + 1; days > maxBillDays {
+		return domain.Bill{}, errors.NewDomainError(errors.InvalidDateRange, "bill can't be longer than %d days", maxBillDays)
+	}
+
+	loc, err := zoneLocation(ctx, s.zonesRepository, simulation.ZoneID)
+	if err != nil {
+		return domain.Bill{}, err
+	}
+	consumptionByDay, err := groupConsumptionByDay(simulation.Consumption, simulation.From, simulation.To, loc)
+	if err != nil {
+		return domain.Bill{}, err
+	}
+
+	var segments []*billSegment
+	for day := simulation.From; !day.After(simulation.To); day = day.AddDate(0, 0, 1) {
+		rates, ok := s.ratesSchedule.RatesOn(day)
+		if !ok {
+			return domain.Bill{}, errors.NewDomainError(errors.BillRatesNotFound, "bill rates not found for %s", day.Format(time.DateOnly))
+		}
+
+		kwh, energy, err := s.dayEnergyCost(ctx, simulation.ZoneID, day, consumptionByDay[day.Format(time.DateOnly)])
+		if err != nil {
+			return domain.Bill{}, err
+		}
+
+		if len(segments) == 0 || !segments[len(segments)-1].rates.ValidFrom().Equal(rates.ValidFrom()) {
+			segments = append(segments, &billSegment{rates: rates, from: day})
+		}
+		segment := segments[len(segments)-1]
+		segment.to = day
+		segment.days++
+		segment.kwh = segment.kwh.Add(kwh)
+		segment.energy = segment.energy.Add(energy)
+	}
+
+	billDto := domain.BillDto{
+		ZoneID: simulation.ZoneID.String(),
+		From:   simulation.From.Format(time.DateOnly),
+		To:     simulation.To.Format(time.DateOnly),
+	}
+	for _, segment := range segments {
+		billDto.Lines = append(billDto.Lines, segment.lines(simulation.ContractedPower)...)
+	}
+
+	return domain.NewBill(billDto)
+}
+
+// dayEnergyCost returns the kWh consumed on day and their exact cost at the zone's PVPC prices.
+func (s BillsService) dayEnergyCost(ctx context.Context, zoneID domain.ZoneID, day time.Time, consumption []domain.HourlyConsumption) (domain.Decimal, domain.Decimal, error) {
+	var kwh, energy domain.Decimal
+	if len(consumption) == 0 {
+		return kwh, energy, nil
+	}
+
+	prices, err := s.pricesRepository.Query(ctx, &zoneID, &day)
+	if err != nil {
+		return kwh, energy, err
+	}
+	pricesByHour := make(map[int64]domain.EnergyPrice)
+	if zonePrices, ok := findZonePrices(prices, zoneID); ok {
+		for _, value := range zonePrices.Values() {
+			pricesByHour[value.Datetime().Unix()] = value.Price()
+		}
+	}
+
+	for _, c := range consumption {
+		price, ok := pricesByHour[c.Datetime().Unix()]
+		if !ok {
+			return domain.Decimal{}, domain.Decimal{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s at %s", zoneID.String(), c.Datetime().Format(time.RFC3339))
+		}
+		consumed := domain.NewDecimalFromFloat(c.Kwh())
+		kwh = kwh.Add(consumed)
+		energy = energy.Add(price.CostOf(consumed))
+	}
+
+	return kwh, energy, nil
+}
+
+// lines returns the bill lines of the segment. Every amount is computed exactly and rounded to cents,
+// and taxes are computed over the already rounded amounts, as in real bills.
+func (b *billSegment) lines(power domain.ContractedPower) []domain.BillLineDto {
+	from, to := b.from.Format(time.DateOnly), b.to.Format(time.DateOnly)
+	days := domain.NewDecimal(int64(b.days), 0)
+	powerP1Days := domain.NewDecimalFromFloat(power.P1()).Mul(days)
+	powerP2Days := domain.NewDecimalFromFloat(power.P2()).Mul(days)
+
+	energy := b.energy.Round(2)
+	energyPrice := b.energy.Div(b.kwh, 6)
+	powerP1 := powerP1Days.Mul(b.rates.PowerP1()).Round(2)
+	powerP2 := powerP2Days.Mul(b.rates.PowerP2()).Round(2)
+	taxBase := energy.Add(powerP1).Add(powerP2)
+	tax := taxBase.Mul(b.rates.ElectricityTax())
+	if minimumTax := b.kwh.Shift(-3).Mul(b.rates.ElectricityTaxMinimum()); minimumTax.Cmp(tax) > 0 {
+		tax = minimumTax
+	}
+	tax = tax.Round(2)
+	meterRental := days.Mul(b.rates.MeterRental()).Round(2)
+	vatBase := taxBase.Add(tax).Add(meterRental)
+	vat := vatBase.Mul(b.rates.VAT()).Round(2)
+
+	return []domain.BillLineDto{
+		{Concept: domain.BillConceptEnergy.String(), From: from, To: to, Quantity: b.kwh.Round(3).Float64(), Unit: "kWh", Price: energyPrice.Float64(), Amount: energy.Float64()},
+		{Concept: domain.BillConceptPowerP1.String(), From: from, To: to, Quantity: powerP1Days.Round(3).Float64(), Unit: "kW·day", Price: b.rates.PowerP1().Float64(), Amount: powerP1.Float64()},
+		{Concept: domain.BillConceptPowerP2.String(), From: from, To: to, Quantity: powerP2Days.Round(3).Float64(), Unit: "kW·day", Price: b.rates.PowerP2().Float64(), Amount: powerP2.Float64()},
+		{Concept: domain.BillConceptElectricityTax.String(), From: from, To: to, Quantity: taxBase.Float64(), Unit: "EUR", Price: b.rates.ElectricityTax().Float64(), Amount: tax.Float64()},
+		{Concept: domain.BillConceptMeterRental.String(), From: from, To: to, Quantity: days.Float64(), Unit: "day", Price: b.rates.MeterRental().Float64(), Amount: meterRental.Float64()},
+		{Concept: domain.BillConceptVAT.String(), From: from, To: to, Quantity: vatBase.Float64(), Unit: "EUR", Price: b.rates.VAT().Float64(), Amount: vat.Float64()},
+	}
+}
+
+// groupConsumptionByDay groups the consumption by its day (YYYY-MM-DD) in loc.
+// It returns an InvalidConsumption error if an hour is repeated or out of the from-to days.
+func groupConsumptionByDay(consumption []domain.HourlyConsumption, from, to time.Time, loc *time.Location) (map[string][]domain.HourlyConsumption, error) {
+	first, last := from.Format(time.DateOnly), to.Format(time.DateOnly)
+
+	byDay := make(map[string][]domain.HourlyConsumption)
+	seen := make(map[int64]bool, len(consumption))
+	for _, c := range consumption {
+		day := c.Datetime().In(loc).Format(time.DateOnly)
+		if day < first || day > last {
+			return nil, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s is out of the billing period", c.Datetime().Format(time.RFC3339))
+		}
+		if seen[c.Datetime().Unix()] {
+			return nil, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s is repeated", c.Datetime().Format(time.RFC3339))
+		}
+		seen[c.Datetime().Unix()] = true
+		byDay[day] = append(byDay[day], c)
+	}
+
+	return byDay, nil
+}
+
+// ReadBillRatesFile reads the bill rates schedule from a JSON file shaped as [{"valid_from": "YYYY-MM-DD",
+// "valid_to": "YYYY-MM-DD", "power_p1": ..., "power_p2": ..., "electricity_tax": ..., "electricity_tax_minimum": ...,
+// "meter_rental": ..., "vat": ...}]. An empty path returns an empty schedule.
+func ReadBillRatesFile(path string) (domain.BillRatesSchedule, error) {
+	if path == "" {
+		return domain.NewBillRatesSchedule(nil)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return domain.BillRatesSchedule{}, errors.WrapIntoDomainError(err, errors.InternalError, "error reading bill rates file")
+	}
+
+	var dtos []struct {
+		ValidFrom             string  `json:"valid_from"`
+		ValidTo               string  `json:"valid_to"`
+		PowerP1               float64 `json:"power_p1"`
+		PowerP2               float64 `json:"power_p2"`
+		ElectricityTax        float64 `json:"electricity_tax"`
+		ElectricityTaxMinimum float64 `json:"electricity_tax_minimum"`
+		MeterRental           float64 `json:"meter_rental"`
+		VAT                   float64 `json:"vat"`
+	}
+	if err := json.Unmarshal(content, &dtos); err != nil {
+		return domain.BillRatesSchedule{}, errors.WrapIntoDomainError(err, errors.InvalidBillRates, "error parsing bill rates file")
+	}
+
+	rates := make([]domain.BillRates, len(dtos))
+	for i, dto := range dtos {
+		r, err := domain.NewBillRates(domain.BillRatesDto(dto))
+		if err != nil {
+			return domain.BillRatesSchedule{}, err
+		}
+		rates[i] = r
+	}
+
+	return domain.NewBillRatesSchedule(rates)
+}
+
+// round rounds x to the given number of decimals.
+func round(x float64, decimals int) float64 {
+	pow := math.Pow(10, float64(decimals))
+	return math.Round(x*pow) / pow
+}
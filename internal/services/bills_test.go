@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_BillsService_SimulateBill(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(zone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	for _, dto := range []domain.PricesDto{
+		{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto, Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-11T10:00:00+02:00", Value: 200},
+		}},
+		{ID: "PEN-2023-10-12", Date: "2023-10-12T00:00:00+02:00", Zone: zoneDto, Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-12T03:00:00+02:00", Value: 100},
+		}},
+		{ID: "PEN-2023-10-13", Date: "2023-10-13T00:00:00+02:00", Zone: zoneDto, Values: []domain.HourlyPriceDto{
+			{Datetime: "2023-10-13T10:00:00+02:00", Value: 275},
+			{Datetime: "2023-10-13T11:00:00+02:00", Value: 275},
+		}},
+	} {
+		prices, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+	}
+
+	consumption := []domain.HourlyConsumptionDto{
+		{Datetime: "2023-10-11T10:00:00+02:00", Kwh: 1.5},
+		{Datetime: "2023-10-12T03:00:00+02:00", Kwh: 2.5},
+	}
+
+	t.Run("bills the energy at the hourly prices and the rest of concepts with the rates", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		bill, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-12", consumption...))
+
+		require.NoError(t, err)
+		require.Equal(t, domain.BillDto{ZoneID: "PEN", From: "2023-10-11", To: "2023-10-12", Lines: []domain.BillLineDto{
+			{Concept: "energy", From: "2023-10-11", To: "2023-10-12", Quantity: 4, Unit: "kWh", Price: 0.1375, Amount: 0.55},
+			{Concept: "power_p1", From: "2023-10-11", To: "2023-10-12", Quantity: 9.2, Unit: "kW·day", Price: 0.1, Amount: 0.92},
+			{Concept: "power_p2", From: "2023-10-11", To: "2023-10-12", Quantity: 9.2, Unit: "kW·day", Price: 0.01, Amount: 0.09},
+			{Concept: "electricity_tax", From: "2023-10-11", To: "2023-10-12", Quantity: 1.56, Unit: "EUR", Price: 0.05, Amount: 0.08},
+			{Concept: "meter_rental", From: "2023-10-11", To: "2023-10-12", Quantity: 2, Unit: "day", Price: 0.02, Amount: 0.04},
+			{Concept: "vat", From: "2023-10-11", To: "2023-10-12", Quantity: 1.68, Unit: "EUR", Price: 0.1, Amount: 0.17},
+		}}, bill.Serialize())
+		require.Equal(t, 1.85, bill.Total())
+	})
+
+	t.Run("the energy cost is computed exactly and only rounded once to cents", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		bill, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-13", "2023-10-13",
+			domain.HourlyConsumptionDto{Datetime: "2023-10-13T10:00:00+02:00", Kwh: 2.3},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-13T11:00:00+02:00", Kwh: 2.3},
+		))
+
+		require.NoError(t, err)
+		// 2 × 2.3 kWh × 0.275 €/kWh is 1.265 €, which float64 arithmetic makes 1.2649999999999999.
+		require.Equal(t, domain.BillLineDto{Concept: "energy", From: "2023-10-13", To: "2023-10-13", Quantity: 4.6, Unit: "kWh", Price: 0.275, Amount: 1.27}, bill.Serialize().Lines[0])
+	})
+
+	t.Run("the rates lines are computed exactly before being rounded to cents", func(t *testing.T) {
+		rates, err := domain.NewBillRates(domain.BillRatesDto{ValidFrom: "2023-01-01", MeterRental: 0.0435})
+		require.NoError(t, err)
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, rates))
+
+		bill, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-01", "2023-10-10"))
+
+		require.NoError(t, err)
+		// 10 days × 0.0435 €/day is 0.435 €, which float64 arithmetic makes 0.43499999999999994.
+		require.Equal(t, domain.BillLineDto{Concept: "meter_rental", From: "2023-10-01", To: "2023-10-10", Quantity: 10, Unit: "day", Price: 0.0435, Amount: 0.44}, bill.Serialize().Lines[4])
+	})
+
+	t.Run("bills every period of validity of the rates apart", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "2023-10-11", 0.05), newTestBillRates(t, "2023-10-12", "", 0.21)))
+
+		bill, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-12", consumption...))
+
+		require.NoError(t, err)
+		lines := bill.Serialize().Lines
+		require.Len(t, lines, 12)
+		require.Equal(t, domain.BillLineDto{Concept: "energy", From: "2023-10-11", To: "2023-10-11", Quantity: 1.5, Unit: "kWh", Price: 0.2, Amount: 0.3}, lines[0])
+		require.Equal(t, domain.BillLineDto{Concept: "vat", From: "2023-10-11", To: "2023-10-11", Quantity: 0.87, Unit: "EUR", Price: 0.05, Amount: 0.04}, lines[5])
+		require.Equal(t, domain.BillLineDto{Concept: "energy", From: "2023-10-12", To: "2023-10-12", Quantity: 2.5, Unit: "kWh", Price: 0.1, Amount: 0.25}, lines[6])
+		require.Equal(t, domain.BillLineDto{Concept: "vat", From: "2023-10-12", To: "2023-10-12", Quantity: 0.82, Unit: "EUR", Price: 0.21, Amount: 0.17}, lines[11])
+	})
+
+	t.Run("when an hour with consumption has no price, it returns a prices not found error", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		_, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-11",
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T11:00:00+02:00", Kwh: 1}))
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+
+	t.Run("when a day has no rates, it returns a bill rates not found error", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-10-12", "", 0.1)))
+
+		_, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-12", consumption...))
+
+		require.Equal(t, errors.BillRatesNotFound, errors.Code(err))
+	})
+
+	t.Run("when consumption is out of the billing period, it returns an invalid consumption error", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		_, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-11", consumption...))
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when an hour is repeated, it returns an invalid consumption error", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		_, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-11", "2023-10-12", consumption[0], consumption[0]))
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when the billing period is inverted or too long, it returns an invalid date range error", func(t *testing.T) {
+		service := NewBillsService(pricesRepository, zonesRepository, newTestBillRatesSchedule(t, newTestBillRates(t, "2023-01-01", "", 0.1)))
+
+		_, err := service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2023-10-12", "2023-10-11"))
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+
+		_, err = service.SimulateBill(context.Background(), newTestBillSimulation(t, zone.ID(), "2022-10-11", "2023-10-12"))
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
+
+func Test_ReadBillRatesFile(t *testing.T) {
+	t.Run("when the file is valid, it returns its rates schedule", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bill_rates.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[
+			{"valid_from": "2024-01-01", "power_p1": 0.08, "power_p2": 0.004, "electricity_tax": 0.025, "electricity_tax_minimum": 1, "meter_rental": 0.0266, "vat": 0.1},
+			{"valid_from": "2023-01-01", "valid_to": "2023-12-31", "power_p1": 0.08, "power_p2": 0.004, "electricity_tax": 0.005, "electricity_tax_minimum": 0.5, "meter_rental": 0.0266, "vat": 0.05}
+		]`), 0o600))
+
+		schedule, err := ReadBillRatesFile(path)
+
+		require.NoError(t, err)
+		rates, ok := schedule.RatesOn(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.True(t, ok)
+		require.Equal(t, 0.05, rates.VAT().Float64())
+		rates, ok = schedule.RatesOn(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.True(t, ok)
+		require.Equal(t, 0.1, rates.VAT().Float64())
+		_, ok = schedule.RatesOn(time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.False(t, ok)
+	})
+
+	t.Run("when periods of validity overlap, it returns an invalid bill rates error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bill_rates.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"valid_from": "2023-01-01", "vat": 0.05}, {"valid_from": "2023-06-01", "vat": 0.1}]`), 0o600))
+
+		_, err := ReadBillRatesFile(path)
+
+		require.Equal(t, errors.InvalidBillRates, errors.Code(err))
+	})
+
+	t.Run("the bill rates file shipped in config is valid", func(t *testing.T) {
+		_, err := ReadBillRatesFile(filepath.Join("..", "..", "config", "bill_rates.json"))
+
+		require.NoError(t, err)
+	})
+
+	t.Run("when no file is configured, it returns an empty schedule", func(t *testing.T) {
+		schedule, err := ReadBillRatesFile("")
+
+		require.NoError(t, err)
+		_, ok := schedule.RatesOn(time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC))
+		require.False(t, ok)
+	})
+}
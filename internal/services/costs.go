@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// maxCostDays is the longest period whose consumption cost can be calculated.
+const maxCostDays = 366
+
+// MissingPricesPolicy is how a cost calculation handles the hours with consumption but without a stored price.
+type MissingPricesPolicy string
+
+const (
+	// MissingPricesFail fails the calculation with a PricesNotFound error. It's the default policy.
+	MissingPricesFail MissingPricesPolicy = "fail"
+	// MissingPricesSkip leaves the hours out of the cost and the average price, reporting them as missing.
+	MissingPricesSkip MissingPricesPolicy = "skip"
+)
+
+// NewMissingPricesPolicy returns the MissingPricesPolicy of value. An empty value is the default MissingPricesFail policy.
+func NewMissingPricesPolicy(value string) (MissingPricesPolicy, error) {
+	switch MissingPricesPolicy(value) {
+	case "", MissingPricesFail:
+		return MissingPricesFail, nil
+	case MissingPricesSkip:
+		return MissingPricesSkip, nil
+	default:
+		return "", errors.NewDomainError(errors.InvalidRequestBody, "invalid missing prices policy: %s. It must be one of: %s, %s", value, MissingPricesFail, MissingPricesSkip)
+	}
+}
+
+// CostsService is the domain service that calculates the cost of a load profile at the stored PVPC prices.
+type CostsService struct {
+	pricesRepository domain.PricesRepository
+	zonesRepository  domain.ZonesRepository
+}
+
+// NewCostsService returns a new CostsService.
+func NewCostsService(pricesRepository domain.PricesRepository, zonesRepository domain.ZonesRepository) CostsService {
+	return CostsService{
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
+	}
+}
+
+// CostReport is the cost of a load profile: the cost of every hour, their totals and the effective
+// average price (€/MWh) of the costed energy, nil if no energy was costed.
+type CostReport struct {
+	ZoneID       domain.ZoneID
+	Hours        []HourlyCost
+	TotalKwh     float64
+	CostedKwh    float64
+	TotalCost    float64
+	AveragePrice *float64
+	MissingHours int
+}
+
+// CalculateCost returns the cost of the given consumption in zoneID, handling the hours without a stored
+// price as missingPrices says. Hours are returned sorted by datetime.
+func (s CostsService) CalculateCost(ctx context.Context, zoneID domain.ZoneID, consumption []domain.HourlyConsumption, missingPrices MissingPricesPolicy) (CostReport, error) {
+	if len(consumption) == 0 {
+		return CostReport{}, errors.NewDomainError(errors.InvalidConsumption, "there is no consumption to calculate its cost")
+	}
+	zone, err := s.zonesRepository.GetByID(ctx, zoneID)
+	if err != nil {
+		return CostReport{}, err
+	}
+
+	sorted := make([]domain.HourlyConsumption, len(consumption))
+	copy(sorted, consumption)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Datetime().Before(sorted[j].Datetime())
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Datetime().Equal(sorted[i-1].Datetime()) {
+			return CostReport{}, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s is repeated", sorted[i].Datetime().Format(time.RFC3339))
+		}
+	}
+	if days := sorted[len(sorted)-1].Datetime().Sub(sorted[0].Datetime()).Hours() / 24; days > maxCostDays {
+		return CostReport{}, errors.NewDomainError(errors.InvalidDateRange, "consumption can't span more than %d days", maxCostDays)
+	}
+
+	prices, err := consumptionPrices(ctx, s.pricesRepository, zoneID, zone.Location(), sorted)
+	if err != nil {
+		return CostReport{}, err
+	}
+
+	report := CostReport{ZoneID: zoneID, Hours: make([]HourlyCost, len(sorted))}
+	var costedKwh, totalCost domain.Decimal // exact, so they're only rounded once
+	for i, c := range sorted {
+		report.Hours[i] = HourlyCost{Datetime: c.Datetime(), Kwh: c.Kwh()}
+		report.TotalKwh += c.Kwh()
+
+		price, ok := prices[c.Datetime().Unix()]
+		if !ok {
+			if missingPrices == MissingPricesFail {
+				return CostReport{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s at %s", zoneID.String(), c.Datetime().Format(time.RFC3339))
+			}
+			report.MissingHours++
+			continue
+		}
+		kwh := domain.NewDecimalFromFloat(c.Kwh())
+		cost := price.CostOf(kwh)
+		totalCost = totalCost.Add(cost)
+		costedKwh = costedKwh.Add(kwh)
+		value, roundedCost := price.In(domain.MWh).Amount().Float64(), cost.Round(6).Float64()
+		report.Hours[i].Price = &value
+		report.Hours[i].Cost = &roundedCost
+	}
+
+	report.TotalKwh = round(report.TotalKwh, 3)
+	report.CostedKwh = costedKwh.Round(3).Float64()
+	report.TotalCost = totalCost.Round(2).Float64()
+	if !costedKwh.IsZero() {
+		averagePrice := totalCost.Shift(3).Div(costedKwh, 2).Float64() // €/MWh
+		report.AveragePrice = &averagePrice
+	}
+
+	return report, nil
+}
+
+// DailyProfileConsumption returns the hourly consumption of repeating the daily profile kwh, the consumption
+// of each of the 24 hours of the local day, from from to to (YYYY-MM-DD days in the zone local time, both included).
+// On DST change days, the skipped hour is left out and the repeated one consumes twice.
+func (s CostsService) DailyProfileConsumption(ctx context.Context, zoneID domain.ZoneID, from, to time.Time, kwh []float64) ([]domain.HourlyConsumption, error) {
+	return dailyProfileConsumption(ctx, s.zonesRepository, zoneID, from, to, kwh)
+}
+
+// dailyProfileConsumption is DailyProfileConsumption, with the zone got from zonesRepository.
+func dailyProfileConsumption(ctx context.Context, zonesRepository domain.ZonesRepository, zoneID domain.ZoneID, from, to time.Time, kwh []float64) ([]domain.HourlyConsumption, error) {
+	if len(kwh) != 24 {
+		return nil, errors.NewDomainError(errors.InvalidConsumption, "daily profile must have 24 hours, it has %d", len(kwh))
+	}
+	if to.Before(from) {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "profile from %s is after to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	if days := to.Sub(from).Hours()/24 + 1; days > maxCostDays {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "profile can't be longer than %d days", maxCostDays)
+	}
+
+	loc, err := zoneLocation(ctx, zonesRepository, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+
+	var consumption []domain.HourlyConsumption
+	for hour := start; hour.Before(end); hour = hour.Add(time.Hour) {
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: hour.Format(time.RFC3339), Kwh: kwh[hour.Hour()]})
+		if err != nil {
+			return nil, err
+		}
+		consumption = append(consumption, c)
+	}
+
+	return consumption, nil
+}
+
+// consumptionPrices returns the zone's PVPC price of every stored hour of the days with consumption, by its Unix time.
+// Days are the ones of loc, the zone's time zone.
+func consumptionPrices(ctx context.Context, pricesRepository domain.PricesRepository, zoneID domain.ZoneID, loc *time.Location, consumption []domain.HourlyConsumption) (map[int64]domain.EnergyPrice, error) {
+	prices := make(map[int64]domain.EnergyPrice)
+	queried := make(map[string]bool)
+	for _, c := range consumption {
+		day := c.Datetime().In(loc).Format(time.DateOnly)
+		if queried[day] {
+			continue
+		}
+		queried[day] = true
+
+		date, _ := time.Parse(time.DateOnly, day)
+		dayPrices, err := pricesRepository.Query(ctx, &zoneID, &date)
+		if err != nil {
+			return nil, err
+		}
+		if zonePrices, ok := findZonePrices(dayPrices, zoneID); ok {
+			for _, value := range zonePrices.Values() {
+				prices[value.Datetime().Unix()] = value.Price()
+			}
+		}
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_CostsService_CalculateCost(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	canDto := domain.ZoneDto{ID: "CAN", ExternalID: "8742", Name: "Canarias", TimeZone: "Atlantic/Canary"}
+	can, err := domain.NewZone(canDto)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(zone, can)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	canPrices, err := domain.NewPrices(domain.PricesDto{ID: "CAN-2023-10-11", Date: "2023-10-11T00:00:00+01:00", Zone: canDto, Values: []domain.HourlyPriceDto{
+		{Datetime: "2023-10-11T22:00:00+01:00", Value: 100},
+		{Datetime: "2023-10-11T23:00:00+01:00", Value: 300},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{canPrices}))
+	prices, err := domain.NewPrices(domain.PricesDto{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto, Values: []domain.HourlyPriceDto{
+		{Datetime: "2023-10-11T10:00:00+02:00", Value: 200},
+		{Datetime: "2023-10-11T11:00:00+02:00", Value: 100},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+	service := NewCostsService(pricesRepository, zonesRepository)
+
+	t.Run("returns the cost of every hour, the total and the average price", func(t *testing.T) {
+		report, err := service.CalculateCost(context.Background(), zone.ID(), newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T11:00:00+02:00", Kwh: 3},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T10:00:00+02:00", Kwh: 1},
+		), MissingPricesFail)
+
+		require.NoError(t, err)
+		require.Len(t, report.Hours, 2)
+		require.Equal(t, "2023-10-11T08:00:00Z", report.Hours[0].Datetime.UTC().Format(time.RFC3339))
+		require.Equal(t, 0.2, *report.Hours[0].Cost)
+		require.Equal(t, 0.3, *report.Hours[1].Cost)
+		require.Equal(t, 4.0, report.TotalKwh)
+		require.Equal(t, 4.0, report.CostedKwh)
+		require.Equal(t, 0.5, report.TotalCost)
+		require.Equal(t, 125.0, *report.AveragePrice)
+		require.Zero(t, report.MissingHours)
+	})
+
+	t.Run("hours are priced with the prices of their day in the zone's time zone", func(t *testing.T) {
+		// 23:00 in the Canary Islands is already the next day in the peninsula.
+		report, err := service.CalculateCost(context.Background(), can.ID(), newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T22:00:00+01:00", Kwh: 1},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T23:00:00+01:00", Kwh: 1},
+		), MissingPricesFail)
+
+		require.NoError(t, err)
+		require.Equal(t, 0.1, *report.Hours[0].Cost)
+		require.Equal(t, 0.3, *report.Hours[1].Cost)
+		require.Equal(t, 0.4, report.TotalCost)
+	})
+
+	t.Run("when an hour has no price, it fails or skips it as the policy says", func(t *testing.T) {
+		consumption := newTestConsumption(t,
+			domain.HourlyConsumptionDto{Datetime: "2023-10-11T10:00:00+02:00", Kwh: 1},
+			domain.HourlyConsumptionDto{Datetime: "2023-10-12T10:00:00+02:00", Kwh: 2},
+		)
+
+		_, err := service.CalculateCost(context.Background(), zone.ID(), consumption, MissingPricesFail)
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+
+		report, err := service.CalculateCost(context.Background(), zone.ID(), consumption, MissingPricesSkip)
+		require.NoError(t, err)
+		require.Equal(t, 3.0, report.TotalKwh)
+		require.Equal(t, 1.0, report.CostedKwh)
+		require.Equal(t, 0.2, report.TotalCost)
+		require.Equal(t, 200.0, *report.AveragePrice)
+		require.Equal(t, 1, report.MissingHours)
+		require.Nil(t, report.Hours[1].Price)
+
+		report, err = service.CalculateCost(context.Background(), zone.ID(), consumption[1:], MissingPricesSkip)
+		require.NoError(t, err)
+		require.Nil(t, report.AveragePrice)
+	})
+
+	t.Run("when the consumption is empty or repeated, it returns an invalid consumption error", func(t *testing.T) {
+		_, err := service.CalculateCost(context.Background(), zone.ID(), nil, MissingPricesFail)
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+
+		consumption := newTestConsumption(t, domain.HourlyConsumptionDto{Datetime: "2023-10-11T10:00:00+02:00", Kwh: 1})
+		_, err = service.CalculateCost(context.Background(), zone.ID(), append(consumption, consumption...), MissingPricesFail)
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when the zone is unknown, it returns a zone not found error", func(t *testing.T) {
+		unknown, err := domain.NewZoneID("BAL")
+		require.NoError(t, err)
+
+		_, err = service.CalculateCost(context.Background(), unknown, newTestConsumption(t, domain.HourlyConsumptionDto{Datetime: "2023-10-11T10:00:00+02:00", Kwh: 1}), MissingPricesFail)
+
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+	})
+}
+
+func Test_CostsService_DailyProfileConsumption(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	pen, err := domain.NewZone(domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península", TimeZone: "Europe/Madrid"})
+	require.NoError(t, err)
+	can, err := domain.NewZone(domain.ZoneDto{ID: "CAN", ExternalID: "8742", Name: "Canarias", TimeZone: "Atlantic/Canary"})
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(pen, can)
+	service := NewCostsService(inmemory.NewPricesRepository(zonesRepository), zonesRepository)
+	zoneID := pen.ID()
+	profile := make([]float64, 24)
+	for i := range profile {
+		profile[i] = float64(i)
+	}
+	t.Run("repeats the profile every local day, also on DST change days", func(t *testing.T) {
+		consumption, err := service.DailyProfileConsumption(context.Background(), zoneID, parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-29"), profile)
+
+		require.NoError(t, err)
+		require.Len(t, consumption, 49)
+		require.Equal(t, "2023-10-27T22:00:00Z", consumption[0].Datetime().UTC().Format(time.RFC3339))
+		require.Equal(t, 23.0, consumption[23].Kwh())
+		require.Equal(t, 2.0, consumption[26].Kwh())
+		require.Equal(t, 2.0, consumption[27].Kwh())
+		require.Equal(t, 3.0, consumption[28].Kwh())
+	})
+
+	t.Run("days are the local days of the zone's time zone", func(t *testing.T) {
+		consumption, err := service.DailyProfileConsumption(context.Background(), can.ID(), parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-28"), profile)
+
+		require.NoError(t, err)
+		require.Len(t, consumption, 24)
+		require.Equal(t, "2023-10-27T23:00:00Z", consumption[0].Datetime().UTC().Format(time.RFC3339))
+	})
+
+	t.Run("when the zone doesn't exist, it returns a zone not found error", func(t *testing.T) {
+		_, err := service.DailyProfileConsumption(context.Background(), domain.ZoneID{}, parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-28"), profile)
+
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+	})
+
+	t.Run("when the profile hasn't 24 hours, it returns an invalid consumption error", func(t *testing.T) {
+		_, err := service.DailyProfileConsumption(context.Background(), zoneID, parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-28"), profile[1:])
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when the range is inverted or too long, it returns an invalid date range error", func(t *testing.T) {
+		_, err := service.DailyProfileConsumption(context.Background(), zoneID, parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-27"), profile)
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+
+		_, err = service.DailyProfileConsumption(context.Background(), zoneID, parseTestDate(t, "2022-10-27"), parseTestDate(t, "2023-10-28"), profile)
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
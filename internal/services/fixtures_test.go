@@ -0,0 +1,135 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+)
+
+// newTestPrices returns the prices of pricesType of zone for the day starting at day, with one value
+// for each hour from then.
+func newTestPrices(t *testing.T, pricesType domain.PricesType, zone domain.ZoneDto, day time.Time, values ...float64) domain.Prices {
+	t.Helper()
+	dto := domain.PricesDto{
+		ID:   zone.ID + "-" + day.Format(time.DateOnly),
+		Type: pricesType.String(),
+		Zone: zone,
+		Date: day.Format(time.RFC3339),
+	}
+	for hour, value := range values {
+		datetime := day.Add(time.Duration(hour) * time.Hour)
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: value})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return prices
+}
+
+// testDayValues returns the 24 hourly values of a day, from base rising step every hour.
+func testDayValues(base, step float64) []float64 {
+	values := make([]float64, 24)
+	for hour := range values {
+		values[hour] = base + float64(hour)*step
+	}
+	return values
+}
+
+// withTestProvenance returns a copy of prices fetched from provider.
+func withTestProvenance(t *testing.T, prices domain.Prices, provider string) domain.Prices {
+	t.Helper()
+	dto := prices.Serialize()
+	dto.Date = prices.Date().Format(time.RFC3339)
+	dto.Provenance = &domain.ProvenanceDto{Provider: provider, FetchedAt: "2023-09-08T10:00:00Z"}
+	fetched, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	return fetched
+}
+
+func newTestConsumption(t *testing.T, dtos ...domain.HourlyConsumptionDto) []domain.HourlyConsumption {
+	t.Helper()
+	consumption := make([]domain.HourlyConsumption, len(dtos))
+	for i, dto := range dtos {
+		var err error
+		consumption[i], err = domain.NewHourlyConsumption(dto)
+		require.NoError(t, err)
+	}
+	return consumption
+}
+
+// newTestBillRates returns the rates valid from validFrom to validTo, with the given VAT and
+// the same charges every time.
+func newTestBillRates(t *testing.T, validFrom, validTo string, vat float64) domain.BillRates {
+	t.Helper()
+	rates, err := domain.NewBillRates(domain.BillRatesDto{
+		ValidFrom: validFrom, ValidTo: validTo,
+		PowerP1: 0.1, PowerP2: 0.01, ElectricityTax: 0.05, ElectricityTaxMinimum: 1, MeterRental: 0.02, VAT: vat,
+	})
+	require.NoError(t, err)
+	return rates
+}
+
+func newTestBillRatesSchedule(t *testing.T, rates ...domain.BillRates) domain.BillRatesSchedule {
+	t.Helper()
+	schedule, err := domain.NewBillRatesSchedule(rates)
+	require.NoError(t, err)
+	return schedule
+}
+
+// newTestBillSimulation returns the simulation of the bill of zoneID from from to to (YYYY-MM-DD),
+// with 4.6 kW contracted in both periods.
+func newTestBillSimulation(t *testing.T, zoneID domain.ZoneID, from, to string, consumption ...domain.HourlyConsumptionDto) BillSimulation {
+	t.Helper()
+	power, err := domain.NewContractedPower(4.6, 4.6)
+	require.NoError(t, err)
+	return BillSimulation{
+		ZoneID:          zoneID,
+		From:            parseTestDate(t, from),
+		To:              parseTestDate(t, to),
+		ContractedPower: power,
+		Consumption:     newTestConsumption(t, consumption...),
+	}
+}
+
+func newTestBattery(t *testing.T, efficiency float64) domain.Battery {
+	t.Helper()
+	battery, err := domain.NewBattery(domain.BatteryDto{CapacityKwh: 10, ChargePowerKw: 5, DischargePowerKw: 5, RoundTripEfficiency: efficiency})
+	require.NoError(t, err)
+	return battery
+}
+
+func newIndicatorID(t *testing.T, value string) domain.IndicatorID {
+	t.Helper()
+	id, err := domain.NewIndicatorID(value)
+	require.NoError(t, err)
+	return id
+}
+
+// newTestIndicator returns the indicator id with one value of the España geo for each hour from day.
+func newTestIndicator(t *testing.T, id string, day time.Time, values ...float64) domain.Indicator {
+	t.Helper()
+	dto := domain.IndicatorDto{ID: id, Name: "Indicator " + id}
+	for hour, value := range values {
+		datetime := day.Add(time.Duration(hour) * time.Hour)
+		dto.Values = append(dto.Values, domain.IndicatorValueDto{Datetime: datetime.Format(time.RFC3339), GeoID: "3", GeoName: "España", Value: value})
+	}
+	indicator, err := domain.NewIndicator(dto)
+	require.NoError(t, err)
+	return indicator
+}
+
+func parseTestDate(t *testing.T, value string) time.Time {
+	t.Helper()
+	date, err := time.Parse(time.DateOnly, value)
+	require.NoError(t, err)
+	return date
+}
+
+func parseTestDatetime(t *testing.T, value string) time.Time {
+	t.Helper()
+	datetime, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+	return datetime
+}
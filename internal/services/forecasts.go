@@ -0,0 +1,407 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+const (
+	// forecastHistoryDays is the number of days before the forecast day the models are trained on.
+	forecastHistoryDays = 35
+	// minForecastHistoryDays is the number of those days that must have stored prices to forecast.
+	minForecastHistoryDays = 7
+	// forecastBacktestDays is the number of the most recent days with prices the models are evaluated on,
+	// forecasting each one with the days before it, to pick the best model and size the confidence bands.
+	forecastBacktestDays = 7
+	// maxForecastHorizonDays is how many days after today a forecast can be asked for.
+	maxForecastHorizonDays = 7
+	// forecastSmoothingAlpha is the weight of the newest day in the exponential smoothing model.
+	forecastSmoothingAlpha = 0.3
+	// forecastConfidenceZ is the normal quantile of ForecastConfidence.
+	forecastConfidenceZ = 1.96
+	// maxAccuracyRange is the longest range of days the accuracy of the forecasts can be listed for.
+	maxAccuracyRange = 366
+)
+
+// ForecastConfidence is the probability that the real price of an hour falls within its forecast band,
+// assuming the forecast errors are normally distributed like the ones of the backtest.
+const ForecastConfidence = 0.95
+
+// ForecastsService is the domain service that forecasts the PVPC prices of a day before REE publishes them,
+// using simple statistical models trained on the stored prices, and measures them against the real prices.
+type ForecastsService struct {
+	pricesRepository    domain.PricesRepository
+	forecastsRepository domain.ForecastsRepository
+	zonesRepository     domain.ZonesRepository
+}
+
+// NewForecastsService returns a new ForecastsService.
+func NewForecastsService(pricesRepository domain.PricesRepository, forecastsRepository domain.ForecastsRepository, zonesRepository domain.ZonesRepository) ForecastsService {
+	return ForecastsService{
+		pricesRepository:    pricesRepository,
+		forecastsRepository: forecastsRepository,
+		zonesRepository:     zonesRepository,
+	}
+}
+
+// PricesForecast is a Forecast along with the errors its model made in the backtest, in €/MWh.
+type PricesForecast struct {
+	Forecast     domain.Forecast
+	BacktestMAE  float64
+	BacktestRMSE float64
+}
+
+// historyDay are the stored prices of a day by local hour of the day. On the day the clocks go back,
+// the repeated hour keeps its first price.
+type historyDay struct {
+	date   time.Time
+	prices map[int]float64
+}
+
+// forecastErrors are the errors of a model over a set of hours.
+type forecastErrors struct {
+	hours int
+	abs   float64
+	sq    float64
+	sum   float64
+}
+
+func (e *forecastErrors) add(forecast, actual float64) {
+	diff := forecast - actual
+	e.hours++
+	e.abs += math.Abs(diff)
+	e.sq += diff * diff
+	e.sum += diff
+}
+
+func (e forecastErrors) mae() float64 {
+	return e.abs / float64(e.hours)
+}
+
+func (e forecastErrors) rmse() float64 {
+	return math.Sqrt(e.sq / float64(e.hours))
+}
+
+func (e forecastErrors) bias() float64 {
+	return e.sum / float64(e.hours)
+}
+
+// Forecast forecasts the prices of the zone for date (a UTC midnight), tomorrow if it is nil. If model is nil, the model with
+// the lowest mean absolute error in the backtest is used. Every hour comes with a band of ±1.96 times
+// the backtest RMSE. The forecast isn't stored, StoreForecasts does it for the days to measure.
+// It returns a PricesNotFound error if there isn't enough prices history before date.
+func (s ForecastsService) Forecast(ctx context.Context, zoneID domain.ZoneID, day *time.Time, model *domain.ForecastModel) (PricesForecast, error) {
+	loc, err := zoneLocation(ctx, s.zonesRepository, zoneID)
+	if err != nil {
+		return PricesForecast{}, err
+	}
+	today := startOfDay(now(), loc)
+	date := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+	if day != nil {
+		date = *day
+	}
+	if lastDay := time.Date(today.Year(), today.Month(), today.Day()+maxForecastHorizonDays, 0, 0, 0, 0, time.UTC); date.After(lastDay) {
+		return PricesForecast{}, errors.NewDomainError(errors.InvalidDateRange, "prices can't be forecast more than %d days ahead", maxForecastHorizonDays)
+	}
+
+	return s.forecast(ctx, zoneID, loc, date, model)
+}
+
+// StoreForecasts forecasts the prices of tomorrow of every zone whose real prices aren't stored yet, with
+// the model with the lowest backtest error, and stores the forecasts so RecordAccuracy can measure them
+// once the real prices are. Zones without enough prices history are skipped.
+func (s ForecastsService) StoreForecasts(ctx context.Context) error {
+	zones, err := s.zonesRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		zoneID := zone.ID()
+		today := startOfDay(now(), zone.Location())
+		date := time.Date(today.Year(), today.Month(), today.Day()+1, 0, 0, 0, 0, time.UTC)
+		published, err := s.pricesRepository.Query(ctx, &zoneID, &date)
+		if err != nil {
+			return err
+		}
+		if _, ok := findZonePrices(published, zoneID); ok {
+			continue
+		}
+
+		forecast, err := s.forecast(ctx, zoneID, zone.Location(), date, nil)
+		if errors.Code(err) == errors.PricesNotFound {
+			logger.DebugContext(ctx, "Forecast skipped", "zone_id", zoneID.String(), "date", date.Format(time.DateOnly), "err", err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.forecastsRepository.Save(ctx, forecast.Forecast); err != nil {
+			return err
+		}
+		logger.InfoContext(ctx, "Forecast stored", "zone_id", zoneID.String(), "date", date.Format(time.DateOnly), "model", forecast.Forecast.Model().String())
+	}
+
+	return nil
+}
+
+// forecast forecasts the prices of the zone, whose local time is loc, for date (a UTC midnight) with model,
+// or the one with the lowest backtest error if it is nil.
+func (s ForecastsService) forecast(ctx context.Context, zoneID domain.ZoneID, loc *time.Location, date time.Time, model *domain.ForecastModel) (PricesForecast, error) {
+	history, err := s.history(ctx, zoneID, date.AddDate(0, 0, -forecastHistoryDays-forecastBacktestDays), date, loc)
+	if err != nil {
+		return PricesForecast{}, err
+	}
+	if n := len(trainingDays(history, date)); n < minForecastHistoryDays {
+		return PricesForecast{}, errors.NewDomainError(errors.PricesNotFound, "not enough prices to forecast zone %s on %s: %d days stored of the %d before, at least %d needed",
+			zoneID.String(), date.Format(time.DateOnly), n, forecastHistoryDays, minForecastHistoryDays)
+	}
+
+	models := domain.ForecastModels
+	if model != nil {
+		models = []domain.ForecastModel{*model}
+	}
+	best, bestErrors := models[0], backtest(models[0], history)
+	for _, m := range models[1:] {
+		if e := backtest(m, history); e.hours > 0 && (bestErrors.hours == 0 || e.mae() < bestErrors.mae()) {
+			best, bestErrors = m, e
+		}
+	}
+	var mae, rmse float64
+	if bestErrors.hours > 0 {
+		mae, rmse = bestErrors.mae(), bestErrors.rmse()
+	}
+
+	training := trainingDays(history, date)
+	dto := domain.ForecastDto{
+		ZoneID:    zoneID.String(),
+		Date:      date.Format(time.DateOnly),
+		Model:     best.String(),
+		CreatedAt: now().UTC().Format(time.RFC3339),
+	}
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	for hour := start; hour.Before(start.AddDate(0, 0, 1)); hour = hour.Add(time.Hour) {
+		value, ok := forecastHour(best, training, date, hour.Hour())
+		if !ok {
+			continue
+		}
+		dto.Values = append(dto.Values, domain.ForecastValueDto{
+			Datetime: hour.Format(time.RFC3339),
+			Value:    round(value, 2),
+			Lower:    round(value-forecastConfidenceZ*rmse, 2),
+			Upper:    round(value+forecastConfidenceZ*rmse, 2),
+		})
+	}
+	forecast, err := domain.NewForecast(dto)
+	if err != nil {
+		return PricesForecast{}, err
+	}
+
+	return PricesForecast{
+		Forecast:     forecast,
+		BacktestMAE:  round(mae, 2),
+		BacktestRMSE: round(rmse, 2),
+	}, nil
+}
+
+// RecordAccuracy measures the stored forecasts of the prices with the given IDs against them,
+// once they are stored, and stores the accuracy of each one. Prices without forecasts are skipped.
+func (s ForecastsService) RecordAccuracy(ctx context.Context, ids []domain.PricesID) error {
+	for _, id := range ids {
+		zoneID := id.ZoneID()
+		date, err := id.Date()
+		if err != nil {
+			return err
+		}
+
+		forecasts, err := s.forecastsRepository.Query(ctx, zoneID, date)
+		if err != nil {
+			return err
+		}
+		if len(forecasts) == 0 {
+			continue
+		}
+		prices, err := s.pricesRepository.Query(ctx, &zoneID, &date)
+		if err != nil {
+			return err
+		}
+		zonePrices, ok := findZonePrices(prices, zoneID)
+		if !ok {
+			continue
+		}
+		actual := make(map[int64]float64, len(zonePrices.Values()))
+		for _, value := range zonePrices.Values() {
+			actual[value.Datetime().Unix()] = value.Value()
+		}
+
+		for _, forecast := range forecasts {
+			var e forecastErrors
+			for _, value := range forecast.Values() {
+				if price, ok := actual[value.Datetime().Unix()]; ok {
+					e.add(value.Value(), price)
+				}
+			}
+			if e.hours == 0 {
+				continue
+			}
+			accuracy, err := domain.NewForecastAccuracy(domain.ForecastAccuracyDto{
+				ZoneID:     zoneID.String(),
+				Date:       date.Format(time.DateOnly),
+				Model:      forecast.Model().String(),
+				Hours:      e.hours,
+				MAE:        round(e.mae(), 2),
+				RMSE:       round(e.rmse(), 2),
+				Bias:       round(e.bias(), 2),
+				RecordedAt: now().UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+			if err := s.forecastsRepository.SaveAccuracy(ctx, accuracy); err != nil {
+				return err
+			}
+			logger.InfoContext(ctx, "Forecast accuracy recorded", "zone_id", zoneID.String(), "date", date.Format(time.DateOnly), "model", forecast.Model().String(), "mae", accuracy.Serialize().MAE)
+		}
+	}
+
+	return nil
+}
+
+// ListAccuracy returns the recorded accuracy of the forecasts of the zone from from to to (UTC midnights, both included).
+func (s ForecastsService) ListAccuracy(ctx context.Context, zoneID domain.ZoneID, from, to time.Time) ([]domain.ForecastAccuracy, error) {
+	if to.Before(from) {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "to date %s can't be before from date %s", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+	if to.Sub(from) >= maxAccuracyRange*24*time.Hour {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "accuracy can't be listed for more than %d days", maxAccuracyRange)
+	}
+	if _, err := s.zonesRepository.GetByID(ctx, zoneID); err != nil {
+		return nil, err
+	}
+
+	return s.forecastsRepository.ListAccuracy(ctx, zoneID, from, to)
+}
+
+// history returns the stored prices of the zone from from (included) to to (excluded), sorted by date.
+func (s ForecastsService) history(ctx context.Context, zoneID domain.ZoneID, from, to time.Time, loc *time.Location) ([]historyDay, error) {
+	prices, err := s.pricesRepository.QueryRange(ctx, zoneID, from, to.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]historyDay, 0, len(prices))
+	for _, zonePrices := range prices {
+		if len(zonePrices.Values()) == 0 {
+			continue
+		}
+		date := time.Date(zonePrices.Date().Year(), zonePrices.Date().Month(), zonePrices.Date().Day(), 0, 0, 0, 0, time.UTC)
+		day := historyDay{date: date, prices: make(map[int]float64, len(zonePrices.Values()))}
+		for _, value := range zonePrices.Values() {
+			hour := value.Datetime().In(loc).Hour()
+			if _, ok := day.prices[hour]; !ok {
+				day.prices[hour] = value.Value()
+			}
+		}
+		history = append(history, day)
+	}
+	return history, nil
+}
+
+// trainingDays returns the days of history in the forecastHistoryDays before date.
+func trainingDays(history []historyDay, date time.Time) []historyDay {
+	first := date.AddDate(0, 0, -forecastHistoryDays)
+	start := sort.Search(len(history), func(i int) bool { return !history[i].date.Before(first) })
+	end := sort.Search(len(history), func(i int) bool { return !history[i].date.Before(date) })
+	return history[start:end]
+}
+
+// backtest forecasts each of the last forecastBacktestDays days of history with model, trained on the days
+// before it, and returns the errors made.
+func backtest(model domain.ForecastModel, history []historyDay) forecastErrors {
+	var e forecastErrors
+	first := len(history) - forecastBacktestDays
+	if first < 1 {
+		first = 1
+	}
+	for _, day := range history[first:] {
+		training := trainingDays(history, day.date)
+		for hour := 0; hour < 24; hour++ {
+			actual, ok := day.prices[hour]
+			if !ok {
+				continue
+			}
+			if forecast, ok := forecastHour(model, training, day.date, hour); ok {
+				e.add(forecast, actual)
+			}
+		}
+	}
+	return e
+}
+
+// forecastHour forecasts the price of the local hour of date with model, trained on history,
+// sorted by date and before date. It returns false if history has no price for that hour.
+func forecastHour(model domain.ForecastModel, history []historyDay, date time.Time, hour int) (float64, bool) {
+	switch model {
+	case domain.SeasonalNaiveModel:
+		// The same hour a week before or, if it isn't stored, the last stored one.
+		weekBefore := date.AddDate(0, 0, -7)
+		var last *float64
+		for i := range history {
+			if price, ok := history[i].prices[hour]; ok {
+				if history[i].date.Equal(weekBefore) {
+					return price, true
+				}
+				last = &price
+			}
+		}
+		if last == nil {
+			return 0, false
+		}
+		return *last, true
+	case domain.WeekdayProfileModel:
+		// The mean of the same hour on the same day of the week or, if there is none, on every day.
+		var weekdaySum, sum float64
+		var weekdayDays, days int
+		for _, day := range history {
+			price, ok := day.prices[hour]
+			if !ok {
+				continue
+			}
+			sum += price
+			days++
+			if day.date.Weekday() == date.Weekday() {
+				weekdaySum += price
+				weekdayDays++
+			}
+		}
+		if weekdayDays > 0 {
+			return weekdaySum / float64(weekdayDays), true
+		}
+		if days == 0 {
+			return 0, false
+		}
+		return sum / float64(days), true
+	case domain.ExponentialSmoothingModel:
+		var level float64
+		var smoothed bool
+		for _, day := range history {
+			price, ok := day.prices[hour]
+			if !ok {
+				continue
+			}
+			if !smoothed {
+				level, smoothed = price, true
+				continue
+			}
+			level = forecastSmoothingAlpha*price + (1-forecastSmoothingAlpha)*level
+		}
+		return level, smoothed
+	}
+	return 0, false
+}
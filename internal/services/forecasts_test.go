@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+var testForecastsZone = domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+
+// newTestForecastsService returns a ForecastsService whose prices repository has the PEN prices
+// of 2023-10-01 to 2023-10-14, which repeat every week: 50 €/MWh plus 10 per weekday number plus the hour.
+func newTestForecastsService(t *testing.T) (ForecastsService, *inmemory.PricesRepository, *inmemory.ForecastsRepository, domain.ZoneID) {
+	t.Helper()
+	zone, err := domain.NewZone(testForecastsZone)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(zone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	forecastsRepository := inmemory.NewForecastsRepository(zonesRepository)
+
+	for day := 1; day <= 14; day++ {
+		date := time.Date(2023, 10, day, 0, 0, 0, 0, time.UTC)
+		saveTestDayPrices(t, pricesRepository, date, func(hour int) float64 {
+			return 50 + 10*float64(date.Weekday()) + float64(hour)
+		})
+	}
+
+	return NewForecastsService(pricesRepository, forecastsRepository, zonesRepository), pricesRepository, forecastsRepository, zone.ID()
+}
+
+// saveTestDayPrices stores the PEN prices of the CEST day date, with the value of each hour.
+func saveTestDayPrices(t *testing.T, pricesRepository domain.PricesRepository, date time.Time, value func(hour int) float64) {
+	t.Helper()
+	values := make([]float64, 24)
+	for hour := range values {
+		values[hour] = value(hour)
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	prices := newTestPrices(t, domain.PVPCPrices, testForecastsZone, day, values...)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+}
+
+func Test_ForecastsService_Forecast(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	now = func() time.Time { return time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC) }
+	defer restoreNow(time.Now)
+	tomorrow := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("picks the model with the lowest backtest error, forecasts tomorrow and doesn't store the forecast", func(t *testing.T) {
+		service, _, forecastsRepository, zoneID := newTestForecastsService(t)
+
+		forecast, err := service.Forecast(context.Background(), zoneID, nil, nil)
+
+		require.NoError(t, err)
+		dto := forecast.Forecast.Serialize()
+		// The prices repeat every week, so the seasonal naive and the weekday profile models are exact.
+		require.Equal(t, "seasonal_naive", dto.Model)
+		require.Equal(t, "2023-10-15", dto.Date)
+		require.Equal(t, 0.0, forecast.BacktestMAE)
+		require.Len(t, dto.Values, 24)
+		require.Equal(t, domain.ForecastValueDto{Datetime: "2023-10-15T00:00:00+02:00", Value: 50, Lower: 50, Upper: 50}, dto.Values[0])
+		require.Equal(t, domain.ForecastValueDto{Datetime: "2023-10-15T23:00:00+02:00", Value: 73, Lower: 73, Upper: 73}, dto.Values[23])
+
+		stored, err := forecastsRepository.Query(context.Background(), zoneID, tomorrow)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+	})
+
+	t.Run("with a model, it uses it and the bands are sized by its backtest errors", func(t *testing.T) {
+		service, _, _, zoneID := newTestForecastsService(t)
+		model := domain.ExponentialSmoothingModel
+
+		forecast, err := service.Forecast(context.Background(), zoneID, &tomorrow, &model)
+
+		require.NoError(t, err)
+		dto := forecast.Forecast.Serialize()
+		require.Equal(t, "exponential_smoothing", dto.Model)
+		require.Greater(t, forecast.BacktestMAE, 0.0)
+		require.InDelta(t, 1.96*forecast.BacktestRMSE, dto.Values[0].Upper-dto.Values[0].Value, 0.02)
+		require.InDelta(t, 1.96*forecast.BacktestRMSE, dto.Values[0].Value-dto.Values[0].Lower, 0.02)
+	})
+
+	t.Run("without enough history, it returns a prices not found error", func(t *testing.T) {
+		service, _, _, zoneID := newTestForecastsService(t)
+		date := time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC)
+
+		_, err := service.Forecast(context.Background(), zoneID, &date, nil)
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+
+	t.Run("too many days ahead, it returns an invalid date range error", func(t *testing.T) {
+		service, _, _, zoneID := newTestForecastsService(t)
+		date := time.Date(2023, 10, 22, 0, 0, 0, 0, time.UTC)
+
+		_, err := service.Forecast(context.Background(), zoneID, &date, nil)
+
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+
+	t.Run("for an unknown zone, it returns a zone not found error", func(t *testing.T) {
+		service, _, _, _ := newTestForecastsService(t)
+		zoneID, err := domain.NewZoneID("BAL")
+		require.NoError(t, err)
+
+		_, err = service.Forecast(context.Background(), zoneID, &tomorrow, nil)
+
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+	})
+}
+
+func Test_ForecastsService_StoreForecasts(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	defer restoreNow(time.Now)
+	tomorrow := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("stores the forecast of tomorrow with the model with the lowest backtest error", func(t *testing.T) {
+		now = func() time.Time { return time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC) }
+		service, _, forecastsRepository, zoneID := newTestForecastsService(t)
+
+		require.NoError(t, service.StoreForecasts(context.Background()))
+
+		forecast, err := service.Forecast(context.Background(), zoneID, &tomorrow, nil)
+		require.NoError(t, err)
+		stored, err := forecastsRepository.Query(context.Background(), zoneID, tomorrow)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ForecastDto{forecast.Forecast.Serialize()}, serializeTestForecasts(stored))
+	})
+
+	t.Run("when the real prices of tomorrow are stored, the forecast isn't", func(t *testing.T) {
+		now = func() time.Time { return time.Date(2023, 10, 13, 12, 0, 0, 0, time.UTC) }
+		service, _, forecastsRepository, zoneID := newTestForecastsService(t)
+
+		require.NoError(t, service.StoreForecasts(context.Background()))
+
+		stored, err := forecastsRepository.Query(context.Background(), zoneID, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Empty(t, stored)
+	})
+
+	t.Run("without enough history, the zone is skipped", func(t *testing.T) {
+		now = func() time.Time { return time.Date(2023, 9, 29, 12, 0, 0, 0, time.UTC) }
+		service, _, forecastsRepository, zoneID := newTestForecastsService(t)
+
+		require.NoError(t, service.StoreForecasts(context.Background()))
+
+		stored, err := forecastsRepository.Query(context.Background(), zoneID, time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Empty(t, stored)
+	})
+}
+
+func Test_ForecastsService_RecordAccuracy(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	now = func() time.Time { return time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC) }
+	defer restoreNow(time.Now)
+	tomorrow := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+	service, pricesRepository, _, zoneID := newTestForecastsService(t)
+	require.NoError(t, service.StoreForecasts(context.Background()))
+
+	// The real prices are 2 €/MWh above the forecast ones until 12:00 and 4 below from then.
+	saveTestDayPrices(t, pricesRepository, tomorrow, func(hour int) float64 {
+		if hour < 12 {
+			return 50 + float64(hour) + 2
+		}
+		return 50 + float64(hour) - 4
+	})
+	id, err := domain.NewPricesID("PEN-2023-10-15")
+	require.NoError(t, err)
+	unforecast, err := domain.NewPricesID("PEN-2023-10-14")
+	require.NoError(t, err)
+	now = func() time.Time { return time.Date(2023, 10, 14, 20, 30, 0, 0, time.UTC) }
+
+	require.NoError(t, service.RecordAccuracy(context.Background(), []domain.PricesID{unforecast, id}))
+
+	accuracies, err := service.ListAccuracy(context.Background(), zoneID, tomorrow.AddDate(0, 0, -7), tomorrow)
+	require.NoError(t, err)
+	dtos := make([]domain.ForecastAccuracyDto, len(accuracies))
+	for i, accuracy := range accuracies {
+		dtos[i] = accuracy.Serialize()
+	}
+	require.Equal(t, []domain.ForecastAccuracyDto{
+		{ZoneID: "PEN", Date: "2023-10-15", Model: "seasonal_naive", Hours: 24, MAE: 3, RMSE: 3.16, Bias: 1, RecordedAt: "2023-10-14T20:30:00Z"},
+	}, dtos)
+}
+
+func Test_ForecastsService_ListAccuracy(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	service, _, _, zoneID := newTestForecastsService(t)
+	day := time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("when to is before from, it returns an invalid date range error", func(t *testing.T) {
+		_, err := service.ListAccuracy(context.Background(), zoneID, day, day.AddDate(0, 0, -1))
+
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+
+	t.Run("when the range is too long, it returns an invalid date range error", func(t *testing.T) {
+		_, err := service.ListAccuracy(context.Background(), zoneID, day, day.AddDate(1, 0, 1))
+
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
+
+func serializeTestForecasts(forecasts []domain.Forecast) []domain.ForecastDto {
+	dtos := make([]domain.ForecastDto, len(forecasts))
+	for i, forecast := range forecasts {
+		dtos[i] = forecast.Serialize()
+	}
+	return dtos
+}
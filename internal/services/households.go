@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// maxCostsReportDays is the longest period a consumption costs report can cover.
+const maxCostsReportDays = 366
+
+// HouseholdsService is the domain service that stores households' consumption and reports its cost
+// at the stored PVPC prices.
+type HouseholdsService struct {
+	householdsRepository domain.HouseholdsRepository
+	zonesRepository      domain.ZonesRepository
+	pricesRepository     domain.PricesRepository
+}
+
+// NewHouseholdsService returns a new HouseholdsService.
+func NewHouseholdsService(householdsRepository domain.HouseholdsRepository, zonesRepository domain.ZonesRepository, pricesRepository domain.PricesRepository) HouseholdsService {
+	return HouseholdsService{
+		householdsRepository: householdsRepository,
+		zonesRepository:      zonesRepository,
+		pricesRepository:     pricesRepository,
+	}
+}
+
+// ImportedHousehold summarizes the consumption imported for a household: the number of hours
+// and the first and last of them.
+type ImportedHousehold struct {
+	CUPS  domain.CUPS
+	Hours int
+	From  time.Time
+	To    time.Time
+}
+
+// HourlyCost is the cost of the consumption of an hour. Price and Cost are nil if the hour's price is not stored.
+type HourlyCost struct {
+	Datetime time.Time
+	Kwh      float64
+	Price    *float64
+	Cost     *float64
+}
+
+// DailyCost is the cost of the consumption of a day, in the household's zone local time.
+// The consumption of the MissingPricesHours hours without a stored price is not costed.
+type DailyCost struct {
+	Date               time.Time
+	Kwh                float64
+	Cost               float64
+	MissingPricesHours int
+}
+
+// ImportConsumption stores the given readings as the consumption of their households, which are billed in zoneID.
+// Readings' hours are numbered in the zone local time, so DST change days have 23 or 25 hours.
+// It returns a summary of the imported consumption per household, sorted by CUPS.
+func (s HouseholdsService) ImportConsumption(ctx context.Context, zoneID domain.ZoneID, readings []domain.ConsumptionReadingDto) ([]ImportedHousehold, error) {
+	if len(readings) == 0 {
+		return nil, errors.NewDomainError(errors.InvalidConsumption, "there is no consumption to import")
+	}
+	loc, err := zoneLocation(ctx, s.zonesRepository, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	households := make(map[string]domain.Household)
+	consumption := make(map[string][]domain.HourlyConsumption)
+	seen := make(map[string]bool, len(readings))
+	for _, reading := range readings {
+		household, err := domain.NewHousehold(domain.HouseholdDto{CUPS: reading.CUPS, ZoneID: zoneID.String()})
+		if err != nil {
+			return nil, err
+		}
+		datetime, err := readingDatetime(reading, loc)
+		if err != nil {
+			return nil, err
+		}
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: datetime.Format(time.RFC3339), Kwh: reading.Kwh})
+		if err != nil {
+			return nil, err
+		}
+
+		cups := household.CUPS().String()
+		key := fmt.Sprintf("%s/%d", cups, datetime.Unix())
+		if seen[key] {
+			return nil, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s at %s hour %d is repeated", cups, reading.Date, reading.Hour)
+		}
+		seen[key] = true
+		households[cups] = household
+		consumption[cups] = append(consumption[cups], c)
+	}
+
+	imported := make([]ImportedHousehold, 0, len(households))
+	for cups, household := range households {
+		if err := s.householdsRepository.Save(ctx, household, consumption[cups]); err != nil {
+			return nil, err
+		}
+
+		summary := ImportedHousehold{CUPS: household.CUPS(), Hours: len(consumption[cups])}
+		for i, c := range consumption[cups] {
+			if i == 0 || c.Datetime().Before(summary.From) {
+				summary.From = c.Datetime()
+			}
+			if i == 0 || c.Datetime().After(summary.To) {
+				summary.To = c.Datetime()
+			}
+		}
+		imported = append(imported, summary)
+	}
+	sort.Slice(imported, func(i, j int) bool {
+		return imported[i].CUPS.String() < imported[j].CUPS.String()
+	})
+
+	return imported, nil
+}
+
+// HourlyCosts returns the cost of every hour with consumption of the household, from from to to
+// (YYYY-MM-DD days in the household's zone local time, both included).
+func (s HouseholdsService) HourlyCosts(ctx context.Context, cups domain.CUPS, from, to time.Time) ([]HourlyCost, error) {
+	_, costs, err := s.hourlyCosts(ctx, cups, from, to)
+	return costs, err
+}
+
+// DailyCosts returns the cost of every day with consumption of the household, from from to to
+// (YYYY-MM-DD days in the household's zone local time, both included).
+func (s HouseholdsService) DailyCosts(ctx context.Context, cups domain.CUPS, from, to time.Time) ([]DailyCost, error) {
+	loc, hourly, err := s.hourlyCosts(ctx, cups, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var costs []DailyCost
+	var exact []domain.Decimal
+	for _, hour := range hourly {
+		day := startOfDay(hour.Datetime, loc)
+		date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		if len(costs) == 0 || !costs[len(costs)-1].Date.Equal(date) {
+			costs = append(costs, DailyCost{Date: date})
+			exact = append(exact, domain.Decimal{})
+		}
+		cost := &costs[len(costs)-1]
+		cost.Kwh += hour.Kwh
+		if hour.Cost == nil {
+			cost.MissingPricesHours++
+			continue
+		}
+		// Hourly costs are exact to 6 decimals, so they're added exactly.
+		exact[len(costs)-1] = exact[len(costs)-1].Add(domain.NewDecimalFromFloat(*hour.Cost))
+	}
+	for i := range costs {
+		costs[i].Kwh = round(costs[i].Kwh, 3)
+		costs[i].Cost = exact[i].Round(2).Float64()
+	}
+
+	return costs, nil
+}
+
+// hourlyCosts returns the household's zone local time zone and the cost of every hour with consumption
+// during the from-to local days.
+func (s HouseholdsService) hourlyCosts(ctx context.Context, cups domain.CUPS, from, to time.Time) (*time.Location, []HourlyCost, error) {
+	household, loc, consumption, err := s.queryConsumption(ctx, cups, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	prices, err := consumptionPrices(ctx, s.pricesRepository, household.ZoneID(), loc, consumption)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	costs := make([]HourlyCost, len(consumption))
+	for i, c := range consumption {
+		costs[i] = HourlyCost{Datetime: c.Datetime(), Kwh: c.Kwh()}
+		if price, ok := prices[c.Datetime().Unix()]; ok {
+			value, cost := price.In(domain.MWh).Amount().Float64(), price.CostOf(domain.NewDecimalFromFloat(c.Kwh())).Round(6).Float64()
+			costs[i].Price = &value
+			costs[i].Cost = &cost
+		}
+	}
+
+	return loc, costs, nil
+}
+
+// queryConsumption returns the household, its zone local time zone and its consumption during the from-to local days.
+func (s HouseholdsService) queryConsumption(ctx context.Context, cups domain.CUPS, from, to time.Time) (domain.Household, *time.Location, []domain.HourlyConsumption, error) {
+	if to.Before(from) {
+		return domain.Household{}, nil, nil, errors.NewDomainError(errors.InvalidDateRange, "costs report from %s is after to %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+	if days := to.Sub(from).Hours()/24 + 1; days > maxCostsReportDays {
+		return domain.Household{}, nil, nil, errors.NewDomainError(errors.InvalidDateRange, "costs report can't be longer than %d days", maxCostsReportDays)
+	}
+
+	household, err := s.householdsRepository.Get(ctx, cups)
+	if err != nil {
+		return domain.Household{}, nil, nil, err
+	}
+	loc, err := zoneLocation(ctx, s.zonesRepository, household.ZoneID())
+	if err != nil {
+		return domain.Household{}, nil, nil, err
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc)
+
+	consumption, err := s.householdsRepository.QueryConsumption(ctx, cups, start, end)
+	if err != nil {
+		return domain.Household{}, nil, nil, err
+	}
+	return household, loc, consumption, nil
+}
+
+// readingDatetime returns the start of the reading's hour. Hours are numbered from 1 within the local day,
+// so the reading's datetime is counted from the day's midnight, which handles the DST change days.
+func readingDatetime(reading domain.ConsumptionReadingDto, loc *time.Location) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, reading.Date)
+	if err != nil {
+		return time.Time{}, errors.WrapIntoDomainError(err, errors.InvalidTime, fmt.Sprintf("error parsing consumption reading date: %s", reading.Date))
+	}
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, loc)
+	hours := int(end.Sub(start).Hours())
+	if reading.Hour < 1 || reading.Hour > hours {
+		return time.Time{}, errors.NewDomainError(errors.InvalidConsumption, "hour %d of %s is out of range, the day has %d hours", reading.Hour, reading.Date, hours)
+	}
+
+	return start.Add(time.Duration(reading.Hour-1) * time.Hour), nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+// newTestHouseholdsService returns a HouseholdsService whose prices repository has the prices of zone
+// for the hour repeated when the clocks went back on 2023-10-29: 100 €/MWh in CEST and 200 in CET.
+func newTestHouseholdsService(t *testing.T, zone domain.ZoneDto) (HouseholdsService, domain.HouseholdsRepository) {
+	t.Helper()
+	domainZone, err := domain.NewZone(zone)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(domainZone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	prices, err := domain.NewPrices(domain.PricesDto{ID: zone.ID + "-2023-10-29", Date: "2023-10-29T00:00:00+02:00", Zone: zone, Values: []domain.HourlyPriceDto{
+		{Datetime: "2023-10-29T02:00:00+02:00", Value: 100},
+		{Datetime: "2023-10-29T02:00:00+01:00", Value: 200},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+	householdsRepository := inmemory.NewHouseholdsRepository(zonesRepository)
+	return NewHouseholdsService(householdsRepository, zonesRepository, pricesRepository), householdsRepository
+}
+
+func Test_HouseholdsService(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	const cups = "ES0021000000000001AA"
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+
+	t.Run("imports the readings numbering the hours from the local midnight", func(t *testing.T) {
+		service, repository := newTestHouseholdsService(t, zoneDto)
+
+		imported, err := service.ImportConsumption(context.Background(), zone.ID(), []domain.ConsumptionReadingDto{
+			{CUPS: cups, Date: "2023-10-29", Hour: 3, Kwh: 0.3},
+			{CUPS: cups, Date: "2023-10-29", Hour: 4, Kwh: 0.4},
+			{CUPS: cups, Date: "2023-10-29", Hour: 25, Kwh: 2.5},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, imported, 1)
+		require.Equal(t, cups, imported[0].CUPS.String())
+		require.Equal(t, 3, imported[0].Hours)
+		require.Equal(t, "2023-10-29T00:00:00Z", imported[0].From.UTC().Format(time.RFC3339))
+		require.Equal(t, "2023-10-29T22:00:00Z", imported[0].To.UTC().Format(time.RFC3339))
+
+		consumption, err := repository.QueryConsumption(context.Background(), imported[0].CUPS, parseTestDate(t, "2023-10-28"), parseTestDate(t, "2023-10-30"))
+		require.NoError(t, err)
+		require.Len(t, consumption, 3)
+		require.Equal(t, "2023-10-29T01:00:00Z", consumption[1].Datetime().UTC().Format(time.RFC3339))
+	})
+
+	t.Run("when an hour is out of the local day, it returns an invalid consumption error", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+
+		_, err := service.ImportConsumption(context.Background(), zone.ID(), []domain.ConsumptionReadingDto{
+			{CUPS: cups, Date: "2023-10-11", Hour: 25, Kwh: 1},
+		})
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when an hour is repeated, it returns an invalid consumption error", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+
+		_, err := service.ImportConsumption(context.Background(), zone.ID(), []domain.ConsumptionReadingDto{
+			{CUPS: cups, Date: "2023-10-11", Hour: 1, Kwh: 1},
+			{CUPS: cups, Date: "2023-10-11", Hour: 1, Kwh: 2},
+		})
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when the CUPS or the zone is invalid, it returns their errors", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+
+		_, err := service.ImportConsumption(context.Background(), zone.ID(), []domain.ConsumptionReadingDto{
+			{CUPS: "ES00", Date: "2023-10-11", Hour: 1, Kwh: 1},
+		})
+		require.Equal(t, errors.InvalidCUPS, errors.Code(err))
+
+		unknownZone, err := domain.NewZoneID("BAL")
+		require.NoError(t, err)
+		_, err = service.ImportConsumption(context.Background(), unknownZone, []domain.ConsumptionReadingDto{
+			{CUPS: cups, Date: "2023-10-11", Hour: 1, Kwh: 1},
+		})
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+	})
+
+	t.Run("reports the cost of every hour and day at the stored prices", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+		imported, err := service.ImportConsumption(context.Background(), zone.ID(), []domain.ConsumptionReadingDto{
+			{CUPS: cups, Date: "2023-10-29", Hour: 3, Kwh: 0.5},
+			{CUPS: cups, Date: "2023-10-29", Hour: 4, Kwh: 1},
+			{CUPS: cups, Date: "2023-10-29", Hour: 5, Kwh: 2},
+			{CUPS: cups, Date: "2023-10-30", Hour: 1, Kwh: 3},
+		})
+		require.NoError(t, err)
+		from, to := parseTestDate(t, "2023-10-29"), parseTestDate(t, "2023-10-30")
+
+		hourly, err := service.HourlyCosts(context.Background(), imported[0].CUPS, from, to)
+		require.NoError(t, err)
+		require.Len(t, hourly, 4)
+		require.Equal(t, 100.0, *hourly[0].Price)
+		require.Equal(t, 0.05, *hourly[0].Cost)
+		require.Equal(t, 200.0, *hourly[1].Price)
+		require.Equal(t, 0.2, *hourly[1].Cost)
+		require.Nil(t, hourly[2].Price)
+		require.Nil(t, hourly[2].Cost)
+
+		daily, err := service.DailyCosts(context.Background(), imported[0].CUPS, from, to)
+		require.NoError(t, err)
+		require.Equal(t, []DailyCost{
+			{Date: from, Kwh: 3.5, Cost: 0.25, MissingPricesHours: 1},
+			{Date: to, Kwh: 3, Cost: 0, MissingPricesHours: 1},
+		}, daily)
+	})
+
+	t.Run("when the household is unknown, it returns a household not found error", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+		unknown, err := domain.NewCUPS(cups)
+		require.NoError(t, err)
+
+		_, err = service.DailyCosts(context.Background(), unknown, parseTestDate(t, "2023-10-29"), parseTestDate(t, "2023-10-29"))
+
+		require.Equal(t, errors.HouseholdNotFound, errors.Code(err))
+	})
+
+	t.Run("when the range is inverted or too long, it returns an invalid date range error", func(t *testing.T) {
+		service, _ := newTestHouseholdsService(t, zoneDto)
+		c, err := domain.NewCUPS(cups)
+		require.NoError(t, err)
+
+		_, err = service.HourlyCosts(context.Background(), c, parseTestDate(t, "2023-10-29"), parseTestDate(t, "2023-10-28"))
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+
+		_, err = service.HourlyCosts(context.Background(), c, parseTestDate(t, "2022-10-28"), parseTestDate(t, "2023-10-29"))
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
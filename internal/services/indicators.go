@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// IngestedIndicator is an indicator fetched and stored on every ingestion run,
+// only for the given geos or for all of them if GeoIDs is empty.
+type IngestedIndicator struct {
+	ID     domain.IndicatorID
+	GeoIDs []string
+}
+
+// ParseIngestedIndicators parses the indicators to ingest, each one in the shape of
+// ID[:GEO_ID[|GEO_ID...]], e.g. "600:3|1" for the spot price of Spain and Portugal,
+// or "1293" for the real demand of every geo.
+func ParseIngestedIndicators(values []string) ([]IngestedIndicator, error) {
+	indicators := make([]IngestedIndicator, 0, len(values))
+	for _, value := range values {
+		idStr, geoIDsStr, _ := strings.Cut(strings.TrimSpace(value), ":")
+		id, err := domain.NewIndicatorID(idStr)
+		if err != nil {
+			return nil, err
+		}
+
+		indicator := IngestedIndicator{ID: id}
+		for _, geoID := range strings.Split(geoIDsStr, "|") {
+			if geoID = strings.TrimSpace(geoID); geoID != "" {
+				indicator.GeoIDs = append(indicator.GeoIDs, geoID)
+			}
+		}
+		indicators = append(indicators, indicator)
+	}
+	return indicators, nil
+}
+
+// IndicatorsService is the domain service that manages operations over Indicator's.
+type IndicatorsService struct {
+	indicatorsProvider   domain.IndicatorsProvider
+	indicatorsRepository domain.IndicatorsRepository
+	ingestedIndicators   []IngestedIndicator
+}
+
+// NewIndicatorsService returns a new IndicatorsService that ingests the given indicators.
+func NewIndicatorsService(
+	indicatorsProvider domain.IndicatorsProvider,
+	indicatorsRepository domain.IndicatorsRepository,
+	ingestedIndicators []IngestedIndicator,
+) IndicatorsService {
+	return IndicatorsService{
+		indicatorsProvider:   indicatorsProvider,
+		indicatorsRepository: indicatorsRepository,
+		ingestedIndicators:   ingestedIndicators,
+	}
+}
+
+// FetchAndStoreIndicators fetches today's and tomorrow's values of every ingested indicator and
+// stores them, replacing the already stored ones. It returns the IDs of the indicators with stored values.
+//
+// Indicators that fail to be fetched are skipped, so one failing indicator doesn't stop the others.
+// If none could be stored because of those failures, the last one is returned.
+func (s IndicatorsService) FetchAndStoreIndicators(ctx context.Context) ([]domain.IndicatorID, error) {
+	loc, err := domain.NewZoneLocation(domain.DefaultZoneTimeZone)
+	if err != nil {
+		return nil, err
+	}
+	today := startOfDay(now(), loc)
+	days := []time.Time{today, today.AddDate(0, 0, 1)}
+
+	var stored []domain.IndicatorID
+	var fetchErr error
+	for _, ingested := range s.ingestedIndicators {
+		storedValues := false
+		for _, day := range days {
+			indicator, err := s.indicatorsProvider.FetchIndicator(ctx, ingested.ID, ingested.GeoIDs, day)
+			if err != nil {
+				logger.WarnContext(ctx, "couldn't fetch indicator", "indicator", ingested.ID.String(), "date", day.Format(time.DateOnly), "err", err)
+				fetchErr = err
+				continue
+			}
+			if len(indicator.Values()) == 0 {
+				continue
+			}
+			if err := s.indicatorsRepository.Save(ctx, indicator); err != nil {
+				return nil, err
+			}
+			storedValues = true
+		}
+		if storedValues {
+			stored = append(stored, ingested.ID)
+		}
+	}
+
+	if len(stored) == 0 && fetchErr != nil {
+		return nil, fetchErr
+	}
+	return stored, nil
+}
+
+// GetIndicator returns the indicator with its values from the first to the last given days (both included),
+// for the given geo or for all of them if geoID is nil. Values datetimes are in the REE publication time zone.
+func (s IndicatorsService) GetIndicator(ctx context.Context, id domain.IndicatorID, geoID *string, from, to time.Time) (domain.Indicator, error) {
+	if from.After(to) {
+		return domain.Indicator{}, errors.NewDomainError(errors.InvalidDateRange, "invalid date range: %s is after %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	loc, err := domain.NewZoneLocation(domain.DefaultZoneTimeZone)
+	if err != nil {
+		return domain.Indicator{}, err
+	}
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+
+	indicator, err := s.indicatorsRepository.Query(ctx, id, geoID, start, end)
+	if err != nil {
+		return domain.Indicator{}, err
+	}
+
+	return indicatorInLocation(indicator, loc)
+}
+
+// indicatorInLocation returns the indicator with the datetimes of its values in loc.
+func indicatorInLocation(indicator domain.Indicator, loc *time.Location) (domain.Indicator, error) {
+	dto := indicator.Serialize()
+	for i, v := range indicator.Values() {
+		dto.Values[i].Datetime = v.Datetime().In(loc).Format(time.RFC3339)
+	}
+	return domain.NewIndicator(dto)
+}
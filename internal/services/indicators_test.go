@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_ParseIngestedIndicators(t *testing.T) {
+	t.Run("parses indicators with and without geos", func(t *testing.T) {
+		indicators, err := ParseIngestedIndicators([]string{"600:3|1", " 1293 ", "10211:"})
+		require.NoError(t, err)
+		require.Len(t, indicators, 3)
+		require.Equal(t, "600", indicators[0].ID.String())
+		require.Equal(t, []string{"3", "1"}, indicators[0].GeoIDs)
+		require.Equal(t, "1293", indicators[1].ID.String())
+		require.Empty(t, indicators[1].GeoIDs)
+		require.Equal(t, "10211", indicators[2].ID.String())
+		require.Empty(t, indicators[2].GeoIDs)
+	})
+
+	t.Run("fails with an invalid indicator ID", func(t *testing.T) {
+		_, err := ParseIngestedIndicators([]string{"600", "spot:3"})
+		require.Error(t, err)
+		require.Equal(t, errors.InvalidIndicatorID, errors.Code(err))
+	})
+}
+
+func Test_IndicatorsService_FetchAndStoreIndicators(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	defer restoreNow(now)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	today := time.Date(2023, 9, 8, 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	now = func() time.Time { return today.Add(14 * time.Hour) }
+
+	spot, demand := IngestedIndicator{ID: newIndicatorID(t, "600"), GeoIDs: []string{"3"}}, IngestedIndicator{ID: newIndicatorID(t, "1293")}
+	t.Run("stores today's and tomorrow's values of every ingested indicator", func(t *testing.T) {
+		provider := new(mocks.IndicatorsProvider)
+		provider.On("FetchIndicator", mock.Anything, spot.ID, spot.GeoIDs, today).Return(newTestIndicator(t, "600", today, 100, 110), nil)
+		provider.On("FetchIndicator", mock.Anything, spot.ID, spot.GeoIDs, tomorrow).Return(newTestIndicator(t, "600", tomorrow, 90), nil)
+		provider.On("FetchIndicator", mock.Anything, demand.ID, []string(nil), today).Return(newTestIndicator(t, "1293", today, 25000), nil)
+		provider.On("FetchIndicator", mock.Anything, demand.ID, []string(nil), tomorrow).Return(newTestIndicator(t, "1293", tomorrow), nil)
+		repository := inmemory.NewIndicatorsRepository()
+		service := NewIndicatorsService(provider, repository, []IngestedIndicator{spot, demand})
+
+		ids, err := service.FetchAndStoreIndicators(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.IndicatorID{spot.ID, demand.ID}, ids)
+		provider.AssertExpectations(t)
+
+		stored, err := repository.Query(context.Background(), spot.ID, nil, today, tomorrow.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Len(t, stored.Values(), 3)
+	})
+
+	t.Run("skips the indicators that fail to be fetched", func(t *testing.T) {
+		provider := new(mocks.IndicatorsProvider)
+		provider.On("FetchIndicator", mock.Anything, spot.ID, mock.Anything, mock.Anything).Return(domain.Indicator{}, errors.NewDomainError(errors.ProviderError, "mock-error"))
+		provider.On("FetchIndicator", mock.Anything, demand.ID, mock.Anything, today).Return(newTestIndicator(t, "1293", today, 25000), nil)
+		provider.On("FetchIndicator", mock.Anything, demand.ID, mock.Anything, tomorrow).Return(newTestIndicator(t, "1293", tomorrow), nil)
+		service := NewIndicatorsService(provider, inmemory.NewIndicatorsRepository(), []IngestedIndicator{spot, demand})
+
+		ids, err := service.FetchAndStoreIndicators(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.IndicatorID{demand.ID}, ids)
+	})
+
+	t.Run("fails when no indicator could be fetched", func(t *testing.T) {
+		provider := new(mocks.IndicatorsProvider)
+		provider.On("FetchIndicator", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.Indicator{}, errors.NewDomainError(errors.ProviderError, "mock-error"))
+		service := NewIndicatorsService(provider, inmemory.NewIndicatorsRepository(), []IngestedIndicator{spot})
+
+		_, err := service.FetchAndStoreIndicators(context.Background())
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderError, errors.Code(err))
+	})
+
+	t.Run("fails when the values can't be stored", func(t *testing.T) {
+		provider := new(mocks.IndicatorsProvider)
+		provider.On("FetchIndicator", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(newTestIndicator(t, "600", today, 100), nil)
+		repository := new(mocks.IndicatorsRepository)
+		repository.On("Save", mock.Anything, mock.Anything).Return(errors.NewDomainError(errors.PersistenceError, "mock-error"))
+		service := NewIndicatorsService(provider, repository, []IngestedIndicator{spot})
+
+		_, err := service.FetchAndStoreIndicators(context.Background())
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+	})
+}
+
+func Test_IndicatorsService_GetIndicator(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	id := newIndicatorID(t, "600")
+	from := time.Date(2023, 9, 8, 0, 0, 0, 0, time.UTC)
+
+	t.Run("queries whole days in the REE time zone and returns the values in it", func(t *testing.T) {
+		indicator, err := domain.NewIndicator(domain.IndicatorDto{ID: "600", Name: "Spot", Values: []domain.IndicatorValueDto{
+			{Datetime: "2023-09-07T22:00:00Z", GeoID: "3", GeoName: "España", Value: 100},
+		}})
+		require.NoError(t, err)
+		repository := new(mocks.IndicatorsRepository)
+		repository.On("Query", mock.Anything, id, (*string)(nil), time.Date(2023, 9, 8, 0, 0, 0, 0, loc), time.Date(2023, 9, 10, 0, 0, 0, 0, loc)).Return(indicator, nil)
+		service := NewIndicatorsService(nil, repository, nil)
+
+		result, err := service.GetIndicator(context.Background(), id, nil, from, from.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		require.Equal(t, "2023-09-08T00:00:00+02:00", result.Serialize().Values[0].Datetime)
+	})
+
+	t.Run("fails with an invalid date range", func(t *testing.T) {
+		service := NewIndicatorsService(nil, new(mocks.IndicatorsRepository), nil)
+
+		_, err := service.GetIndicator(context.Background(), id, nil, from, from.AddDate(0, 0, -1))
+		require.Error(t, err)
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
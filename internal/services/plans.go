@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// maxPlanWindow is the longest window a plan can be scheduled in. Prices are published a day ahead,
+// so there are never stored prices beyond the end of tomorrow.
+const maxPlanWindow = 48 * time.Hour
+
+// planEnergyTolerance is the energy (kWh) below which a plan is considered fulfilled, to absorb floating point errors.
+const planEnergyTolerance = 1e-9
+
+// PlansService is the domain service that schedules loads in the cheapest hours of the stored PVPC prices.
+type PlansService struct {
+	pricesRepository domain.PricesRepository
+	zonesRepository  domain.ZonesRepository
+}
+
+// NewPlansService returns a new PlansService.
+func NewPlansService(pricesRepository domain.PricesRepository, zonesRepository domain.ZonesRepository) PlansService {
+	return PlansService{
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
+	}
+}
+
+// EVCharging is the input of an EV charging plan: the energy (kWh) to charge at the charger
+// power (kW), starting not before Start (now, if it is zero) and ending not after Deadline.
+type EVCharging struct {
+	ZoneID         domain.ZoneID
+	EnergyKwh      float64
+	ChargerPowerKw float64
+	Start          time.Time
+	Deadline       time.Time
+}
+
+// ChargingPlan is the cheapest schedule of an EV charging, along with its cost and the
+// savings versus charging immediately from the start at full power.
+type ChargingPlan struct {
+	ZoneID        domain.ZoneID
+	EnergyKwh     float64
+	Slots         []ChargingSlot
+	Cost          float64
+	ImmediateCost float64
+	Savings       float64
+}
+
+// ChargingSlot is a period of a ChargingPlan when the EV charges, at the price of its hour.
+type ChargingSlot struct {
+	Start time.Time
+	End   time.Time
+	Kwh   float64
+	Price float64
+	Cost  float64
+}
+
+// priceSlot is the part of a priced hour inside a plan window.
+type priceSlot struct {
+	start time.Time
+	end   time.Time
+	price domain.EnergyPrice
+}
+
+// PlanEVCharging returns the cheapest charging schedule, which can be non-contiguous, using the hours of
+// the window with a stored price. It returns an InvalidChargingPlan error if the energy can't be charged
+// in time and a PricesNotFound error if it could, but some hours of the window have no price yet.
+func (s PlansService) PlanEVCharging(ctx context.Context, charging EVCharging) (ChargingPlan, error) {
+	if charging.Start.IsZero() {
+		charging.Start = now()
+	}
+	if charging.EnergyKwh <= 0 || charging.ChargerPowerKw <= 0 {
+		return ChargingPlan{}, errors.NewDomainError(errors.InvalidChargingPlan, "energy and charger power must be positive")
+	}
+	if !charging.Deadline.After(charging.Start) {
+		return ChargingPlan{}, errors.NewDomainError(errors.InvalidChargingPlan, "deadline %s must be after start %s", charging.Deadline.Format(time.RFC3339), charging.Start.Format(time.RFC3339))
+	}
+	if charging.Deadline.Sub(charging.Start) > maxPlanWindow {
+		return ChargingPlan{}, errors.NewDomainError(errors.InvalidChargingPlan, "deadline can't be more than %s after start", maxPlanWindow)
+	}
+	if hours := charging.Deadline.Sub(charging.Start).Hours(); charging.EnergyKwh-planEnergyTolerance > hours*charging.ChargerPowerKw {
+		return ChargingPlan{}, errors.NewDomainError(errors.InvalidChargingPlan, "%v kWh can't be charged at %v kW before the deadline", charging.EnergyKwh, charging.ChargerPowerKw)
+	}
+	zone, err := s.zonesRepository.GetByID(ctx, charging.ZoneID)
+	if err != nil {
+		return ChargingPlan{}, err
+	}
+
+	slots, complete, err := s.priceSlots(ctx, zone, charging.Start, charging.Deadline)
+	if err != nil {
+		return ChargingPlan{}, err
+	}
+	var capacity float64
+	for _, slot := range slots {
+		capacity += slot.end.Sub(slot.start).Hours() * charging.ChargerPowerKw
+	}
+	if capacity < charging.EnergyKwh-planEnergyTolerance {
+		if !complete {
+			return ChargingPlan{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s for every hour until %s", charging.ZoneID.String(), charging.Deadline.Format(time.RFC3339))
+		}
+		return ChargingPlan{}, errors.NewDomainError(errors.InvalidChargingPlan, "%v kWh can't be charged at %v kW before the deadline", charging.EnergyKwh, charging.ChargerPowerKw)
+	}
+
+	immediate := fillSlots(slots, charging.EnergyKwh, charging.ChargerPowerKw)
+	cheapest := make([]priceSlot, len(slots))
+	copy(cheapest, slots)
+	sort.SliceStable(cheapest, func(i, j int) bool {
+		return cheapest[i].price.Amount().Cmp(cheapest[j].price.Amount()) < 0
+	})
+	planned := fillSlots(cheapest, charging.EnergyKwh, charging.ChargerPowerKw)
+	sort.Slice(planned, func(i, j int) bool {
+		return planned[i].Start.Before(planned[j].Start)
+	})
+
+	cost, immediateCost := slotsCost(planned).Round(2), slotsCost(immediate).Round(2)
+	plan := ChargingPlan{
+		ZoneID:        charging.ZoneID,
+		EnergyKwh:     charging.EnergyKwh,
+		Slots:         planned,
+		Cost:          cost.Float64(),
+		ImmediateCost: immediateCost.Float64(),
+		Savings:       immediateCost.Sub(cost).Float64(),
+	}
+	for i := range plan.Slots {
+		plan.Slots[i].Kwh = round(plan.Slots[i].Kwh, 3)
+	}
+
+	return plan, nil
+}
+
+// priceSlots returns the priced hours of the zone between start and end, trimmed to them and sorted by start.
+// complete is false if some hour of the window has no stored price.
+func (s PlansService) priceSlots(ctx context.Context, zone domain.Zone, start, end time.Time) ([]priceSlot, bool, error) {
+	zoneID := zone.ID()
+	var slots []priceSlot
+	for day := startOfDay(start, zone.Location()); day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		prices, err := s.pricesRepository.Query(ctx, &zoneID, &date)
+		if err != nil {
+			return nil, false, err
+		}
+		zonePrices, ok := findZonePrices(prices, zoneID)
+		if !ok {
+			continue
+		}
+		for _, value := range zonePrices.Values() {
+			slot := priceSlot{start: value.Datetime(), end: value.Datetime().Add(time.Hour), price: value.Price()}
+			if slot.start.Before(start) {
+				slot.start = start
+			}
+			if slot.end.After(end) {
+				slot.end = end
+			}
+			if slot.start.Before(slot.end) {
+				slots = append(slots, slot)
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].start.Before(slots[j].start)
+	})
+
+	var covered time.Duration
+	for _, slot := range slots {
+		covered += slot.end.Sub(slot.start)
+	}
+	return slots, covered == end.Sub(start), nil
+}
+
+// fillSlots charges energy kWh at power kW in the given slots, in order, each one from its start.
+// The cost of every slot is exact to 6 decimals.
+func fillSlots(slots []priceSlot, energy, power float64) []ChargingSlot {
+	var charged []ChargingSlot
+	for _, slot := range slots {
+		if energy <= planEnergyTolerance {
+			break
+		}
+		kwh := slot.end.Sub(slot.start).Hours() * power
+		if kwh > energy {
+			kwh = energy
+		}
+		energy -= kwh
+		charged = append(charged, ChargingSlot{
+			Start: slot.start,
+			End:   slot.start.Add(time.Duration(kwh / power * float64(time.Hour)).Round(time.Second)),
+			Kwh:   kwh,
+			Price: slot.price.In(domain.MWh).Amount().Float64(),
+			Cost:  slot.price.CostOf(domain.NewDecimalFromFloat(kwh)).Round(6).Float64(),
+		})
+	}
+	return charged
+}
+
+// slotsCost returns the exact sum of the costs of the slots.
+func slotsCost(slots []ChargingSlot) domain.Decimal {
+	var cost domain.Decimal
+	for _, slot := range slots {
+		cost = cost.Add(domain.NewDecimalFromFloat(slot.Cost))
+	}
+	return cost
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func newTestPlansService(t *testing.T) (PlansService, domain.ZoneID) {
+	t.Helper()
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(zone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+
+	// 2023-10-11 prices: 100 €/MWh every hour except 03:00 (20), 04:00 (30) and 05:00 (40).
+	dto := domain.PricesDto{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto}
+	for hour := 0; hour < 24; hour++ {
+		value := 100.0
+		switch hour {
+		case 3:
+			value = 20
+		case 4:
+			value = 30
+		case 5:
+			value = 40
+		}
+		datetime := time.Date(2023, 10, 11, hour, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: value})
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+	return NewPlansService(pricesRepository, zonesRepository), zone.ID()
+}
+
+func Test_PlansService_PlanEVCharging(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	service, zoneID := newTestPlansService(t)
+
+	t.Run("charges in the cheapest hours before the deadline", func(t *testing.T) {
+		plan, err := service.PlanEVCharging(context.Background(), EVCharging{
+			ZoneID: zoneID, EnergyKwh: 15, ChargerPowerKw: 7,
+			Start: parseTestDatetime(t, "2023-10-11T01:30:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-11T08:00:00+02:00"),
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, []ChargingSlot{
+			{Start: parseTestDatetime(t, "2023-10-11T03:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T04:00:00+02:00"), Kwh: 7, Price: 20, Cost: 0.14},
+			{Start: parseTestDatetime(t, "2023-10-11T04:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), Kwh: 7, Price: 30, Cost: 0.21},
+			{Start: parseTestDatetime(t, "2023-10-11T05:00:00+02:00"), End: parseTestDatetime(t, "2023-10-11T05:08:34+02:00"), Kwh: 1, Price: 40, Cost: 0.04},
+		}, plan.Slots)
+		require.Equal(t, 0.39, plan.Cost)
+		// Immediately: 3.5 kWh at 100 (01:30-02:00), 7 kWh at 100 (02:00-03:00) and 4.5 kWh at 20.
+		require.Equal(t, 1.14, plan.ImmediateCost)
+		require.Equal(t, 0.75, plan.Savings)
+	})
+
+	t.Run("when there is no start, it charges from now", func(t *testing.T) {
+		now = func() time.Time { return parseTestDatetime(t, "2023-10-11T04:30:00+02:00") }
+		defer restoreNow(time.Now)
+
+		plan, err := service.PlanEVCharging(context.Background(), EVCharging{
+			ZoneID: zoneID, EnergyKwh: 3.5, ChargerPowerKw: 7, Deadline: parseTestDatetime(t, "2023-10-11T08:00:00+02:00"),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, plan.Slots, 1)
+		require.Equal(t, parseTestDatetime(t, "2023-10-11T04:30:00+02:00"), plan.Slots[0].Start)
+		require.Equal(t, 0.11, plan.Cost)
+	})
+
+	t.Run("when the energy can't be charged before the deadline, it returns an invalid charging plan error", func(t *testing.T) {
+		_, err := service.PlanEVCharging(context.Background(), EVCharging{
+			ZoneID: zoneID, EnergyKwh: 50, ChargerPowerKw: 7,
+			Start: parseTestDatetime(t, "2023-10-11T01:30:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-11T08:00:00+02:00"),
+		})
+
+		require.Equal(t, errors.InvalidChargingPlan, errors.Code(err))
+	})
+
+	t.Run("when the hours needed have no prices yet, it returns a prices not found error", func(t *testing.T) {
+		_, err := service.PlanEVCharging(context.Background(), EVCharging{
+			ZoneID: zoneID, EnergyKwh: 30, ChargerPowerKw: 7,
+			Start: parseTestDatetime(t, "2023-10-11T22:00:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-12T08:00:00+02:00"),
+		})
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+
+	t.Run("when the input is invalid, it returns an invalid charging plan error", func(t *testing.T) {
+		for _, charging := range []EVCharging{
+			{ZoneID: zoneID, EnergyKwh: 0, ChargerPowerKw: 7, Start: parseTestDatetime(t, "2023-10-11T01:00:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-11T08:00:00+02:00")},
+			{ZoneID: zoneID, EnergyKwh: 10, ChargerPowerKw: -1, Start: parseTestDatetime(t, "2023-10-11T01:00:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-11T08:00:00+02:00")},
+			{ZoneID: zoneID, EnergyKwh: 10, ChargerPowerKw: 7, Start: parseTestDatetime(t, "2023-10-11T08:00:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-11T01:00:00+02:00")},
+			{ZoneID: zoneID, EnergyKwh: 10, ChargerPowerKw: 7, Start: parseTestDatetime(t, "2023-10-11T01:00:00+02:00"), Deadline: parseTestDatetime(t, "2023-10-14T01:00:00+02:00")},
+		} {
+			_, err := service.PlanEVCharging(context.Background(), charging)
+
+			require.Equal(t, errors.InvalidChargingPlan, errors.Code(err))
+		}
+	})
+}
@@ -3,50 +3,67 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
 	"pvpc-backend/pkg/logger"
 )
 
 var now = time.Now
 
+// pricesPublicationHour is the hour (zone local time) after which the next day's PVPC prices are expected to be published.
+const pricesPublicationHour = 21
+
 // PricesService is the domain service that manages operations over Price's.
 type PricesService struct {
-	mainPricesProvider     domain.PricesProvider
-	fallbackPricesProvider domain.PricesProvider
-	pricesRepository       domain.PricesRepository
-	zonesRepository        domain.ZonesRepository
+	pricesProviders      []domain.PricesProvider
+	pricesRepository     domain.PricesRepository
+	zonesRepository      domain.ZonesRepository
+	quarantineRepository domain.QuarantineRepository
+	anomalyThresholds    domain.AnomalyThresholds
 }
 
-// NewPricesService returns a new ListingService.
+// NewPricesService returns a new PricesService.
+//
+// pricesProviders is the ordered chain of providers used to fetch prices: each provider is only
+// asked for the zones that the previous ones didn't return.
 func NewPricesService(
-	mainPricesProvider domain.PricesProvider,
-	fallbackPricesProvider domain.PricesProvider,
+	pricesProviders []domain.PricesProvider,
 	pricesRepository domain.PricesRepository,
 	zonesRepository domain.ZonesRepository,
 ) PricesService {
 	return PricesService{
-		mainPricesProvider:     mainPricesProvider,
-		fallbackPricesProvider: fallbackPricesProvider,
-		pricesRepository:       pricesRepository,
-		zonesRepository:        zonesRepository,
+		pricesProviders:  pricesProviders,
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
 	}
 }
 
 // FetchAndStorePricesFromREE calls REE APIs to fetch prices and stores them in the database.
 func (s PricesService) FetchAndStorePricesFromREE(ctx context.Context) ([]domain.PricesID, error) {
-	var today, tomorrow time.Time
-	var zonesToFetchToday []domain.Zone
-	var zonesToFetchTomorrow []domain.Zone
+	return s.fetchAndStorePrices(ctx, domain.PVPCPrices)
+}
 
+// FetchAndStoreSurplusPricesFromREE calls REE APIs to fetch the self-consumption surplus compensation
+// prices and stores them in the database, the same way FetchAndStorePricesFromREE does with the PVPC ones.
+// Only the providers that implement domain.SurplusPricesProvider are asked for them.
+func (s PricesService) FetchAndStoreSurplusPricesFromREE(ctx context.Context) ([]domain.PricesID, error) {
+	return s.fetchAndStorePrices(ctx, domain.SurplusPrices)
+}
+
+// fetchAndStorePrices fetches the prices of pricesType missing from storage for today and,
+// once published, for tomorrow, and stores the ones that pass the anomaly checks.
+func (s PricesService) fetchAndStorePrices(ctx context.Context, pricesType domain.PricesType) ([]domain.PricesID, error) {
 	allZones, err := s.zonesRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 	pricesMapByZoneID := make(map[domain.ZoneID]domain.Prices)
 
-	allPrices, err := s.pricesRepository.Query(ctx, nil, nil)
+	allPrices, err := s.queryPrices(ctx, pricesType, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -55,89 +72,488 @@ func (s PricesService) FetchAndStorePricesFromREE(ctx context.Context) ([]domain
 		pricesMapByZoneID[prices.Zone().ID()] = prices
 	}
 
-	now := now()
-	locationStr := "Europe/Madrid"
-	loc, err := time.LoadLocation(locationStr)
+	fetches := zonesFetches(allZones, pricesMapByZoneID, now(), pricesPublicationHour)
+	fetchedCh := make([]chan []domain.Prices, len(fetches))
+	for i, fetch := range fetches {
+		fetchedCh[i] = make(chan []domain.Prices)
+		go func(ch chan []domain.Prices, fetch zonesFetch) {
+			ch <- s.fetchPrices(ctx, pricesType, fetch.zones, fetch.day)
+		}(fetchedCh[i], fetch)
+	}
+	var fetched []domain.Prices
+	for _, ch := range fetchedCh {
+		fetched = append(fetched, <-ch...)
+	}
+
+	pricesToStore := s.screenPrices(ctx, pricesType, fetched)
+	if len(pricesToStore) == 0 {
+		return nil, nil
+	}
+
+	err = s.pricesRepository.Save(ctx, pricesToStore)
 
 	if err != nil {
-		logger.ErrorContext(ctx, fmt.Sprintf("error loading %s timezone. Using server default: %s", locationStr, now.Location().String()), "err", err)
-		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	} else {
-		today = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+		return nil, err
 	}
-	tomorrow = today.AddDate(0, 0, 1)
 
-	for _, zone := range allZones {
-		if _, ok := pricesMapByZoneID[zone.ID()]; !ok {
-			zonesToFetchToday = append(zonesToFetchToday, zone)
-			if now.Hour() > 20 {
-				zonesToFetchTomorrow = append(zonesToFetchTomorrow, zone)
-			}
-		} else {
-			if pricesMapByZoneID[zone.ID()].Date().Before(today) {
-				zonesToFetchToday = append(zonesToFetchToday, zone)
-			}
-			if now.Hour() > 20 && pricesMapByZoneID[zone.ID()].Date().Before(tomorrow) {
-				zonesToFetchTomorrow = append(zonesToFetchTomorrow, zone)
+	pricesIDs := make([]domain.PricesID, len(pricesToStore))
+	for i, price := range pricesToStore {
+		pricesIDs[i] = price.ID()
+	}
+	return pricesIDs, nil
+}
+
+// zonesFetch is a day to fetch prices of, and the zones to fetch them for.
+type zonesFetch struct {
+	day   time.Time
+	zones []domain.Zone
+}
+
+// zonesFetches returns the zones whose latest stored prices are older than today and, from publicationHour on,
+// than tomorrow, grouped by day. Today and tomorrow are the days of each zone's time zone.
+func zonesFetches(zones []domain.Zone, latest map[domain.ZoneID]domain.Prices, now time.Time, publicationHour int) []zonesFetch {
+	var fetches []zonesFetch
+	fetchZone := func(day time.Time, zone domain.Zone) {
+		for i := range fetches {
+			if fetches[i].day.Equal(day) {
+				fetches[i].zones = append(fetches[i].zones, zone)
+				return
 			}
 		}
+		fetches = append(fetches, zonesFetch{day: day, zones: []domain.Zone{zone}})
+	}
 
+	for _, zone := range zones {
+		loc := zone.Location()
+		today := startOfDay(now, loc)
+		tomorrow := today.AddDate(0, 0, 1)
+		prices, ok := latest[zone.ID()]
+		if !ok || prices.Date().Before(today) {
+			fetchZone(today, zone)
+		}
+		if now.In(loc).Hour() >= publicationHour && (!ok || prices.Date().Before(tomorrow)) {
+			fetchZone(tomorrow, zone)
+		}
 	}
+	return fetches
+}
 
-	todayCh := make(chan []domain.Prices)
-	tomorrowCh := make(chan []domain.Prices)
+// fetchPrices walks the providers chain in order until prices of pricesType for all the given zones are fetched.
+// Each provider is only asked for the zones still missing, so a partial response from one provider
+// is completed by the next ones. Providers that don't offer pricesType are skipped.
+func (s PricesService) fetchPrices(ctx context.Context, pricesType domain.PricesType, zones []domain.Zone, date time.Time) []domain.Prices {
+	var fetchedPrices []domain.Prices
+	pendingZones := zones
 
-	go func() {
-		if len(zonesToFetchToday) == 0 {
-			todayCh <- nil
-			return
+	for i, provider := range s.pricesProviders {
+		name := providerName(provider, i)
+		if len(pendingZones) == 0 {
+			break
+		}
+		fetch, ok := providerFetchFunc(provider, pricesType)
+		if !ok {
+			continue
+		}
+		prices, err := fetch(ctx, pendingZones, date)
+		if err != nil {
+			logger.WarnContext(ctx, "couldn't fetch prices from provider", "provider", name, "type", pricesType.String(), "date", date.Format(time.DateOnly), "err", err)
+			continue
 		}
-		todayPrices, err := s.mainPricesProvider.FetchPVPCPrices(ctx, zonesToFetchToday, today)
-		if err != nil || len(todayPrices) == 0 {
-			logger.WarnContext(ctx, "couldn't fetch today prices from main provider. Using fallback", "err", err)
-			todayPrices, err = s.fallbackPricesProvider.FetchPVPCPrices(ctx, zonesToFetchToday, today)
-			if err != nil || len(todayPrices) == 0 {
-				logger.ErrorContext(ctx, "couldn't fetch today prices from fallback provider", "err", err)
+
+		var missingZones []domain.Zone
+		for _, zone := range pendingZones {
+			if zonePrices, ok := findZonePrices(prices, zone.ID()); ok {
+				fetchedPrices = append(fetchedPrices, zonePrices)
+			} else {
+				missingZones = append(missingZones, zone)
 			}
 		}
-		todayCh <- todayPrices
-	}()
+		if len(missingZones) > 0 {
+			logger.WarnContext(ctx, "provider didn't return prices for some zones", "provider", name, "type", pricesType.String(), "date", date.Format(time.DateOnly), "zones", zoneIDs(missingZones))
+		}
+		pendingZones = missingZones
+	}
+
+	if len(pendingZones) > 0 {
+		logger.ErrorContext(ctx, "couldn't fetch prices from any provider", "type", pricesType.String(), "date", date.Format(time.DateOnly), "zones", zoneIDs(pendingZones))
+	}
+
+	return fetchedPrices
+}
 
-	go func() {
-		if len(zonesToFetchTomorrow) == 0 {
-			tomorrowCh <- nil
-			return
+// providerName returns the name of the provider to identify it in logs or, if it doesn't
+// implement domain.NamedPricesProvider, its position in the providers chain.
+func providerName(provider domain.PricesProvider, position int) string {
+	if named, ok := provider.(domain.NamedPricesProvider); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("#%d", position)
+}
+
+// providerFetchFunc returns the provider's method to fetch prices of pricesType, if it offers them.
+func providerFetchFunc(provider domain.PricesProvider, pricesType domain.PricesType) (func(context.Context, []domain.Zone, time.Time) ([]domain.Prices, error), bool) {
+	switch pricesType {
+	case domain.PVPCPrices:
+		return provider.FetchPVPCPrices, true
+	case domain.SurplusPrices:
+		if surplusProvider, ok := provider.(domain.SurplusPricesProvider); ok {
+			return surplusProvider.FetchSurplusPrices, true
 		}
-		tomorrowPrices, err := s.mainPricesProvider.FetchPVPCPrices(ctx, zonesToFetchTomorrow, tomorrow)
-		if err != nil || len(tomorrowPrices) == 0 {
-			logger.WarnContext(ctx, "couldn't fetch tomorrow prices from main provider. Using fallback", "err", err)
-			tomorrowPrices, err = s.fallbackPricesProvider.FetchPVPCPrices(ctx, zonesToFetchTomorrow, tomorrow)
-			if err != nil || len(tomorrowPrices) == 0 {
-				logger.ErrorContext(ctx, "couldn't fetch tomorrow prices from fallback provider", "err", err)
-			}
+	}
+	return nil, false
+}
+
+// queryPrices queries the stored prices of pricesType. PVPC prices are the ones of PricesRepository.Query.
+func (s PricesService) queryPrices(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	if pricesType == domain.PVPCPrices {
+		return s.pricesRepository.Query(ctx, zoneID, date)
+	}
+	return s.pricesRepository.QueryByType(ctx, pricesType, zoneID, date)
+}
+
+// startOfDay returns the midnight of the day of t in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func findZonePrices(prices []domain.Prices, zoneID domain.ZoneID) (domain.Prices, bool) {
+	for _, p := range prices {
+		if p.Zone().ID() == zoneID {
+			return p, true
 		}
-		tomorrowCh <- tomorrowPrices
-	}()
+	}
+	return domain.Prices{}, false
+}
 
-	pricesToStore := append(<-todayCh, <-tomorrowCh...)
-	if len(pricesToStore) == 0 {
-		return nil, nil
+func zoneIDs(zones []domain.Zone) []string {
+	ids := make([]string, len(zones))
+	for i, zone := range zones {
+		ids[i] = zone.ID().String()
 	}
+	return ids
+}
 
-	err = s.pricesRepository.Save(ctx, pricesToStore)
+// GetPricesRevisions returns the current version of the prices with the given ID
+// and its previous versions, from the oldest to the newest one.
+func (s PricesService) GetPricesRevisions(ctx context.Context, id domain.PricesID) (domain.Prices, []domain.PricesRevision, error) {
+	zoneID := id.ZoneID()
+	date, err := id.Date()
+	if err != nil {
+		return domain.Prices{}, nil, err
+	}
 
+	prices, err := s.pricesRepository.Query(ctx, &zoneID, &date)
 	if err != nil {
-		return nil, err
+		return domain.Prices{}, nil, err
+	}
+	if len(prices) == 0 {
+		return domain.Prices{}, nil, errors.NewDomainError(errors.PricesNotFound, "prices not found: %s", id.String())
 	}
 
-	pricesIDs := make([]domain.PricesID, len(pricesToStore))
-	for i, price := range pricesToStore {
-		pricesIDs[i] = price.ID()
+	revisions, err := s.pricesRepository.ListRevisions(ctx, id)
+	if err != nil {
+		return domain.Prices{}, nil, err
 	}
-	return pricesIDs, nil
+
+	return prices[0], revisions, nil
 }
 
 func (s PricesService) GetPrices(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
 	return s.pricesRepository.Query(ctx, zoneID, date)
 
 }
+
+// GetPricesByType returns the prices of pricesType for the given date and zoneID, with the same semantics as GetPrices.
+func (s PricesService) GetPricesByType(ctx context.Context, pricesType domain.PricesType, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	return s.queryPrices(ctx, pricesType, zoneID, date)
+}
+
+// priceLevelsRollingDays is the number of days, ending on the prices day, of the rolling baseline of price levels.
+const priceLevelsRollingDays = 30
+
+// ClassifyPriceLevels returns the price level of every hourly value of the given prices, in the same order,
+// compared to the baseline of levels: the prices of the same day or the ones of the same type and zone
+// stored for the rolling 30 days ending on that day.
+func (s PricesService) ClassifyPriceLevels(ctx context.Context, prices []domain.Prices, levels domain.PriceLevels) ([][]domain.PriceLevel, error) {
+	// baselineDays caches the stored prices of each type and day, of every zone, for the rolling baseline.
+	baselineDays := make(map[string][]domain.Prices)
+
+	classified := make([][]domain.PriceLevel, len(prices))
+	for i, p := range prices {
+		var baseline []float64
+		if levels.Baseline() == domain.Rolling30DaysBaseline {
+			last, err := p.ID().Date()
+			if err != nil {
+				return nil, err
+			}
+			for day := last.AddDate(0, 0, 1-priceLevelsRollingDays); !day.After(last); day = day.AddDate(0, 0, 1) {
+				day := day
+				key := p.Type().String() + day.Format(time.DateOnly)
+				dayPrices, ok := baselineDays[key]
+				if !ok {
+					dayPrices, err = s.queryPrices(ctx, p.Type(), nil, &day)
+					if err != nil {
+						return nil, err
+					}
+					baselineDays[key] = dayPrices
+				}
+				if zonePrices, ok := findZonePrices(dayPrices, p.Zone().ID()); ok {
+					for _, value := range zonePrices.Values() {
+						baseline = append(baseline, value.Value())
+					}
+				}
+			}
+		} else {
+			for _, value := range p.Values() {
+				baseline = append(baseline, value.Value())
+			}
+		}
+
+		classify := levels.Classifier(baseline)
+		classified[i] = make([]domain.PriceLevel, len(p.Values()))
+		for j, value := range p.Values() {
+			classified[i][j] = classify(value.Value())
+		}
+	}
+
+	return classified, nil
+}
+
+// maxAggregatesYears is the longest period, in years, whose prices can be aggregated.
+const maxAggregatesYears = 10
+
+// PricesAggregateReport is the aggregate of the PVPC prices of a period along with, when any prices
+// of it are stored, the aggregate of the same period of the previous year.
+type PricesAggregateReport struct {
+	Aggregate    domain.PricesAggregate
+	PreviousYear *domain.PricesAggregate
+}
+
+// AggregatePrices returns the aggregates of the PVPC prices of the zone from from to to (UTC midnights,
+// both included) by periods of granularity, each one with the same period of the previous year.
+// Periods partially in the range only aggregate the days in it, and so do their previous year ones.
+func (s PricesService) AggregatePrices(ctx context.Context, zoneID domain.ZoneID, granularity domain.AggregateGranularity, from, to time.Time) ([]PricesAggregateReport, error) {
+	if to.Before(from) {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "to date %s can't be before from date %s", to.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+	if to.After(from.AddDate(maxAggregatesYears, 0, 0)) {
+		return nil, errors.NewDomainError(errors.InvalidDateRange, "prices can't be aggregated for more than %d years", maxAggregatesYears)
+	}
+	if _, err := s.zonesRepository.GetByID(ctx, zoneID); err != nil {
+		return nil, err
+	}
+
+	aggregates, err := s.pricesRepository.Aggregate(ctx, zoneID, granularity, from, to)
+	if err != nil {
+		return nil, err
+	}
+	previousAggregates, err := s.pricesRepository.Aggregate(ctx, zoneID, granularity, granularity.PreviousYear(from), granularity.PreviousYear(to))
+	if err != nil {
+		return nil, err
+	}
+	previousYear := make(map[string]domain.PricesAggregate, len(previousAggregates))
+	for _, aggregate := range previousAggregates {
+		previousYear[aggregate.PeriodStart().Format(time.DateOnly)] = aggregate
+	}
+
+	reports := make([]PricesAggregateReport, len(aggregates))
+	for i, aggregate := range aggregates {
+		reports[i] = PricesAggregateReport{Aggregate: aggregate}
+		previousPeriodStart := granularity.PeriodStart(granularity.PreviousYear(aggregate.PeriodStart()))
+		if previous, ok := previousYear[previousPeriodStart.Format(time.DateOnly)]; ok {
+			reports[i].PreviousYear = &previous
+		}
+	}
+
+	return reports, nil
+}
+
+const (
+	// AgainstYesterday compares a day with the previous one.
+	AgainstYesterday = "yesterday"
+	// AgainstLastWeek compares a day with the same day of the previous week.
+	AgainstLastWeek = "last_week"
+	// AgainstLastYear compares a day with the same day of the previous year.
+	AgainstLastYear = "last_year"
+)
+
+// comparisonMovers is the number of hours with the biggest price changes in a PricesComparison.
+const comparisonMovers = 3
+
+// AgainstDay returns the day to compare date with: yesterday, last_week, last_year or a YYYY-MM-DD day.
+// An empty against is yesterday.
+func AgainstDay(date time.Time, against string) (time.Time, error) {
+	switch against {
+	case "", AgainstYesterday:
+		return date.AddDate(0, 0, -1), nil
+	case AgainstLastWeek:
+		return date.AddDate(0, 0, -7), nil
+	case AgainstLastYear:
+		return date.AddDate(-1, 0, 0), nil
+	}
+	day, err := time.Parse(time.DateOnly, against)
+	if err != nil {
+		return time.Time{}, errors.NewDomainError(errors.InvalidDateRange, "invalid day to compare with: %s. It must be one of: %s, %s, %s or a YYYY-MM-DD day",
+			against, AgainstYesterday, AgainstLastWeek, AgainstLastYear)
+	}
+	return day, nil
+}
+
+// PricesComparison is the comparison of the PVPC prices of a zone on a day with the ones of another day.
+// Changes are the prices of the day minus the ones of the other day, in €/MWh, and the percentages are
+// relative to the other day's prices, nil when those are 0.
+type PricesComparison struct {
+	ZoneID        domain.ZoneID
+	Date          time.Time
+	Against       time.Time
+	Mean          float64
+	AgainstMean   float64
+	MeanChange    float64
+	MeanChangePct *float64
+	// Hours are the hours of both days matched by their local time, sorted by it. On DST change days,
+	// the hours only one of the days has come without the other day's price nor change.
+	Hours []HourlyPricesComparison
+	// Movers are the hours with the biggest absolute price changes, from the biggest one.
+	Movers []HourlyPricesComparison
+}
+
+// HourlyPricesComparison is the comparison of the prices of the same local hour of two days.
+type HourlyPricesComparison struct {
+	Hour            string // HH:MM local time
+	Datetime        *time.Time
+	Value           *float64
+	AgainstDatetime *time.Time
+	AgainstValue    *float64
+	Change          *float64
+	ChangePct       *float64
+}
+
+// ComparePrices compares the PVPC prices of the zone on date (a UTC midnight), today if it is nil, with the
+// ones of the against day (see AgainstDay). It returns a PricesNotFound error if the prices of any of the days aren't stored.
+func (s PricesService) ComparePrices(ctx context.Context, zoneID domain.ZoneID, day *time.Time, against string) (PricesComparison, error) {
+	var date time.Time
+	if day != nil {
+		date = *day
+	} else {
+		loc, err := zoneLocation(ctx, s.zonesRepository, zoneID)
+		if err != nil {
+			return PricesComparison{}, err
+		}
+		today := startOfDay(now(), loc)
+		date = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	againstDate, err := AgainstDay(date, against)
+	if err != nil {
+		return PricesComparison{}, err
+	}
+
+	prices, err := s.zoneDayPrices(ctx, zoneID, date)
+	if err != nil {
+		return PricesComparison{}, err
+	}
+	againstPrices, err := s.zoneDayPrices(ctx, zoneID, againstDate)
+	if err != nil {
+		return PricesComparison{}, err
+	}
+
+	mean, againstMean := meanPrice(prices), meanPrice(againstPrices)
+	comparison := PricesComparison{
+		ZoneID:        zoneID,
+		Date:          date,
+		Against:       againstDate,
+		Mean:          round(mean, 2),
+		AgainstMean:   round(againstMean, 2),
+		MeanChange:    round(mean-againstMean, 2),
+		MeanChangePct: changePct(mean-againstMean, againstMean),
+	}
+
+	// Hours are matched by their zone local time and, for the hour repeated when DST ends, by its occurrence.
+	loc := prices.Zone().Location()
+	type hourKey struct {
+		hour       string
+		occurrence int
+	}
+	hours := make(map[hourKey]*HourlyPricesComparison)
+	var keys []hourKey
+	hourOf := func(value domain.HourlyPrice, seen map[string]int) hourKey {
+		hour := value.Datetime().In(loc).Format("15:04")
+		seen[hour]++
+		key := hourKey{hour: hour, occurrence: seen[hour]}
+		if _, ok := hours[key]; !ok {
+			hours[key] = &HourlyPricesComparison{Hour: hour}
+			keys = append(keys, key)
+		}
+		return key
+	}
+	seen := make(map[string]int)
+	for _, value := range prices.Values() {
+		datetime, price := value.Datetime(), value.Value()
+		hour := hours[hourOf(value, seen)]
+		hour.Datetime, hour.Value = &datetime, &price
+	}
+	seen = make(map[string]int)
+	for _, value := range againstPrices.Values() {
+		datetime, price := value.Datetime(), value.Value()
+		hour := hours[hourOf(value, seen)]
+		hour.AgainstDatetime, hour.AgainstValue = &datetime, &price
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].hour != keys[j].hour {
+			return keys[i].hour < keys[j].hour
+		}
+		return keys[i].occurrence < keys[j].occurrence
+	})
+
+	comparison.Hours = make([]HourlyPricesComparison, len(keys))
+	var movers []HourlyPricesComparison
+	for i, key := range keys {
+		hour := hours[key]
+		if hour.Value != nil && hour.AgainstValue != nil {
+			change := round(*hour.Value-*hour.AgainstValue, 2)
+			hour.Change = &change
+			hour.ChangePct = changePct(*hour.Value-*hour.AgainstValue, *hour.AgainstValue)
+			movers = append(movers, *hour)
+		}
+		comparison.Hours[i] = *hour
+	}
+	sort.SliceStable(movers, func(i, j int) bool {
+		return math.Abs(*movers[i].Change) > math.Abs(*movers[j].Change)
+	})
+	if len(movers) > comparisonMovers {
+		movers = movers[:comparisonMovers]
+	}
+	comparison.Movers = movers
+
+	return comparison, nil
+}
+
+// zoneDayPrices returns the stored PVPC prices of the zone on date, or a PricesNotFound error.
+func (s PricesService) zoneDayPrices(ctx context.Context, zoneID domain.ZoneID, date time.Time) (domain.Prices, error) {
+	prices, err := s.pricesRepository.Query(ctx, &zoneID, &date)
+	if err != nil {
+		return domain.Prices{}, err
+	}
+	zonePrices, ok := findZonePrices(prices, zoneID)
+	if !ok || len(zonePrices.Values()) == 0 {
+		return domain.Prices{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s on %s", zoneID.String(), date.Format(time.DateOnly))
+	}
+	return zonePrices, nil
+}
+
+func meanPrice(prices domain.Prices) float64 {
+	var sum float64
+	for _, value := range prices.Values() {
+		sum += value.Value()
+	}
+	return sum / float64(len(prices.Values()))
+}
+
+// changePct returns change as a percentage of base, rounded to 2 decimals, or nil if base is 0.
+func changePct(change, base float64) *float64 {
+	if base == 0 {
+		return nil
+	}
+	pct := round(change/math.Abs(base)*100, 2)
+	return &pct
+}
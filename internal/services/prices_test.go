@@ -1,7 +1,11 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -12,6 +16,10 @@ import (
 	"pvpc-backend/internal/domain"
 	"pvpc-backend/internal/domain/errors"
 	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/providers/esios"
+	"pvpc-backend/internal/platform/providers/fakeree"
+	"pvpc-backend/internal/platform/providers/redataapi"
+	"pvpc-backend/internal/platform/storage/inmemory"
 	"pvpc-backend/pkg/logger"
 )
 
@@ -48,7 +56,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mockError := errors.NewDomainError(errors.PersistenceError, "mock-error")
 		zonesRepositoryMock.On("GetAll", ctx).Return(nil, mockError)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.Error(t, err)
 		require.Equal(t, mockError, err)
@@ -67,7 +75,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		zonesRepositoryMock.On("GetAll", ctx).Return([]domain.Zone{testZone}, nil)
 		pricesRepositoryMock.On("Query", ctx, (*domain.ZoneID)(nil), (*time.Time)(nil)).Return(nil, mockError)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.Error(t, err)
 		require.Equal(t, mockError, err)
@@ -89,7 +97,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(mockError)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.Error(t, err)
 		require.Equal(t, mockError, err)
@@ -112,7 +120,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return(nil, mockError)
 		fallbackPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return(nil, mockError)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Nil(t, res)
@@ -135,7 +143,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return([]domain.Prices{}, nil)
 		fallbackPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return([]domain.Prices{}, nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Nil(t, res)
@@ -164,7 +172,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, todayTestDate).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Equal(t, []domain.PricesID{testPricesFetchId}, res)
@@ -194,7 +202,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, tomorrowTestDate).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Equal(t, []domain.PricesID{testPricesFetchId, testPricesFetchId}, res)
@@ -227,7 +235,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, today).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Equal(t, []domain.PricesID{testPricesFetchId}, res)
@@ -262,7 +270,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, tomorrow).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Equal(t, []domain.PricesID{testPricesFetchId, testPricesFetchId}, res)
@@ -291,7 +299,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		zonesRepositoryMock.On("GetAll", ctx).Return([]domain.Zone{testZone}, nil)
 		pricesRepositoryMock.On("Query", ctx, (*domain.ZoneID)(nil), (*time.Time)(nil)).Return([]domain.Prices{todayPrices}, nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Nil(t, res)
@@ -325,7 +333,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, tomorrow).Return([]domain.Prices{testPricesFetch}, nil)
 		pricesRepositoryMock.On("Save", ctx, mock.Anything).Return(nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Equal(t, []domain.PricesID{testPricesFetchId}, res)
@@ -355,7 +363,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		zonesRepositoryMock.On("GetAll", ctx).Return([]domain.Zone{testZone}, nil)
 		pricesRepositoryMock.On("Query", ctx, (*domain.ZoneID)(nil), (*time.Time)(nil)).Return([]domain.Prices{tomorrowPrices}, nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Nil(t, res)
@@ -385,7 +393,7 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		zonesRepositoryMock.On("GetAll", ctx).Return([]domain.Zone{testZone}, nil)
 		pricesRepositoryMock.On("Query", ctx, (*domain.ZoneID)(nil), (*time.Time)(nil)).Return([]domain.Prices{tomorrowPrices}, nil)
 
-		pricesService := NewPricesService(mainPricesProviderMock, fallbackPricesProviderMock, pricesRepositoryMock, zonesRepositoryMock)
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepositoryMock, zonesRepositoryMock)
 		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
 		require.NoError(t, err)
 		require.Nil(t, res)
@@ -397,3 +405,610 @@ func Test_PricesService_FetchAndStorePricesFromREE(t *testing.T) {
 		pricesRepositoryMock.AssertNotCalled(t, "Save", ctx, mock.Anything)
 	})
 }
+
+func Test_PricesService_FetchAndStorePricesFromREE_InMemoryStorage(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	testZoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	testZone, err := domain.NewZone(testZoneDto)
+	require.NoError(t, err)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	todayTestDate := time.Date(2020, 1, 1, 21, 0, 0, 0, loc)
+	today := time.Date(2020, 1, 1, 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+	now = func() time.Time { return todayTestDate }
+	defer restoreNow(time.Now)
+
+	t.Run("stored prices are not fetched again", func(t *testing.T) {
+		mainPricesProviderMock := new(mocks.PricesProvider)
+		fallbackPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(testZone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+
+		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, today).Return([]domain.Prices{newTestPrices(t, domain.PVPCPrices, testZoneDto, today, 0.123)}, nil).Once()
+		mainPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, tomorrow).Return([]domain.Prices{newTestPrices(t, domain.PVPCPrices, testZoneDto, tomorrow, 0.123)}, nil).Once()
+
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+
+		res, err = pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Nil(t, res)
+
+		stored, err := pricesService.GetPrices(ctx, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, "ZON-2020-01-02", stored[0].ID().String())
+
+		mainPricesProviderMock.AssertExpectations(t)
+		fallbackPricesProviderMock.AssertNotCalled(t, "FetchPVPCPrices", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("prices from the fallback provider are stored when main provider fails", func(t *testing.T) {
+		mainPricesProviderMock := new(mocks.PricesProvider)
+		fallbackPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(testZone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+		mockError := errors.NewDomainError(errors.ProviderError, "mock-error")
+
+		mainPricesProviderMock.On("FetchPVPCPrices", ctx, mock.Anything, mock.Anything).Return(nil, mockError)
+		fallbackPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, today).Return([]domain.Prices{newTestPrices(t, domain.PVPCPrices, testZoneDto, today, 0.123)}, nil)
+		fallbackPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{testZone}, tomorrow).Return(nil, mockError)
+
+		pricesService := NewPricesService([]domain.PricesProvider{mainPricesProviderMock, fallbackPricesProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		date := today
+		stored, err := pricesService.GetPrices(ctx, nil, &date)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, "ZON-2020-01-01", stored[0].ID().String())
+
+		mainPricesProviderMock.AssertExpectations(t)
+		fallbackPricesProviderMock.AssertExpectations(t)
+	})
+}
+
+// surplusPricesProviderMock is a PricesProvider that also offers surplus compensation prices.
+type surplusPricesProviderMock struct {
+	*mocks.PricesProvider
+	*mocks.SurplusPricesProvider
+}
+
+// namedPricesProviderMock is a PricesProvider that also tells its name.
+type namedPricesProviderMock struct {
+	*mocks.PricesProvider
+	name string
+}
+
+func (m namedPricesProviderMock) Name() string {
+	return m.name
+}
+
+func Test_PricesService_FetchAndStoreSurplusPricesFromREE(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	testZoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	testZone, err := domain.NewZone(testZoneDto)
+	require.NoError(t, err)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	today := time.Date(2020, 1, 1, 0, 0, 0, 0, loc)
+	now = func() time.Time { return time.Date(2020, 1, 1, 12, 0, 0, 0, loc) }
+	defer restoreNow(time.Now)
+
+	t.Run("surplus prices are only fetched from the providers that offer them", func(t *testing.T) {
+		pvpcOnlyProviderMock := new(mocks.PricesProvider)
+		surplusProviderMock := surplusPricesProviderMock{new(mocks.PricesProvider), new(mocks.SurplusPricesProvider)}
+		zonesRepository := inmemory.NewZonesRepository(testZone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+
+		surplusProviderMock.SurplusPricesProvider.On("FetchSurplusPrices", ctx, []domain.Zone{testZone}, today).Return([]domain.Prices{newTestPrices(t, domain.SurplusPrices, testZoneDto, today, 0.05)}, nil).Once()
+
+		pricesService := NewPricesService([]domain.PricesProvider{pvpcOnlyProviderMock, surplusProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStoreSurplusPricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "ZON-2020-01-01", res[0].String())
+
+		stored, err := pricesService.GetPricesByType(ctx, domain.SurplusPrices, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, domain.SurplusPrices, stored[0].Type())
+
+		stored, err = pricesService.GetPrices(ctx, nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+
+		surplusProviderMock.SurplusPricesProvider.AssertExpectations(t)
+		pvpcOnlyProviderMock.AssertNotCalled(t, "FetchPVPCPrices", mock.Anything, mock.Anything, mock.Anything)
+		surplusProviderMock.PricesProvider.AssertNotCalled(t, "FetchPVPCPrices", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stored PVPC prices don't prevent fetching the surplus ones", func(t *testing.T) {
+		surplusProviderMock := surplusPricesProviderMock{new(mocks.PricesProvider), new(mocks.SurplusPricesProvider)}
+		zonesRepository := inmemory.NewZonesRepository(testZone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+		require.NoError(t, pricesRepository.Save(ctx, []domain.Prices{newTestPrices(t, domain.PVPCPrices, testZoneDto, today, 0.05)}))
+
+		surplusProviderMock.SurplusPricesProvider.On("FetchSurplusPrices", ctx, []domain.Zone{testZone}, today).Return([]domain.Prices{newTestPrices(t, domain.SurplusPrices, testZoneDto, today, 0.05)}, nil).Once()
+
+		pricesService := NewPricesService([]domain.PricesProvider{surplusProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStoreSurplusPricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+
+		surplusProviderMock.SurplusPricesProvider.AssertExpectations(t)
+	})
+
+	t.Run("no prices are stored when no provider offers surplus prices", func(t *testing.T) {
+		pvpcOnlyProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(testZone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+
+		pricesService := NewPricesService([]domain.PricesProvider{pvpcOnlyProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStoreSurplusPricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Nil(t, res)
+
+		pvpcOnlyProviderMock.AssertNotCalled(t, "FetchPVPCPrices", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_PricesService_FetchAndStorePricesFromREE_ProvidersChain(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	today := time.Date(2020, 1, 1, 0, 0, 0, 0, loc)
+	now = func() time.Time { return time.Date(2020, 1, 1, 10, 0, 0, 0, loc) }
+	defer restoreNow(time.Now)
+
+	zones := make([]domain.Zone, 0, 3)
+	zonesPrices := make([]domain.Prices, 0, 3)
+	for i, id := range []string{"AAA", "BBB", "CCC"} {
+		zoneDto := domain.ZoneDto{ID: id, ExternalID: fmt.Sprintf("%d", i), Name: id}
+		zone, err := domain.NewZone(zoneDto)
+		require.NoError(t, err)
+		zones = append(zones, zone)
+		prices, err := domain.NewPrices(domain.PricesDto{ID: id + "-2020-01-01", Zone: zoneDto, Date: "2020-01-01T00:00:00+01:00", Values: []domain.HourlyPriceDto{{Datetime: "2020-01-01T00:00:00+01:00", Value: 0.123}}})
+		require.NoError(t, err)
+		zonesPrices = append(zonesPrices, prices)
+	}
+
+	t.Run("missing zones are requested from the next providers", func(t *testing.T) {
+		firstPricesProviderMock := new(mocks.PricesProvider)
+		secondPricesProviderMock := new(mocks.PricesProvider)
+		thirdPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(zones...)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+		mockError := errors.NewDomainError(errors.ProviderError, "mock-error")
+
+		firstPricesProviderMock.On("FetchPVPCPrices", ctx, zones, today).Return([]domain.Prices{zonesPrices[0]}, nil)
+		secondPricesProviderMock.On("FetchPVPCPrices", ctx, zones[1:], today).Return(nil, mockError)
+		thirdPricesProviderMock.On("FetchPVPCPrices", ctx, zones[1:], today).Return([]domain.Prices{zonesPrices[2], zonesPrices[1]}, nil)
+
+		pricesService := NewPricesService([]domain.PricesProvider{firstPricesProviderMock, secondPricesProviderMock, thirdPricesProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []domain.PricesID{zonesPrices[0].ID(), zonesPrices[1].ID(), zonesPrices[2].ID()}, res)
+
+		firstPricesProviderMock.AssertExpectations(t)
+		secondPricesProviderMock.AssertExpectations(t)
+		thirdPricesProviderMock.AssertExpectations(t)
+	})
+
+	t.Run("prices for zones that were not requested are discarded", func(t *testing.T) {
+		firstPricesProviderMock := new(mocks.PricesProvider)
+		secondPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(zones...)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+
+		firstPricesProviderMock.On("FetchPVPCPrices", ctx, zones, today).Return([]domain.Prices{zonesPrices[0], zonesPrices[1]}, nil)
+		secondPricesProviderMock.On("FetchPVPCPrices", ctx, zones[2:], today).Return([]domain.Prices{zonesPrices[0], zonesPrices[2]}, nil)
+
+		pricesService := NewPricesService([]domain.PricesProvider{firstPricesProviderMock, secondPricesProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []domain.PricesID{zonesPrices[0].ID(), zonesPrices[1].ID(), zonesPrices[2].ID()}, res)
+
+		firstPricesProviderMock.AssertExpectations(t)
+		secondPricesProviderMock.AssertExpectations(t)
+	})
+
+	t.Run("stores the fetched zones when no provider returns the rest", func(t *testing.T) {
+		firstPricesProviderMock := new(mocks.PricesProvider)
+		secondPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(zones...)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+
+		firstPricesProviderMock.On("FetchPVPCPrices", ctx, zones, today).Return([]domain.Prices{zonesPrices[1]}, nil)
+		secondPricesProviderMock.On("FetchPVPCPrices", ctx, []domain.Zone{zones[0], zones[2]}, today).Return([]domain.Prices{}, nil)
+
+		pricesService := NewPricesService([]domain.PricesProvider{firstPricesProviderMock, secondPricesProviderMock}, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesID{zonesPrices[1].ID()}, res)
+
+		firstPricesProviderMock.AssertExpectations(t)
+		secondPricesProviderMock.AssertExpectations(t)
+	})
+
+	t.Run("failed providers are logged by name or by position if they have none", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger.SetTestLogger(&logs)
+		defer logger.SetTestLogger(os.Stderr)
+		firstPricesProviderMock := namedPricesProviderMock{PricesProvider: new(mocks.PricesProvider), name: "esios"}
+		secondPricesProviderMock := new(mocks.PricesProvider)
+		zonesRepository := inmemory.NewZonesRepository(zones...)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		ctx := context.Background()
+		mockError := errors.NewDomainError(errors.ProviderError, "mock-error")
+
+		firstPricesProviderMock.On("FetchPVPCPrices", ctx, zones, today).Return(nil, mockError)
+		secondPricesProviderMock.On("FetchPVPCPrices", ctx, zones, today).Return(nil, mockError)
+
+		pricesService := NewPricesService([]domain.PricesProvider{firstPricesProviderMock, secondPricesProviderMock}, pricesRepository, zonesRepository)
+		_, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Contains(t, logs.String(), `msg="couldn't fetch prices from provider" provider=esios`)
+		require.Contains(t, logs.String(), `msg="couldn't fetch prices from provider" provider=#1`)
+	})
+
+	t.Run("nothing is fetched without providers", func(t *testing.T) {
+		zonesRepository := inmemory.NewZonesRepository(zones...)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+
+		pricesService := NewPricesService(nil, pricesRepository, zonesRepository)
+		res, err := pricesService.FetchAndStorePricesFromREE(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+}
+
+// newTestFakeREEPricesService returns a PricesService that fetches the prices of zones from the
+// Esios and REData APIs served at url, and stores them in memory.
+func newTestFakeREEPricesService(url string, zones ...domain.Zone) PricesService {
+	zonesRepository := inmemory.NewZonesRepository(zones...)
+	return NewPricesService(
+		[]domain.PricesProvider{esios.NewEsiosAPI(url, "token", nil), redataapi.NewREDataAPI(url, nil)},
+		inmemory.NewPricesRepository(zonesRepository),
+		zonesRepository,
+	)
+}
+
+func Test_PricesService_FetchAndStorePricesFromREE_FakeREE(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	fake, err := fakeree.NewServer("../../docs", "token")
+	require.NoError(t, err)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	zones := make([]domain.Zone, 0, 5)
+	for i, id := range []string{"PEN", "CAN", "BAL", "CEU", "MEL"} {
+		zone, err := domain.NewZone(domain.ZoneDto{ID: id, ExternalID: fmt.Sprintf("%d", 8741+i), Name: id})
+		require.NoError(t, err)
+		zones = append(zones, zone)
+	}
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	now = func() time.Time { return time.Date(2023, 9, 8, 21, 0, 0, 0, loc) }
+	defer restoreNow(time.Now)
+
+	t.Run("stores today and tomorrow prices from Esios", func(t *testing.T) {
+		fake.ClearFaults()
+		res, err := newTestFakeREEPricesService(server.URL, zones...).FetchAndStorePricesFromREE(context.Background())
+		require.NoError(t, err)
+		require.Len(t, res, 10)
+	})
+
+	t.Run("stores prices from REData when Esios fails", func(t *testing.T) {
+		fake.ClearFaults()
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{StatusCode: http.StatusBadGateway})
+		pricesService := newTestFakeREEPricesService(server.URL, zones...)
+
+		res, err := pricesService.FetchAndStorePricesFromREE(context.Background())
+		require.NoError(t, err)
+		require.Len(t, res, 10)
+
+		stored, err := pricesService.GetPrices(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, stored, 5)
+		for _, p := range stored {
+			require.Equal(t, "2023-09-09", p.Date().Format("2006-01-02"))
+			require.Len(t, p.Values(), 24)
+		}
+	})
+
+	t.Run("stores zones missing from Esios with prices from REData", func(t *testing.T) {
+		fake.ClearFaults()
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{MissingGeoIDs: []string{"8742", "8744"}})
+		pricesService := newTestFakeREEPricesService(server.URL, zones...)
+		redataRequests := fake.Requests(fakeree.REData)
+
+		res, err := pricesService.FetchAndStorePricesFromREE(context.Background())
+		require.NoError(t, err)
+		require.Len(t, res, 10)
+		require.Equal(t, redataRequests+4, fake.Requests(fakeree.REData))
+
+		stored, err := pricesService.GetPrices(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, stored, 5)
+		for _, p := range stored {
+			require.NotNil(t, p.Provenance())
+			switch p.Zone().ID().String() {
+			case "CAN", "CEU":
+				require.Equal(t, "redata", p.Provenance().Provider())
+			default:
+				require.Equal(t, "esios", p.Provenance().Provider())
+			}
+		}
+	})
+
+	t.Run("stores nothing when both APIs fail", func(t *testing.T) {
+		fake.ClearFaults()
+		fake.InjectFault(fakeree.Esios, fakeree.Fault{EmptyValues: true})
+		fake.InjectFault(fakeree.REData, fakeree.Fault{StatusCode: http.StatusInternalServerError})
+
+		res, err := newTestFakeREEPricesService(server.URL, zones...).FetchAndStorePricesFromREE(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, res)
+	})
+}
+
+func Test_PricesService_ClassifyPriceLevels(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	_, pricesRepository, _, zoneID := newTestForecastsService(t)
+	zone, err := domain.NewZone(testForecastsZone)
+	require.NoError(t, err)
+	pricesService := NewPricesService(nil, pricesRepository, inmemory.NewZonesRepository(zone))
+	day := time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC)
+	stored, err := pricesRepository.Query(context.Background(), &zoneID, &day)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+
+	t.Run("with the day baseline, terciles split the day in three groups of 8 hours", func(t *testing.T) {
+		levels, err := domain.NewPriceLevels(domain.PriceLevelsDto{})
+		require.NoError(t, err)
+
+		classified, err := pricesService.ClassifyPriceLevels(context.Background(), stored, levels)
+
+		require.NoError(t, err)
+		require.Len(t, classified, 1)
+		require.Equal(t, domain.CheapPriceLevel, classified[0][0])
+		require.Equal(t, domain.CheapPriceLevel, classified[0][7])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][8])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][15])
+		require.Equal(t, domain.ExpensivePriceLevel, classified[0][16])
+		require.Equal(t, domain.ExpensivePriceLevel, classified[0][23])
+	})
+
+	t.Run("with the 30d baseline, Saturday prices are expensive compared to the whole week", func(t *testing.T) {
+		// The prices are 50 €/MWh plus 10 per weekday number plus the hour, so Saturday is the most expensive day.
+		levels, err := domain.NewPriceLevels(domain.PriceLevelsDto{Baseline: "30d"})
+		require.NoError(t, err)
+
+		classified, err := pricesService.ClassifyPriceLevels(context.Background(), stored, levels)
+
+		require.NoError(t, err)
+		require.Len(t, classified, 1)
+		for _, level := range classified[0] {
+			require.Equal(t, domain.ExpensivePriceLevel, level)
+		}
+	})
+
+	t.Run("with the thresholds strategy, the bounds are fixed", func(t *testing.T) {
+		cheapBelow, expensiveAbove := 115.0, 120.0
+		levels, err := domain.NewPriceLevels(domain.PriceLevelsDto{Strategy: "thresholds", CheapBelow: &cheapBelow, ExpensiveAbove: &expensiveAbove})
+		require.NoError(t, err)
+
+		classified, err := pricesService.ClassifyPriceLevels(context.Background(), stored, levels)
+
+		require.NoError(t, err)
+		require.Equal(t, domain.CheapPriceLevel, classified[0][4])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][5])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][10])
+		require.Equal(t, domain.ExpensivePriceLevel, classified[0][11])
+	})
+
+	t.Run("with the deviation strategy, the bounds are a share around the mean", func(t *testing.T) {
+		// The mean is 121.5 €/MWh, so a 5% deviation sets the bounds at 115.425 and 127.575.
+		levels, err := domain.NewPriceLevels(domain.PriceLevelsDto{Strategy: "deviation", Deviation: 0.05})
+		require.NoError(t, err)
+
+		classified, err := pricesService.ClassifyPriceLevels(context.Background(), stored, levels)
+
+		require.NoError(t, err)
+		require.Equal(t, domain.CheapPriceLevel, classified[0][5])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][6])
+		require.Equal(t, domain.NormalPriceLevel, classified[0][17])
+		require.Equal(t, domain.ExpensivePriceLevel, classified[0][18])
+	})
+}
+
+func Test_PricesService_AggregatePrices(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	_, pricesRepository, _, zoneID := newTestForecastsService(t)
+	zone, err := domain.NewZone(testForecastsZone)
+	require.NoError(t, err)
+	pricesService := NewPricesService(nil, pricesRepository, inmemory.NewZonesRepository(zone))
+	// The week 52 weeks before the one of 2023-10-02 is the one of 2022-10-03, with prices 10 €/MWh lower.
+	for day := 3; day <= 9; day++ {
+		date := time.Date(2022, 10, day, 0, 0, 0, 0, time.UTC)
+		saveTestDayPrices(t, pricesRepository, date, func(hour int) float64 {
+			return 40 + 10*float64(date.Weekday()) + float64(hour)
+		})
+	}
+	from, to := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("aggregates the prices by period along with the same period of the previous year", func(t *testing.T) {
+		reports, err := pricesService.AggregatePrices(context.Background(), zoneID, domain.WeekGranularity, from, to)
+
+		require.NoError(t, err)
+		require.Len(t, reports, 2)
+		require.Equal(t, "2023-10-02", reports[0].Aggregate.Serialize().PeriodStart)
+		require.Equal(t, 7, reports[0].Aggregate.Serialize().Days)
+		require.Equal(t, 91.5, reports[0].Aggregate.Mean())
+		require.NotNil(t, reports[0].PreviousYear)
+		require.Equal(t, "2022-10-03", reports[0].PreviousYear.Serialize().PeriodStart)
+		require.Equal(t, 81.5, reports[0].PreviousYear.Mean())
+		require.Equal(t, "2023-10-09", reports[1].Aggregate.Serialize().PeriodStart)
+		require.Nil(t, reports[1].PreviousYear)
+	})
+
+	t.Run("peak hours are the ones of the zone's local time, whatever the offset of the prices", func(t *testing.T) {
+		canDto := domain.ZoneDto{ID: "CAN", ExternalID: "8742", Name: "Canarias", TimeZone: "Atlantic/Canary"}
+		can, err := domain.NewZone(canDto)
+		require.NoError(t, err)
+		zonesRepository := inmemory.NewZonesRepository(can)
+		canPricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		// Esios returns the Canarias prices with peninsula offsets, one hour ahead of the Canary local time.
+		dto := domain.PricesDto{ID: "CAN-2023-10-02", Date: "2023-10-02T00:00:00+01:00", Zone: canDto}
+		cest := time.FixedZone("CEST", 2*60*60)
+		for hour := 0; hour < 24; hour++ {
+			datetime := time.Date(2023, 10, 2, hour+1, 0, 0, 0, cest)
+			dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: float64(hour)})
+		}
+		prices, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+		require.NoError(t, canPricesRepository.Save(context.Background(), []domain.Prices{prices}))
+		day := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+
+		reports, err := NewPricesService(nil, canPricesRepository, zonesRepository).AggregatePrices(context.Background(), can.ID(), domain.WeekGranularity, day, day)
+
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		require.Equal(t, 13.5, *reports[0].Aggregate.Serialize().PeakMean)
+		require.Equal(t, 9.5, *reports[0].Aggregate.Serialize().OffpeakMean)
+	})
+
+	t.Run("when to is before from, it returns an invalid date range error", func(t *testing.T) {
+		_, err := pricesService.AggregatePrices(context.Background(), zoneID, domain.MonthGranularity, to, from)
+
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+
+	t.Run("for an unknown zone, it returns a zone not found error", func(t *testing.T) {
+		unknown, err := domain.NewZoneID("BAL")
+		require.NoError(t, err)
+
+		_, err = pricesService.AggregatePrices(context.Background(), unknown, domain.MonthGranularity, from, to)
+
+		require.Equal(t, errors.ZoneNotFound, errors.Code(err))
+	})
+}
+
+func Test_PricesService_ComparePrices(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	now = func() time.Time { return time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC) }
+	defer restoreNow(time.Now)
+	_, pricesRepository, _, zoneID := newTestForecastsService(t)
+	zone, err := domain.NewZone(testForecastsZone)
+	require.NoError(t, err)
+	pricesService := NewPricesService(nil, pricesRepository, inmemory.NewZonesRepository(zone))
+
+	t.Run("by default, it compares today with yesterday", func(t *testing.T) {
+		// Saturday prices are 10 €/MWh higher than Friday ones.
+		comparison, err := pricesService.ComparePrices(context.Background(), zoneID, nil, "")
+
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 10, 14, 0, 0, 0, 0, time.UTC), comparison.Date)
+		require.Equal(t, time.Date(2023, 10, 13, 0, 0, 0, 0, time.UTC), comparison.Against)
+		require.Equal(t, 121.5, comparison.Mean)
+		require.Equal(t, 111.5, comparison.AgainstMean)
+		require.Equal(t, 10.0, comparison.MeanChange)
+		require.Equal(t, 8.97, *comparison.MeanChangePct)
+		require.Len(t, comparison.Hours, 24)
+		require.Equal(t, "23:00", comparison.Hours[23].Hour)
+		require.Equal(t, 133.0, *comparison.Hours[23].Value)
+		require.Equal(t, 123.0, *comparison.Hours[23].AgainstValue)
+		require.Equal(t, 10.0, *comparison.Hours[23].Change)
+		require.Equal(t, 8.13, *comparison.Hours[23].ChangePct)
+		require.Len(t, comparison.Movers, 3)
+	})
+
+	t.Run("with last_week, it compares with the same day of the previous week", func(t *testing.T) {
+		date := time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC)
+
+		comparison, err := pricesService.ComparePrices(context.Background(), zoneID, &date, AgainstLastWeek)
+
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC), comparison.Against)
+		require.Equal(t, 0.0, comparison.MeanChange)
+	})
+
+	t.Run("the biggest movers are the hours whose prices changed the most", func(t *testing.T) {
+		date := time.Date(2023, 10, 20, 0, 0, 0, 0, time.UTC)
+		saveTestDayPrices(t, pricesRepository, date, func(hour int) float64 {
+			switch hour {
+			case 8:
+				return 0
+			case 20:
+				return 200
+			case 21:
+				return 150
+			}
+			return 100
+		})
+		against := "2023-10-13"
+
+		comparison, err := pricesService.ComparePrices(context.Background(), zoneID, &date, against)
+
+		require.NoError(t, err)
+		require.Len(t, comparison.Movers, 3)
+		require.Equal(t, "08:00", comparison.Movers[0].Hour)
+		require.Equal(t, -108.0, *comparison.Movers[0].Change)
+		require.Equal(t, -100.0, *comparison.Movers[0].ChangePct)
+		require.Equal(t, "20:00", comparison.Movers[1].Hour)
+		require.Equal(t, "21:00", comparison.Movers[2].Hour)
+	})
+
+	t.Run("on DST change days, hours are matched by their local time", func(t *testing.T) {
+		date, against := time.Date(2023, 10, 29, 0, 0, 0, 0, time.UTC), time.Date(2023, 10, 28, 0, 0, 0, 0, time.UTC)
+		saveTestDayPrices(t, pricesRepository, against, func(hour int) float64 { return 100 + float64(hour) })
+		dto := domain.PricesDto{ID: "PEN-2023-10-29", Date: "2023-10-29T00:00:00+02:00", Zone: testForecastsZone}
+		for hour := 0; hour < 25; hour++ {
+			datetime := time.Date(2023, 10, 28, 22, 0, 0, 0, time.UTC).Add(time.Duration(hour) * time.Hour)
+			dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime.Format(time.RFC3339), Value: 100})
+		}
+		prices, err := domain.NewPrices(dto)
+		require.NoError(t, err)
+		require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+		comparison, err := pricesService.ComparePrices(context.Background(), zoneID, &date, "")
+
+		require.NoError(t, err)
+		require.Len(t, comparison.Hours, 25)
+		require.Equal(t, "02:00", comparison.Hours[2].Hour)
+		require.Equal(t, -2.0, *comparison.Hours[2].Change)
+		require.Equal(t, "02:00", comparison.Hours[3].Hour)
+		require.NotNil(t, comparison.Hours[3].Value)
+		require.Nil(t, comparison.Hours[3].AgainstValue)
+		require.Nil(t, comparison.Hours[3].Change)
+		require.Equal(t, "03:00", comparison.Hours[4].Hour)
+		require.Equal(t, -3.0, *comparison.Hours[4].Change)
+	})
+
+	t.Run("without the prices of any of the days, it returns a prices not found error", func(t *testing.T) {
+		date := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := pricesService.ComparePrices(context.Background(), zoneID, &date, AgainstLastYear)
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+
+	t.Run("with an invalid day to compare with, it returns an invalid date range error", func(t *testing.T) {
+		_, err := pricesService.ComparePrices(context.Background(), zoneID, nil, "last_month")
+
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// anomalyHistoryDays is the number of days before the fetched prices day whose daily mean prices
+// the fetched ones are compared with to look for outliers.
+const anomalyHistoryDays = 30
+
+// WithQuarantine returns a copy of the service that checks the fetched prices for anomalies before
+// storing them. The ones that fail any check are held back in quarantineRepository, and an error is
+// logged, until they are manually approved or rejected with ReviewQuarantinedPrices.
+func (s PricesService) WithQuarantine(quarantineRepository domain.QuarantineRepository, thresholds domain.AnomalyThresholds) PricesService {
+	s.quarantineRepository = quarantineRepository
+	s.anomalyThresholds = thresholds
+	return s
+}
+
+// ListQuarantinedPrices returns the quarantined prices with the given status, or all of them if status is nil,
+// from the most recently detected ones.
+func (s PricesService) ListQuarantinedPrices(ctx context.Context, status *domain.QuarantineStatus) ([]domain.QuarantinedPrices, error) {
+	if s.quarantineRepository == nil {
+		return nil, errors.NewDomainError(errors.InternalError, "prices quarantine is not enabled")
+	}
+	return s.quarantineRepository.List(ctx, status)
+}
+
+// ReviewQuarantinedPrices approves or rejects, as given by status, the pending quarantined prices of pricesType
+// with the given ID. Approved prices are stored as if they had passed the checks, replacing the stored PVPC ones.
+// As only PVPC prices can be replaced, approving prices of other types already stored returns a PricesAlreadyStored
+// error and leaves them pending.
+func (s PricesService) ReviewQuarantinedPrices(ctx context.Context, pricesType domain.PricesType, id domain.PricesID, status domain.QuarantineStatus) (domain.QuarantinedPrices, error) {
+	if s.quarantineRepository == nil {
+		return domain.QuarantinedPrices{}, errors.NewDomainError(errors.InternalError, "prices quarantine is not enabled")
+	}
+
+	quarantined, err := s.quarantineRepository.Get(ctx, pricesType, id)
+	if err != nil {
+		return domain.QuarantinedPrices{}, err
+	}
+	reviewed, err := quarantined.Review(status, now())
+	if err != nil {
+		return domain.QuarantinedPrices{}, err
+	}
+
+	if status == domain.ApprovedQuarantine {
+		prices := []domain.Prices{reviewed.Prices()}
+		if pricesType == domain.PVPCPrices {
+			err = s.pricesRepository.Upsert(ctx, prices)
+		} else if err = s.checkPricesNotStored(ctx, reviewed.Prices()); err == nil {
+			err = s.pricesRepository.Save(ctx, prices)
+		}
+		if err != nil {
+			return domain.QuarantinedPrices{}, err
+		}
+	}
+
+	if err := s.quarantineRepository.Save(ctx, reviewed); err != nil {
+		return domain.QuarantinedPrices{}, err
+	}
+	logger.InfoContext(ctx, "Quarantined prices reviewed", "id", id.String(), "type", pricesType.String(), "status", status.String())
+
+	return reviewed, nil
+}
+
+// checkPricesNotStored returns a PricesAlreadyStored error if prices with the same type and ID are stored.
+func (s PricesService) checkPricesNotStored(ctx context.Context, prices domain.Prices) error {
+	zoneID, date := prices.Zone().ID(), prices.Date()
+	stored, err := s.pricesRepository.QueryByType(ctx, prices.Type(), &zoneID, &date)
+	if err != nil {
+		return err
+	}
+	for _, p := range stored {
+		if p.ID() == prices.ID() {
+			return errors.NewDomainError(errors.PricesAlreadyStored, "%s prices %s are already stored and can't be replaced", prices.Type().String(), prices.ID().String())
+		}
+	}
+	return nil
+}
+
+// screenPrices checks the fetched prices of pricesType for anomalies and returns the ones that passed
+// all the checks. The rest are quarantined, unless they are already quarantined with the same values.
+// Without a quarantine repository, all the prices are returned.
+func (s PricesService) screenPrices(ctx context.Context, pricesType domain.PricesType, fetched []domain.Prices) []domain.Prices {
+	if s.quarantineRepository == nil || len(fetched) == 0 {
+		return fetched
+	}
+
+	dailyMeans := make(map[string]map[domain.ZoneID]float64)
+	clean := make([]domain.Prices, 0, len(fetched))
+	for _, prices := range fetched {
+		history, err := s.anomalyHistory(ctx, pricesType, prices, dailyMeans)
+		if err != nil {
+			logger.WarnContext(ctx, "Error querying the prices history. Checking prices without it", "id", prices.ID().String(), "type", pricesType.String(), "err", err)
+		}
+
+		anomalies := domain.DetectPricesAnomalies(prices, history, s.anomalyThresholds)
+		if len(anomalies) == 0 {
+			clean = append(clean, prices)
+			continue
+		}
+
+		details := make([]string, len(anomalies))
+		for i, anomaly := range anomalies {
+			details[i] = anomaly.Kind().String() + ": " + anomaly.Detail()
+		}
+
+		existing, err := s.quarantineRepository.Get(ctx, pricesType, prices.ID())
+		if err == nil && existing.Status() != domain.ApprovedQuarantine &&
+			reflect.DeepEqual(existing.Prices().Serialize().Values, prices.Serialize().Values) {
+			logger.WarnContext(ctx, "Fetched prices are still quarantined", "id", prices.ID().String(), "type", pricesType.String(), "status", existing.Status().String())
+			continue
+		}
+
+		if err := s.quarantineRepository.Save(ctx, domain.QuarantinePrices(prices, anomalies, now())); err != nil {
+			logger.ErrorContext(ctx, "Error quarantining fetched prices. They won't be stored", "id", prices.ID().String(), "type", pricesType.String(), "anomalies", details, "err", err)
+			continue
+		}
+		logger.ErrorContext(ctx, "Fetched prices quarantined for manual approval", "id", prices.ID().String(), "type", pricesType.String(), "anomalies", details)
+	}
+
+	return clean
+}
+
+// anomalyHistory returns the daily mean prices of pricesType of the zone of prices for the anomalyHistoryDays
+// days before its day, caching the stored prices of every zone by day in dailyMeans.
+func (s PricesService) anomalyHistory(ctx context.Context, pricesType domain.PricesType, prices domain.Prices, dailyMeans map[string]map[domain.ZoneID]float64) ([]float64, error) {
+	history := make([]float64, 0, anomalyHistoryDays)
+	for i := 1; i <= anomalyHistoryDays; i++ {
+		day := prices.Date().AddDate(0, 0, -i)
+		key := day.Format(time.DateOnly)
+		means, ok := dailyMeans[key]
+		if !ok {
+			dayPrices, err := s.queryPrices(ctx, pricesType, nil, &day)
+			if err != nil {
+				return nil, err
+			}
+			means = make(map[domain.ZoneID]float64, len(dayPrices))
+			for _, p := range dayPrices {
+				if len(p.Values()) > 0 {
+					means[p.Zone().ID()] = meanPrice(p)
+				}
+			}
+			dailyMeans[key] = means
+		}
+		if mean, ok := means[prices.Zone().ID()]; ok {
+			history = append(history, mean)
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_PricesService_FetchAndStorePricesFromREE_Quarantine(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	today := time.Date(2020, 1, 1, 0, 0, 0, 0, loc)
+	detectedAt := time.Date(2020, 1, 1, 10, 0, 0, 0, loc)
+	now = func() time.Time { return detectedAt }
+	defer restoreNow(time.Now)
+
+	zoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	otherZoneDto := domain.ZoneDto{ID: "ZOT", ExternalID: "456", Name: "Zone 2"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	otherZone, err := domain.NewZone(otherZoneDto)
+	require.NoError(t, err)
+	zones := []domain.Zone{zone, otherZone}
+
+	// 10 days of history of both zones, around 100 €/MWh
+	var history []domain.Prices
+	for i := 1; i <= 10; i++ {
+		date := today.AddDate(0, 0, -i)
+		values := testDayValues(100+float64(i%3)*5, 0.1)
+		history = append(history, newTestPrices(t, domain.PVPCPrices, zoneDto, date, values...), newTestPrices(t, domain.PVPCPrices, otherZoneDto, date, values...))
+	}
+	otherPrices := newTestPrices(t, domain.PVPCPrices, otherZoneDto, today, testDayValues(110, 0.1)...)
+
+	t.Run("anomalous prices are quarantined and the rest are stored", func(t *testing.T) {
+		providerMock := new(mocks.PricesProvider)
+		ctx := context.Background()
+		pricesService, _, quarantineRepository := newTestQuarantineService(t, zones, providerMock, history)
+		anomalous := newTestPrices(t, domain.PVPCPrices, zoneDto, today, testDayValues(100000, 0.1)...)
+		providerMock.On("FetchPVPCPrices", ctx, zones, mock.Anything).
+			Return([]domain.Prices{anomalous, otherPrices}, nil).Once()
+
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ZOT-2020-01-01"}, pricesIDs(res))
+
+		quarantined, err := quarantineRepository.Get(ctx, domain.PVPCPrices, anomalous.ID())
+		require.NoError(t, err)
+		dto := quarantined.Serialize()
+		require.Equal(t, "pending", dto.Status)
+		require.Equal(t, "2020-01-01T09:00:00Z", dto.DetectedAt)
+		require.Equal(t, []domain.PricesAnomalyDto{
+			{Kind: "out_of_bounds", Detail: "24 of 24 hourly prices are out of [-500, 5000]"},
+			{Kind: "outlier", Detail: "the mean price 100001.15 is 25792.8 standard deviations from the mean of the last 10 days (106.15)"},
+		}, dto.Anomalies)
+
+		date := today
+		stored, err := pricesService.GetPrices(ctx, nil, &date)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, "ZOT-2020-01-01", stored[0].ID().String())
+		providerMock.AssertExpectations(t)
+	})
+
+	t.Run("prices fetched again with the same values are kept in quarantine as they were", func(t *testing.T) {
+		providerMock := new(mocks.PricesProvider)
+		ctx := context.Background()
+		pricesService, _, quarantineRepository := newTestQuarantineService(t, zones, providerMock, history)
+		flatline := newTestPrices(t, domain.PVPCPrices, zoneDto, today, make([]float64, 24)...)
+		providerMock.On("FetchPVPCPrices", ctx, zones, mock.Anything).
+			Return([]domain.Prices{flatline, otherPrices}, nil).Once()
+		providerMock.On("FetchPVPCPrices", ctx, []domain.Zone{zone}, mock.Anything).
+			Return([]domain.Prices{flatline}, nil).Once()
+
+		_, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		now = func() time.Time { return detectedAt.Add(time.Hour) }
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Nil(t, res)
+
+		quarantined, err := quarantineRepository.Get(ctx, domain.PVPCPrices, flatline.ID())
+		require.NoError(t, err)
+		require.Equal(t, detectedAt, quarantined.DetectedAt().In(loc))
+		require.Equal(t, domain.FlatlineAnomaly, quarantined.Anomalies()[0].Kind())
+		providerMock.AssertExpectations(t)
+	})
+
+	t.Run("prices in €/kWh are quarantined", func(t *testing.T) {
+		providerMock := new(mocks.PricesProvider)
+		ctx := context.Background()
+		pricesService, _, quarantineRepository := newTestQuarantineService(t, zones, providerMock, history)
+		kwh := newTestPrices(t, domain.PVPCPrices, zoneDto, today, testDayValues(0.11, 0.0001)...)
+		providerMock.On("FetchPVPCPrices", ctx, zones, mock.Anything).
+			Return([]domain.Prices{kwh, otherPrices}, nil).Once()
+
+		res, err := pricesService.FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ZOT-2020-01-01"}, pricesIDs(res))
+
+		quarantined, err := quarantineRepository.Get(ctx, domain.PVPCPrices, kwh.ID())
+		require.NoError(t, err)
+		require.Contains(t, quarantined.Serialize().Anomalies,
+			domain.PricesAnomalyDto{Kind: "units", Detail: "all the 24 hourly prices are within (-1, 1)"})
+		providerMock.AssertExpectations(t)
+	})
+
+	t.Run("without quarantine the fetched prices are stored without checks", func(t *testing.T) {
+		providerMock := new(mocks.PricesProvider)
+		ctx := context.Background()
+		zonesRepository := inmemory.NewZonesRepository(zone)
+		pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+		providerMock.On("FetchPVPCPrices", ctx, []domain.Zone{zone}, mock.Anything).
+			Return([]domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, today, testDayValues(100000, 0.1)...)}, nil).Once()
+
+		res, err := NewPricesService([]domain.PricesProvider{providerMock}, pricesRepository, zonesRepository).FetchAndStorePricesFromREE(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ZON-2020-01-01"}, pricesIDs(res))
+	})
+}
+
+func Test_PricesService_ReviewQuarantinedPrices(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, loc)
+	reviewedAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return reviewedAt }
+	defer restoreNow(time.Now)
+
+	zoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	zones := []domain.Zone{zone}
+	id, err := domain.NewPricesID("ZON-2020-01-01")
+	require.NoError(t, err)
+
+	for _, pricesType := range []domain.PricesType{domain.PVPCPrices, domain.SurplusPrices} {
+		t.Run(fmt.Sprintf("approved %s prices are stored", pricesType), func(t *testing.T) {
+			ctx := context.Background()
+			quarantined := newTestQuarantinedPrices(newTestPrices(t, pricesType, zoneDto, day, 0, 0), reviewedAt.Add(-time.Hour))
+			pricesService, pricesRepository, _ := newTestQuarantineService(t, zones, nil, nil, quarantined)
+
+			reviewed, err := pricesService.ReviewQuarantinedPrices(ctx, pricesType, id, domain.ApprovedQuarantine)
+			require.NoError(t, err)
+			require.Equal(t, domain.ApprovedQuarantine, reviewed.Status())
+			require.Equal(t, reviewedAt, *reviewed.ReviewedAt())
+
+			stored, err := pricesRepository.QueryByType(ctx, pricesType, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, stored, 1)
+			require.Equal(t, reviewed.Prices().Serialize(), stored[0].Serialize())
+
+			listed, err := pricesService.ListQuarantinedPrices(ctx, nil)
+			require.NoError(t, err)
+			require.Len(t, listed, 1)
+			require.Equal(t, domain.ApprovedQuarantine, listed[0].Status())
+		})
+	}
+
+	t.Run("approving non PVPC prices already stored fails and keeps them pending", func(t *testing.T) {
+		ctx := context.Background()
+		prices := newTestPrices(t, domain.SurplusPrices, zoneDto, day, 0, 0)
+		quarantined := newTestQuarantinedPrices(prices, reviewedAt.Add(-time.Hour))
+		pricesService, _, _ := newTestQuarantineService(t, zones, nil, []domain.Prices{prices}, quarantined)
+
+		_, err := pricesService.ReviewQuarantinedPrices(ctx, domain.SurplusPrices, id, domain.ApprovedQuarantine)
+		require.Equal(t, errors.PricesAlreadyStored, errors.Code(err))
+
+		listed, err := pricesService.ListQuarantinedPrices(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		require.Equal(t, domain.PendingQuarantine, listed[0].Status())
+	})
+
+	t.Run("rejected prices are not stored and can't be reviewed again", func(t *testing.T) {
+		ctx := context.Background()
+		quarantined := newTestQuarantinedPrices(newTestPrices(t, domain.PVPCPrices, zoneDto, day, 0, 0), reviewedAt.Add(-time.Hour))
+		pricesService, pricesRepository, _ := newTestQuarantineService(t, zones, nil, nil, quarantined)
+
+		reviewed, err := pricesService.ReviewQuarantinedPrices(ctx, domain.PVPCPrices, id, domain.RejectedQuarantine)
+		require.NoError(t, err)
+		require.Equal(t, domain.RejectedQuarantine, reviewed.Status())
+
+		stored, err := pricesRepository.Query(ctx, nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+
+		_, err = pricesService.ReviewQuarantinedPrices(ctx, domain.PVPCPrices, id, domain.ApprovedQuarantine)
+		require.Equal(t, errors.InvalidQuarantine, errors.Code(err))
+	})
+
+	t.Run("reviewing prices never quarantined fails", func(t *testing.T) {
+		pricesService, _, _ := newTestQuarantineService(t, zones, nil, nil)
+
+		_, err := pricesService.ReviewQuarantinedPrices(context.Background(), domain.SurplusPrices, id, domain.ApprovedQuarantine)
+		require.Equal(t, errors.QuarantineNotFound, errors.Code(err))
+	})
+}
+
+// newTestQuarantineService returns a service of zones that quarantines the anomalous prices fetched
+// from provider, if any, with history and quarantined already stored.
+func newTestQuarantineService(t *testing.T, zones []domain.Zone, provider domain.PricesProvider, history []domain.Prices, quarantined ...domain.QuarantinedPrices) (PricesService, *inmemory.PricesRepository, *inmemory.QuarantineRepository) {
+	t.Helper()
+	zonesRepository := inmemory.NewZonesRepository(zones...)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	quarantineRepository := inmemory.NewQuarantineRepository(zonesRepository)
+	if len(history) > 0 {
+		require.NoError(t, pricesRepository.Save(context.Background(), history))
+	}
+	for _, q := range quarantined {
+		require.NoError(t, quarantineRepository.Save(context.Background(), q))
+	}
+	var providers []domain.PricesProvider
+	if provider != nil {
+		providers = append(providers, provider)
+	}
+	pricesService := NewPricesService(providers, pricesRepository, zonesRepository).
+		WithQuarantine(quarantineRepository, domain.DefaultAnomalyThresholds)
+	return pricesService, pricesRepository, quarantineRepository
+}
+
+// newTestQuarantinedPrices returns prices quarantined at detectedAt with the anomalies found in them.
+func newTestQuarantinedPrices(prices domain.Prices, detectedAt time.Time) domain.QuarantinedPrices {
+	anomalies := domain.DetectPricesAnomalies(prices, nil, domain.DefaultAnomalyThresholds)
+	return domain.QuarantinePrices(prices, anomalies, detectedAt)
+}
+
+func pricesIDs(ids []domain.PricesID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/pkg/logger"
+)
+
+// StoredSource is the source name given in reconciliation reports to the prices already stored.
+const StoredSource = "stored"
+
+// ReconciliationService is the domain service that compares the stored prices with the
+// ones published by every provider, and repairs the stored ones when asked to.
+type ReconciliationService struct {
+	pricesProviders  map[string]domain.PricesProvider
+	pricesRepository domain.PricesRepository
+	zonesRepository  domain.ZonesRepository
+}
+
+// ReconciliationOptions are the parameters of a reconciliation run.
+type ReconciliationOptions struct {
+	// From and To are the first and last days (both included) to reconcile.
+	From time.Time
+	To   time.Time
+	// Tolerance is the maximum absolute difference allowed between two sources for the same hour.
+	Tolerance float64
+	// PreferredProvider is the provider whose prices are trusted when sources disagree.
+	PreferredProvider string
+	// Repair makes the stored prices that disagree with the PreferredProvider ones to be replaced by them.
+	Repair bool
+}
+
+// ReconciliationReport is the result of a reconciliation run.
+type ReconciliationReport struct {
+	From          time.Time
+	To            time.Time
+	Sources       []string
+	ComparedHours int
+	Discrepancies []PriceDiscrepancy
+	Repaired      []domain.PricesID
+}
+
+// PriceDiscrepancy is an hour of a zone whose price differs between sources more than the
+// tolerance, or that is missing from some of them.
+type PriceDiscrepancy struct {
+	ZoneID   domain.ZoneID
+	Datetime time.Time
+	// Values holds the price of the hour by source name. Sources without a price for the hour are absent.
+	Values map[string]float64
+}
+
+// sourcePrices indexes the prices of a source by zone and then by hour (unix timestamp).
+type sourcePrices map[domain.ZoneID]map[int64]float64
+
+// NewReconciliationService returns a new ReconciliationService comparing the given providers by name.
+func NewReconciliationService(
+	pricesProviders map[string]domain.PricesProvider,
+	pricesRepository domain.PricesRepository,
+	zonesRepository domain.ZonesRepository,
+) ReconciliationService {
+	return ReconciliationService{
+		pricesProviders:  pricesProviders,
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
+	}
+}
+
+// Reconcile compares, day by day and hour by hour, the stored prices of every zone with the ones
+// fetched from each provider, and reports the discrepancies found.
+//
+// Providers that fail to return prices for a day are left out of that day's comparison.
+func (s ReconciliationService) Reconcile(ctx context.Context, opts ReconciliationOptions) (ReconciliationReport, error) {
+	if opts.From.After(opts.To) {
+		return ReconciliationReport{}, errors.NewDomainError(errors.InvalidDateRange, "invalid date range: %s is after %s", opts.From.Format(time.DateOnly), opts.To.Format(time.DateOnly))
+	}
+	if _, ok := s.pricesProviders[opts.PreferredProvider]; opts.Repair && !ok {
+		return ReconciliationReport{}, errors.NewDomainError(errors.ProviderNotFound, "unknown preferred provider: %s", opts.PreferredProvider)
+	}
+
+	zones, err := s.zonesRepository.GetAll(ctx)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	providerNames := make([]string, 0, len(s.pricesProviders))
+	for name := range s.pricesProviders {
+		providerNames = append(providerNames, name)
+	}
+	sort.Strings(providerNames)
+
+	report := ReconciliationReport{
+		From:    opts.From,
+		To:      opts.To,
+		Sources: append([]string{StoredSource}, providerNames...),
+	}
+
+	// Days are the ones of the REE publication calendar, shared by every zone.
+	loc, err := domain.NewZoneLocation(domain.DefaultZoneTimeZone)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+	var toRepair []domain.Prices
+	last := startOfDay(opts.To, loc)
+	for day := startOfDay(opts.From, loc); !day.After(last); day = day.AddDate(0, 0, 1) {
+		stored, err := s.pricesRepository.Query(ctx, nil, &day)
+		if err != nil {
+			return ReconciliationReport{}, err
+		}
+
+		sources := map[string]sourcePrices{StoredSource: indexPrices(stored)}
+		var preferred []domain.Prices
+		for _, name := range providerNames {
+			fetched, err := s.pricesProviders[name].FetchPVPCPrices(ctx, zones, day)
+			if err != nil {
+				logger.WarnContext(ctx, "couldn't fetch prices to reconcile", "provider", name, "date", day.Format(time.DateOnly), "err", err)
+				continue
+			}
+			sources[name] = indexPrices(fetched)
+			if name == opts.PreferredProvider {
+				preferred = fetched
+			}
+		}
+
+		for _, zone := range zones {
+			compared, discrepancies := compareZone(zone.ID(), sources, opts.Tolerance, zone.Location())
+			report.ComparedHours += compared
+			report.Discrepancies = append(report.Discrepancies, discrepancies...)
+
+			if !opts.Repair || !needsRepair(discrepancies, opts.PreferredProvider, opts.Tolerance) {
+				continue
+			}
+			if prices, ok := findZonePrices(preferred, zone.ID()); ok {
+				toRepair = append(toRepair, prices)
+			}
+		}
+	}
+
+	if len(toRepair) > 0 {
+		if err := s.pricesRepository.Upsert(ctx, toRepair); err != nil {
+			return report, err
+		}
+		for _, prices := range toRepair {
+			report.Repaired = append(report.Repaired, prices.ID())
+		}
+		logger.InfoContext(ctx, "repaired stored prices", "provider", opts.PreferredProvider, "prices", len(toRepair))
+	}
+
+	return report, nil
+}
+
+func indexPrices(prices []domain.Prices) sourcePrices {
+	index := make(sourcePrices, len(prices))
+	for _, p := range prices {
+		hours := make(map[int64]float64, len(p.Values()))
+		for _, v := range p.Values() {
+			hours[v.Datetime().Unix()] = v.Value()
+		}
+		index[p.Zone().ID()] = hours
+	}
+	return index
+}
+
+// compareZone compares hour by hour the prices of a zone from every source. It returns the number
+// of hours compared and the discrepancies found, sorted by hour and with their datetimes in loc.
+func compareZone(zoneID domain.ZoneID, sources map[string]sourcePrices, tolerance float64, loc *time.Location) (int, []PriceDiscrepancy) {
+	hours := make(map[int64]struct{})
+	for _, source := range sources {
+		for hour := range source[zoneID] {
+			hours[hour] = struct{}{}
+		}
+	}
+
+	sortedHours := make([]int64, 0, len(hours))
+	for hour := range hours {
+		sortedHours = append(sortedHours, hour)
+	}
+	sort.Slice(sortedHours, func(i, j int) bool { return sortedHours[i] < sortedHours[j] })
+
+	var discrepancies []PriceDiscrepancy
+	for _, hour := range sortedHours {
+		values := make(map[string]float64, len(sources))
+		min, max := math.Inf(1), math.Inf(-1)
+		for name, source := range sources {
+			if value, ok := source[zoneID][hour]; ok {
+				values[name] = value
+				min, max = math.Min(min, value), math.Max(max, value)
+			}
+		}
+		if len(values) < len(sources) || max-min > tolerance {
+			discrepancies = append(discrepancies, PriceDiscrepancy{
+				ZoneID:   zoneID,
+				Datetime: time.Unix(hour, 0).In(loc),
+				Values:   values,
+			})
+		}
+	}
+
+	return len(sortedHours), discrepancies
+}
+
+// needsRepair reports whether any of the discrepancies shows the stored prices
+// missing or differing from the preferred provider ones.
+func needsRepair(discrepancies []PriceDiscrepancy, preferredProvider string, tolerance float64) bool {
+	for _, d := range discrepancies {
+		preferred, ok := d.Values[preferredProvider]
+		if !ok {
+			continue
+		}
+		stored, ok := d.Values[StoredSource]
+		if !ok || math.Abs(stored-preferred) > tolerance {
+			return true
+		}
+	}
+	return false
+}
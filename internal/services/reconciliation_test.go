@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+// newTestReconciliationService returns a ReconciliationService comparing the stored prices of zone
+// with the ones of the esios and redata providers.
+func newTestReconciliationService(t *testing.T, zone domain.Zone, stored []domain.Prices, esios, redata *mocks.PricesProvider) (ReconciliationService, *inmemory.PricesRepository) {
+	t.Helper()
+	zonesRepository := inmemory.NewZonesRepository(zone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	require.NoError(t, pricesRepository.Save(context.Background(), stored))
+	providers := map[string]domain.PricesProvider{"esios": esios, "redata": redata}
+	return NewReconciliationService(providers, pricesRepository, zonesRepository), pricesRepository
+}
+
+func Test_ReconciliationService_Reconcile(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	day := time.Date(2023, 9, 8, 0, 0, 0, 0, loc)
+	zoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+
+	t.Run("reports no discrepancies when all sources agree within the tolerance", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110), "esios")}, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100.005, 110), "redata")}, nil)
+		service, _ := newTestReconciliationService(t, zone, []domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110)}, esios, redata)
+
+		report, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day, Tolerance: 0.01})
+		require.NoError(t, err)
+		require.Equal(t, []string{StoredSource, "esios", "redata"}, report.Sources)
+		require.Equal(t, 2, report.ComparedHours)
+		require.Empty(t, report.Discrepancies)
+		require.Empty(t, report.Repaired)
+	})
+
+	t.Run("reports hours that differ or are missing from some source", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110, 120), "esios")}, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 150), "redata")}, nil)
+		service, _ := newTestReconciliationService(t, zone, []domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110, 120)}, esios, redata)
+
+		report, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day, Tolerance: 0.01})
+		require.NoError(t, err)
+		require.Equal(t, 3, report.ComparedHours)
+		require.Equal(t, []PriceDiscrepancy{
+			{ZoneID: zone.ID(), Datetime: day.Add(time.Hour), Values: map[string]float64{StoredSource: 110, "esios": 110, "redata": 150}},
+			{ZoneID: zone.ID(), Datetime: day.Add(2 * time.Hour), Values: map[string]float64{StoredSource: 120, "esios": 120}},
+		}, report.Discrepancies)
+	})
+
+	t.Run("leaves failing providers out of the comparison", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100), "esios")}, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return(nil, errors.NewDomainError(errors.ProviderError, "mock-error"))
+		service, _ := newTestReconciliationService(t, zone, []domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100)}, esios, redata)
+
+		report, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day})
+		require.NoError(t, err)
+		require.Equal(t, 1, report.ComparedHours)
+		require.Empty(t, report.Discrepancies)
+	})
+
+	t.Run("repairs the stored prices from the preferred provider", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esiosPrices := withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110), "esios")
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{esiosPrices}, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110), "redata")}, nil)
+		service, pricesRepository := newTestReconciliationService(t, zone, []domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 999)}, esios, redata)
+
+		report, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day, PreferredProvider: "esios", Repair: true})
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		require.Equal(t, []domain.PricesID{esiosPrices.ID()}, report.Repaired)
+
+		stored, err := pricesRepository.Query(context.Background(), nil, &day)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, 110.0, stored[0].Values()[1].Value())
+		require.Equal(t, "esios", stored[0].Provenance().Provider())
+	})
+
+	t.Run("does not repair when the stored prices agree with the preferred provider", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110), "esios")}, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day).Return([]domain.Prices{withTestProvenance(t, newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 120), "redata")}, nil)
+		service, _ := newTestReconciliationService(t, zone, []domain.Prices{newTestPrices(t, domain.PVPCPrices, zoneDto, day, 100, 110)}, esios, redata)
+
+		report, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day, PreferredProvider: "esios", Repair: true})
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		require.Empty(t, report.Repaired)
+	})
+
+	t.Run("walks every day of the range", func(t *testing.T) {
+		esios, redata := new(mocks.PricesProvider), new(mocks.PricesProvider)
+		esios.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, mock.Anything).Return(nil, nil)
+		redata.On("FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, mock.Anything).Return(nil, nil)
+		service, _ := newTestReconciliationService(t, zone, nil, esios, redata)
+
+		from, err := time.Parse(time.DateOnly, "2023-09-08")
+		require.NoError(t, err)
+		_, err = service.Reconcile(context.Background(), ReconciliationOptions{From: from, To: from.AddDate(0, 0, 2)})
+		require.NoError(t, err)
+
+		esios.AssertNumberOfCalls(t, "FetchPVPCPrices", 3)
+		esios.AssertCalled(t, "FetchPVPCPrices", mock.Anything, []domain.Zone{zone}, day.AddDate(0, 0, 2))
+	})
+
+	t.Run("fails with an invalid date range", func(t *testing.T) {
+		service, _ := newTestReconciliationService(t, zone, nil, new(mocks.PricesProvider), new(mocks.PricesProvider))
+
+		_, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day.AddDate(0, 0, -1)})
+		require.Error(t, err)
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+
+	t.Run("fails repairing from an unknown provider", func(t *testing.T) {
+		service, _ := newTestReconciliationService(t, zone, nil, new(mocks.PricesProvider), new(mocks.PricesProvider))
+
+		_, err := service.Reconcile(context.Background(), ReconciliationOptions{From: day, To: day, PreferredProvider: "unknown", Repair: true})
+		require.Error(t, err)
+		require.Equal(t, errors.ProviderNotFound, errors.Code(err))
+	})
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+const (
+	// maxSimulationDays is the longest period that can be simulated.
+	maxSimulationDays = 366
+	// batteryLevels is the number of states of charge, besides empty, a battery schedule is optimized over.
+	batteryLevels = 100
+	// batteryCyclePenalty is the cost (€/kWh) added to the charged energy while optimizing,
+	// so that of the schedules with the same cost the one cycling less energy is chosen.
+	batteryCyclePenalty = 1e-9
+)
+
+// SimulationsService is the domain service that simulates the behavior of home installations
+// with the stored historic PVPC prices.
+type SimulationsService struct {
+	pricesRepository domain.PricesRepository
+	zonesRepository  domain.ZonesRepository
+}
+
+// NewSimulationsService returns a new SimulationsService.
+func NewSimulationsService(pricesRepository domain.PricesRepository, zonesRepository domain.ZonesRepository) SimulationsService {
+	return SimulationsService{
+		pricesRepository: pricesRepository,
+		zonesRepository:  zonesRepository,
+	}
+}
+
+// BatterySimulation is the input of a battery arbitrage simulation: the household's zone, battery and
+// hourly consumption from From to To (YYYY-MM-DD days in the zone local time, both included).
+// Hours without consumption consume nothing.
+type BatterySimulation struct {
+	ZoneID      domain.ZoneID
+	From        time.Time
+	To          time.Time
+	Battery     domain.Battery
+	Consumption []domain.HourlyConsumption
+}
+
+// BatteryReport is the result of a battery arbitrage simulation: the optimal schedule of every day and
+// the cost of the consumption without and with the battery.
+type BatteryReport struct {
+	ZoneID        domain.ZoneID
+	From          time.Time
+	To            time.Time
+	BaselineCost  float64
+	BatteryCost   float64
+	Savings       float64
+	ChargedKwh    float64
+	DischargedKwh float64
+	Days          []BatteryDay
+}
+
+// BatteryDay is the optimal battery schedule of a day. The battery starts and ends every day empty.
+type BatteryDay struct {
+	Date         time.Time
+	BaselineCost float64
+	BatteryCost  float64
+	Savings      float64
+	Hours        []BatteryHour
+}
+
+// BatteryHour is what the battery does during an hour: the energy it charges from the grid, the energy
+// it discharges to cover the household's consumption, and its state of charge at the end of the hour.
+type BatteryHour struct {
+	Datetime       time.Time
+	Price          float64
+	ConsumptionKwh float64
+	ChargeKwh      float64
+	DischargeKwh   float64
+	StateOfCharge  float64
+}
+
+// DailyProfileConsumption returns the hourly consumption of repeating the daily profile kwh from from to to,
+// as CostsService.DailyProfileConsumption does.
+func (s SimulationsService) DailyProfileConsumption(ctx context.Context, zoneID domain.ZoneID, from, to time.Time, kwh []float64) ([]domain.HourlyConsumption, error) {
+	return dailyProfileConsumption(ctx, s.zonesRepository, zoneID, from, to, kwh)
+}
+
+// SimulateBattery returns the optimal daily schedule of the battery, charging at cheap hours and discharging
+// at expensive ones, and its savings. Batteries don't export energy: they only discharge to cover the
+// household's consumption. The round-trip efficiency losses are accounted when charging.
+// Every hour of the period must have its PVPC price stored, and the battery must be able to charge and
+// discharge, in an hour, at least the energy of one of the batteryLevels states of charge.
+func (s SimulationsService) SimulateBattery(ctx context.Context, simulation BatterySimulation) (BatteryReport, error) {
+	if simulation.To.Before(simulation.From) {
+		return BatteryReport{}, errors.NewDomainError(errors.InvalidDateRange, "simulation from %s is after to %s", simulation.From.Format(time.DateOnly), simulation.To.Format(time.DateOnly))
+	}
+	if days := simulation.To.Sub(simulation.From).Hours()/24 + 1; days > maxSimulationDays {
+		return BatteryReport{}, errors.NewDomainError(errors.InvalidDateRange, "simulation can't be longer than %d days", maxSimulationDays)
+	}
+	if err := validateSimulatedBattery(simulation.Battery); err != nil {
+		return BatteryReport{}, err
+	}
+	loc, err := zoneLocation(ctx, s.zonesRepository, simulation.ZoneID)
+	if err != nil {
+		return BatteryReport{}, err
+	}
+	start := time.Date(simulation.From.Year(), simulation.From.Month(), simulation.From.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(simulation.To.Year(), simulation.To.Month(), simulation.To.Day()+1, 0, 0, 0, 0, loc)
+
+	consumptionByHour := make(map[int64]float64, len(simulation.Consumption))
+	for _, c := range simulation.Consumption {
+		if c.Datetime().Before(start) || !c.Datetime().Before(end) {
+			return BatteryReport{}, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s is out of the simulation period", c.Datetime().Format(time.RFC3339))
+		}
+		if _, ok := consumptionByHour[c.Datetime().Unix()]; ok {
+			return BatteryReport{}, errors.NewDomainError(errors.InvalidConsumption, "consumption of %s is repeated", c.Datetime().Format(time.RFC3339))
+		}
+		consumptionByHour[c.Datetime().Unix()] = c.Kwh()
+	}
+
+	var hours []domain.HourlyConsumption
+	for hour := start; hour.Before(end); hour = hour.Add(time.Hour) {
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: hour.Format(time.RFC3339), Kwh: consumptionByHour[hour.Unix()]})
+		if err != nil {
+			return BatteryReport{}, err
+		}
+		hours = append(hours, c)
+	}
+	prices, err := consumptionPrices(ctx, s.pricesRepository, simulation.ZoneID, loc, hours)
+	if err != nil {
+		return BatteryReport{}, err
+	}
+
+	report := BatteryReport{ZoneID: simulation.ZoneID, From: simulation.From, To: simulation.To}
+	var baselineCost, batteryCost domain.Decimal // exact, so they're only rounded once
+	for dayStart := start; dayStart.Before(end); {
+		dayEnd := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day()+1, 0, 0, 0, 0, loc)
+		var dayHours []BatteryHour
+		var dayPrices []domain.EnergyPrice
+		for hour := dayStart; hour.Before(dayEnd); hour = hour.Add(time.Hour) {
+			price, ok := prices[hour.Unix()]
+			if !ok {
+				return BatteryReport{}, errors.NewDomainError(errors.PricesNotFound, "prices not found for zone %s at %s", simulation.ZoneID.String(), hour.Format(time.RFC3339))
+			}
+			dayHours = append(dayHours, BatteryHour{Datetime: hour, Price: price.In(domain.MWh).Amount().Float64(), ConsumptionKwh: consumptionByHour[hour.Unix()]})
+			dayPrices = append(dayPrices, price)
+		}
+
+		day := scheduleBatteryDay(simulation.Battery, dayHours)
+		day.Date = time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), 0, 0, 0, 0, time.UTC)
+		dayBaselineCost, dayBatteryCost := batteryDayCosts(day.Hours, dayPrices)
+		day.BaselineCost = dayBaselineCost.Round(2).Float64()
+		day.BatteryCost = dayBatteryCost.Round(2).Float64()
+		day.Savings = dayBaselineCost.Round(2).Sub(dayBatteryCost.Round(2)).Float64()
+		baselineCost = baselineCost.Add(dayBaselineCost)
+		batteryCost = batteryCost.Add(dayBatteryCost)
+		for _, hour := range day.Hours {
+			report.ChargedKwh += hour.ChargeKwh
+			report.DischargedKwh += hour.DischargeKwh
+		}
+		report.Days = append(report.Days, day)
+		dayStart = dayEnd
+	}
+
+	report.BaselineCost = baselineCost.Round(2).Float64()
+	report.BatteryCost = batteryCost.Round(2).Float64()
+	report.Savings = baselineCost.Round(2).Sub(batteryCost.Round(2)).Float64()
+	report.ChargedKwh = round(report.ChargedKwh, 3)
+	report.DischargedKwh = round(report.DischargedKwh, 3)
+
+	return report, nil
+}
+
+// validateSimulatedBattery returns an InvalidBattery error if the battery can't change its state of charge
+// by one level in an hour, as then scheduleBatteryDay couldn't ever charge it and would report no savings.
+func validateSimulatedBattery(battery domain.Battery) error {
+	step := battery.CapacityKwh() / batteryLevels
+	if step/battery.RoundTripEfficiency() > battery.ChargePowerKw()+planEnergyTolerance || step > battery.DischargePowerKw()+planEnergyTolerance {
+		return errors.NewDomainError(errors.InvalidBattery, "battery of %v kWh needs charge and discharge powers of at least %v kW and %v kW to be simulated: %v kW and %v kW",
+			battery.CapacityKwh(), round(step/battery.RoundTripEfficiency(), 3), round(step, 3), battery.ChargePowerKw(), battery.DischargePowerKw())
+	}
+	return nil
+}
+
+// scheduleBatteryDay returns the schedule of the battery that minimizes the cost of the day's hours,
+// optimizing over batteryLevels states of charge by dynamic programming. The day's costs are left
+// to batteryDayCosts.
+func scheduleBatteryDay(battery domain.Battery, hours []BatteryHour) BatteryDay {
+	step := battery.CapacityKwh() / batteryLevels
+	efficiency := battery.RoundTripEfficiency()
+
+	// costs[h][l] is the minimum cost, cycle penalty included, of the hours before h ending them at the level l, and
+	// previous[h][l] is the level at the start of the hour h-1 that achieves it.
+	costs := make([][]float64, len(hours)+1)
+	previous := make([][]int, len(hours)+1)
+	for h := range costs {
+		costs[h] = make([]float64, batteryLevels+1)
+		previous[h] = make([]int, batteryLevels+1)
+		for l := range costs[h] {
+			costs[h][l] = math.Inf(1)
+		}
+	}
+	costs[0][0] = 0
+
+	for h, hour := range hours {
+		for from := 0; from <= batteryLevels; from++ {
+			if math.IsInf(costs[h][from], 1) {
+				continue
+			}
+			for to := 0; to <= batteryLevels; to++ {
+				charge, discharge, ok := batteryTransition(battery, hour.ConsumptionKwh, float64(to-from)*step, efficiency)
+				if !ok {
+					continue
+				}
+				cost := costs[h][from] + (hour.ConsumptionKwh-discharge+charge)*hour.Price/1000 + charge*batteryCyclePenalty // prices are in €/MWh
+				if cost < costs[h+1][to] {
+					costs[h+1][to] = cost
+					previous[h+1][to] = from
+				}
+			}
+		}
+	}
+
+	day := BatteryDay{Hours: make([]BatteryHour, len(hours))}
+	level := 0
+	for h := len(hours); h > 0; h-- {
+		from := previous[h][level]
+		hour := hours[h-1]
+		charge, discharge, _ := batteryTransition(battery, hour.ConsumptionKwh, float64(level-from)*step, efficiency)
+		hour.ChargeKwh = round(charge, 3)
+		hour.DischargeKwh = round(discharge, 3)
+		hour.StateOfCharge = round(float64(level)*step, 3)
+		day.Hours[h-1] = hour
+		level = from
+	}
+
+	return day
+}
+
+// batteryDayCosts returns the exact cost of the consumption of the scheduled hours without and with the battery,
+// at the given price of every hour. The battery's energy is costed as reported, rounded to Wh.
+func batteryDayCosts(hours []BatteryHour, prices []domain.EnergyPrice) (domain.Decimal, domain.Decimal) {
+	var baseline, battery domain.Decimal
+	for i, hour := range hours {
+		consumption := domain.NewDecimalFromFloat(hour.ConsumptionKwh)
+		grid := consumption.Sub(domain.NewDecimalFromFloat(hour.DischargeKwh)).Add(domain.NewDecimalFromFloat(hour.ChargeKwh))
+		baseline = baseline.Add(prices[i].CostOf(consumption))
+		battery = battery.Add(prices[i].CostOf(grid))
+	}
+	return baseline, battery
+}
+
+// batteryTransition returns the energy charged from the grid or discharged to the household to change the
+// battery's stored energy by delta during an hour, and whether the battery and the consumption allow it.
+func batteryTransition(battery domain.Battery, consumption, delta, efficiency float64) (float64, float64, bool) {
+	switch {
+	case delta > 0:
+		charge := delta / efficiency
+		return charge, 0, charge <= battery.ChargePowerKw()+planEnergyTolerance
+	case delta < 0:
+		discharge := -delta
+		return 0, discharge, discharge <= battery.DischargePowerKw()+planEnergyTolerance && discharge <= consumption+planEnergyTolerance
+	default:
+		return 0, 0, true
+	}
+}
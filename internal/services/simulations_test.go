@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_SimulationsService_SimulateBattery(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	zoneDto := domain.ZoneDto{ID: "PEN", ExternalID: "8741", Name: "Península"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+	zonesRepository := inmemory.NewZonesRepository(zone)
+	pricesRepository := inmemory.NewPricesRepository(zonesRepository)
+	cest := time.FixedZone("CEST", 2*60*60)
+
+	// 2023-10-11 prices: 50 €/MWh from 00:00 to 06:00, 250 €/MWh from 18:00 and 100 €/MWh the rest of the day.
+	dto := domain.PricesDto{ID: "PEN-2023-10-11", Date: "2023-10-11T00:00:00+02:00", Zone: zoneDto}
+	var consumption []domain.HourlyConsumption
+	for hour := 0; hour < 24; hour++ {
+		value := 100.0
+		if hour < 6 {
+			value = 50
+		} else if hour >= 18 {
+			value = 250
+		}
+		datetime := time.Date(2023, 10, 11, hour, 0, 0, 0, cest).Format(time.RFC3339)
+		dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime, Value: value})
+		c, err := domain.NewHourlyConsumption(domain.HourlyConsumptionDto{Datetime: datetime, Kwh: 1})
+		require.NoError(t, err)
+		consumption = append(consumption, c)
+	}
+	prices, err := domain.NewPrices(dto)
+	require.NoError(t, err)
+	require.NoError(t, pricesRepository.Save(context.Background(), []domain.Prices{prices}))
+
+	service := NewSimulationsService(pricesRepository, zonesRepository)
+	day := time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC)
+	t.Run("charges at the cheap hours and discharges at the expensive ones", func(t *testing.T) {
+		report, err := service.SimulateBattery(context.Background(), BatterySimulation{
+			ZoneID: zone.ID(), From: day, To: day, Battery: newTestBattery(t, 1), Consumption: consumption,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 3.0, report.BaselineCost)
+		require.Equal(t, 1.6, report.BatteryCost)
+		require.Equal(t, 1.4, report.Savings)
+		require.Equal(t, 10.0, report.ChargedKwh)
+		require.Equal(t, 10.0, report.DischargedKwh)
+		require.Len(t, report.Days, 1)
+		require.Equal(t, day, report.Days[0].Date)
+		require.Len(t, report.Days[0].Hours, 24)
+		for _, hour := range report.Days[0].Hours[18:] {
+			require.Equal(t, 1.0, hour.DischargeKwh)
+		}
+		require.Equal(t, 0.0, report.Days[0].Hours[23].StateOfCharge)
+	})
+
+	t.Run("accounts the round-trip efficiency losses", func(t *testing.T) {
+		report, err := service.SimulateBattery(context.Background(), BatterySimulation{
+			ZoneID: zone.ID(), From: day, To: day, Battery: newTestBattery(t, 0.5), Consumption: consumption,
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, 0.9, report.Savings)
+	})
+
+	t.Run("when an hour has no price, it returns a prices not found error", func(t *testing.T) {
+		_, err := service.SimulateBattery(context.Background(), BatterySimulation{
+			ZoneID: zone.ID(), From: day, To: day.AddDate(0, 0, 1), Battery: newTestBattery(t, 1), Consumption: consumption,
+		})
+
+		require.Equal(t, errors.PricesNotFound, errors.Code(err))
+	})
+
+	t.Run("when consumption is out of the period, it returns an invalid consumption error", func(t *testing.T) {
+		_, err := service.SimulateBattery(context.Background(), BatterySimulation{
+			ZoneID: zone.ID(), From: day.AddDate(0, 0, 1), To: day.AddDate(0, 0, 1), Battery: newTestBattery(t, 1), Consumption: consumption,
+		})
+
+		require.Equal(t, errors.InvalidConsumption, errors.Code(err))
+	})
+
+	t.Run("when the battery can't charge one level in an hour, it returns an invalid battery error", func(t *testing.T) {
+		battery, err := domain.NewBattery(domain.BatteryDto{CapacityKwh: 10, ChargePowerKw: 0.05, DischargePowerKw: 5, RoundTripEfficiency: 1})
+		require.NoError(t, err)
+
+		_, err = service.SimulateBattery(context.Background(), BatterySimulation{
+			ZoneID: zone.ID(), From: day, To: day, Battery: battery, Consumption: consumption,
+		})
+
+		require.Equal(t, errors.InvalidBattery, errors.Code(err))
+	})
+
+	t.Run("when the period is inverted or too long, it returns an invalid date range error", func(t *testing.T) {
+		_, err := service.SimulateBattery(context.Background(), BatterySimulation{ZoneID: zone.ID(), From: day, To: day.AddDate(0, 0, -1), Battery: newTestBattery(t, 1)})
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+
+		_, err = service.SimulateBattery(context.Background(), BatterySimulation{ZoneID: zone.ID(), From: day, To: day.AddDate(1, 0, 1), Battery: newTestBattery(t, 1)})
+		require.Equal(t, errors.InvalidDateRange, errors.Code(err))
+	})
+}
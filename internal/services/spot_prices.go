@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/pkg/logger"
+)
+
+// spotPricesPublicationHour is the hour (zone local time) after which the next day's spot prices
+// are expected to be published. OMIE publishes the day-ahead market results around 13:00.
+const spotPricesPublicationHour = 14
+
+// SpotPricesService is the domain service that manages operations over the day-ahead market (spot) prices.
+type SpotPricesService struct {
+	spotPricesProvider   domain.SpotPricesProvider
+	spotPricesRepository domain.SpotPricesRepository
+	zonesRepository      domain.ZonesRepository
+}
+
+// NewSpotPricesService returns a new SpotPricesService.
+func NewSpotPricesService(
+	spotPricesProvider domain.SpotPricesProvider,
+	spotPricesRepository domain.SpotPricesRepository,
+	zonesRepository domain.ZonesRepository,
+) SpotPricesService {
+	return SpotPricesService{
+		spotPricesProvider:   spotPricesProvider,
+		spotPricesRepository: spotPricesRepository,
+		zonesRepository:      zonesRepository,
+	}
+}
+
+// FetchAndStoreSpotPrices fetches the spot prices missing from storage for today and,
+// once published, for tomorrow, and stores them.
+func (s SpotPricesService) FetchAndStoreSpotPrices(ctx context.Context) ([]domain.PricesID, error) {
+	allZones, err := s.zonesRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := s.spotPricesRepository.Query(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	latestByZoneID := make(map[domain.ZoneID]domain.Prices, len(latest))
+	for _, prices := range latest {
+		latestByZoneID[prices.Zone().ID()] = prices
+	}
+
+	var pricesToStore []domain.Prices
+	for _, fetch := range zonesFetches(allZones, latestByZoneID, now(), spotPricesPublicationHour) {
+		prices, err := s.spotPricesProvider.FetchSpotPrices(ctx, fetch.zones, fetch.day)
+		if err != nil {
+			logger.WarnContext(ctx, "couldn't fetch spot prices", "date", fetch.day.Format(time.DateOnly), "zones", zoneIDs(fetch.zones), "err", err)
+			continue
+		}
+		pricesToStore = append(pricesToStore, prices...)
+	}
+
+	if len(pricesToStore) == 0 {
+		return nil, nil
+	}
+
+	if err := s.spotPricesRepository.Save(ctx, pricesToStore); err != nil {
+		return nil, err
+	}
+
+	pricesIDs := make([]domain.PricesID, len(pricesToStore))
+	for i, prices := range pricesToStore {
+		pricesIDs[i] = prices.ID()
+	}
+	return pricesIDs, nil
+}
+
+// GetSpotPrices returns the spot prices for the given date and zoneID, with the same
+// semantics as PricesService.GetPrices.
+func (s SpotPricesService) GetSpotPrices(ctx context.Context, zoneID *domain.ZoneID, date *time.Time) ([]domain.Prices, error) {
+	return s.spotPricesRepository.Query(ctx, zoneID, date)
+}
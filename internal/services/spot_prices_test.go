@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_SpotPricesService_FetchAndStoreSpotPrices(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	defer restoreNow(now)
+	loc, err := time.LoadLocation("Europe/Madrid")
+	require.NoError(t, err)
+	today := time.Date(2023, 9, 8, 0, 0, 0, 0, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	zoneDto := domain.ZoneDto{ID: "ZON", ExternalID: "123", Name: "Zone 1"}
+	zone, err := domain.NewZone(zoneDto)
+	require.NoError(t, err)
+
+	t.Run("fetches only today's spot prices before they are published for tomorrow", func(t *testing.T) {
+		now = func() time.Time { return today.Add(10 * time.Hour) }
+		provider := new(mocks.SpotPricesProvider)
+		provider.On("FetchSpotPrices", mock.Anything, []domain.Zone{zone}, today).Return([]domain.Prices{newTestPrices(t, domain.SpotPrices, zoneDto, today, 100)}, nil)
+		zonesRepository := inmemory.NewZonesRepository(zone)
+		service := NewSpotPricesService(provider, inmemory.NewSpotPricesRepository(zonesRepository), zonesRepository)
+
+		ids, err := service.FetchAndStoreSpotPrices(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesID{newTestPrices(t, domain.SpotPrices, zoneDto, today, 100).ID()}, ids)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("fetches tomorrow's spot prices once published, skipping the stored ones", func(t *testing.T) {
+		now = func() time.Time { return today.Add(15 * time.Hour) }
+		provider := new(mocks.SpotPricesProvider)
+		provider.On("FetchSpotPrices", mock.Anything, []domain.Zone{zone}, tomorrow).Return([]domain.Prices{newTestPrices(t, domain.SpotPrices, zoneDto, tomorrow, 100)}, nil)
+		zonesRepository := inmemory.NewZonesRepository(zone)
+		repository := inmemory.NewSpotPricesRepository(zonesRepository)
+		service := NewSpotPricesService(provider, repository, zonesRepository)
+		require.NoError(t, repository.Save(context.Background(), []domain.Prices{newTestPrices(t, domain.SpotPrices, zoneDto, today, 100)}))
+
+		ids, err := service.FetchAndStoreSpotPrices(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.PricesID{newTestPrices(t, domain.SpotPrices, zoneDto, tomorrow, 100).ID()}, ids)
+		provider.AssertNumberOfCalls(t, "FetchSpotPrices", 1)
+	})
+
+	t.Run("stores nothing when the provider fails", func(t *testing.T) {
+		now = func() time.Time { return today.Add(15 * time.Hour) }
+		provider := new(mocks.SpotPricesProvider)
+		provider.On("FetchSpotPrices", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.NewDomainError(errors.ProviderError, "mock-error"))
+		zonesRepository := inmemory.NewZonesRepository(zone)
+		repository := inmemory.NewSpotPricesRepository(zonesRepository)
+		service := NewSpotPricesService(provider, repository, zonesRepository)
+
+		ids, err := service.FetchAndStoreSpotPrices(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, ids)
+		stored, err := repository.Query(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+	})
+
+	t.Run("fails with a repository error saving spot prices", func(t *testing.T) {
+		now = func() time.Time { return today.Add(10 * time.Hour) }
+		provider := new(mocks.SpotPricesProvider)
+		provider.On("FetchSpotPrices", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Prices{newTestPrices(t, domain.SpotPrices, zoneDto, today, 100)}, nil)
+		repository := new(mocks.SpotPricesRepository)
+		repository.On("Query", mock.Anything, (*domain.ZoneID)(nil), (*time.Time)(nil)).Return(nil, nil)
+		repository.On("Save", mock.Anything, mock.Anything).Return(errors.NewDomainError(errors.PersistenceError, "mock-error"))
+		service := NewSpotPricesService(provider, repository, inmemory.NewZonesRepository(zone))
+
+		_, err := service.FetchAndStoreSpotPrices(context.Background())
+		require.Error(t, err)
+		require.Equal(t, errors.PersistenceError, errors.Code(err))
+	})
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"pvpc-backend/internal/domain"
+	"pvpc-backend/internal/domain/errors"
+)
+
+// TariffPeriodsService is the domain service that classifies prices hours into 2.0TD tariff periods
+// and manages the holidays calendar they depend on.
+type TariffPeriodsService struct {
+	holidaysRepository domain.HolidaysRepository
+}
+
+// NewTariffPeriodsService returns a new TariffPeriodsService.
+func NewTariffPeriodsService(holidaysRepository domain.HolidaysRepository) TariffPeriodsService {
+	return TariffPeriodsService{
+		holidaysRepository: holidaysRepository,
+	}
+}
+
+// ClassifyPrices returns the tariff period of every hourly value of the given prices, in the same order.
+// Hours are classified in the local time of each prices' zone.
+func (s TariffPeriodsService) ClassifyPrices(ctx context.Context, prices []domain.Prices) ([][]domain.TariffPeriod, error) {
+	holidays, err := s.holidaysRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	calendar := domain.NewHolidayCalendar(holidays)
+
+	periods := make([][]domain.TariffPeriod, len(prices))
+	for i, p := range prices {
+		loc := p.Zone().Location()
+		periods[i] = make([]domain.TariffPeriod, len(p.Values()))
+		for j, value := range p.Values() {
+			periods[i][j] = domain.TariffPeriodOf(p.Zone().ID(), value.Datetime().In(loc), calendar)
+		}
+	}
+
+	return periods, nil
+}
+
+// ListHolidays returns the holidays calendar, sorted by date.
+func (s TariffPeriodsService) ListHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	return s.holidaysRepository.GetAll(ctx)
+}
+
+// SaveHolidays adds the given holidays to the calendar, replacing the ones of the same days.
+func (s TariffPeriodsService) SaveHolidays(ctx context.Context, holidays []domain.Holiday) error {
+	return s.holidaysRepository.Save(ctx, holidays)
+}
+
+// SeedHolidays adds the given holidays of the years the calendar has no holiday in yet, so the holidays
+// changed or deleted through SaveHolidays and DeleteHoliday are kept. It returns the number of added holidays.
+func (s TariffPeriodsService) SeedHolidays(ctx context.Context, holidays []domain.Holiday) (int, error) {
+	stored, err := s.holidaysRepository.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	storedYears := holidaysYears(stored)
+
+	var missing []domain.Holiday
+	for _, holiday := range holidays {
+		if !storedYears[holiday.Date().Year()] {
+			missing = append(missing, holiday)
+		}
+	}
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	if err := s.holidaysRepository.Save(ctx, missing); err != nil {
+		return 0, err
+	}
+	return len(missing), nil
+}
+
+// UncoveredYears returns the given years the calendar has no holiday in, whose national
+// holidays would be classified as working days.
+func (s TariffPeriodsService) UncoveredYears(ctx context.Context, years ...int) ([]int, error) {
+	holidays, err := s.holidaysRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	covered := holidaysYears(holidays)
+
+	var uncovered []int
+	for _, year := range years {
+		if !covered[year] {
+			uncovered = append(uncovered, year)
+		}
+	}
+	return uncovered, nil
+}
+
+func holidaysYears(holidays []domain.Holiday) map[int]bool {
+	years := make(map[int]bool)
+	for _, holiday := range holidays {
+		years[holiday.Date().Year()] = true
+	}
+	return years
+}
+
+// DeleteHoliday removes the holiday of the given day from the calendar.
+func (s TariffPeriodsService) DeleteHoliday(ctx context.Context, date time.Time) error {
+	return s.holidaysRepository.Delete(ctx, date)
+}
+
+// ReadHolidaysFile reads a holidays calendar from a JSON file shaped as [{"date": "YYYY-MM-DD", "name": "..."}].
+func ReadHolidaysFile(path string) ([]domain.Holiday, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.InternalError, "error reading holidays file")
+	}
+
+	var dtos []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &dtos); err != nil {
+		return nil, errors.WrapIntoDomainError(err, errors.InternalError, "error parsing holidays file")
+	}
+
+	holidays := make([]domain.Holiday, len(dtos))
+	for i, dto := range dtos {
+		holiday, err := domain.NewHoliday(domain.HolidayDto{Date: dto.Date, Name: dto.Name})
+		if err != nil {
+			return nil, err
+		}
+		holidays[i] = holiday
+	}
+
+	return holidays, nil
+}
+
+// zoneLocation returns the local time zone of the zone with the given ID, in which its days start and end.
+// It returns a ZoneNotFound error if the zone doesn't exist.
+func zoneLocation(ctx context.Context, zonesRepository domain.ZonesRepository, zoneID domain.ZoneID) (*time.Location, error) {
+	zone, err := zonesRepository.GetByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	return zone.Location(), nil
+}
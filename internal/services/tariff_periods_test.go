@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pvpc-backend/internal/domain"
+	dErrors "pvpc-backend/internal/domain/errors"
+	"pvpc-backend/internal/mocks"
+	"pvpc-backend/internal/platform/storage/inmemory"
+	"pvpc-backend/pkg/logger"
+)
+
+func Test_TariffPeriodsService_ClassifyPrices(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+
+	holiday, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-10-12", Name: "Fiesta Nacional de España"})
+	require.NoError(t, err)
+	pen := domain.ZoneDto{ID: "PEN", ExternalID: "1", Name: "PEN"}
+	bal := domain.ZoneDto{ID: "BAL", ExternalID: "1", Name: "BAL"}
+	ceu := domain.ZoneDto{ID: "CEU", ExternalID: "1", Name: "CEU"}
+	can := domain.ZoneDto{ID: "CAN", ExternalID: "1", Name: "CAN", TimeZone: "Atlantic/Canary"}
+
+	t.Run("when the holidays can't be read, it returns the error", func(t *testing.T) {
+		repository := new(mocks.HolidaysRepository)
+		repository.On("GetAll", mock.Anything).Return(nil, dErrors.NewDomainError(dErrors.PersistenceError, "mock-error"))
+
+		_, err := NewTariffPeriodsService(repository).ClassifyPrices(context.Background(), []domain.Prices{newTestPrices(t, domain.PVPCPrices, pen, parseTestDatetime(t, "2023-10-11T00:00:00+02:00"))})
+
+		require.Equal(t, dErrors.PersistenceError, dErrors.Code(err))
+		repository.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name      string
+		zone      domain.ZoneDto
+		datetimes []string
+		expected  []domain.TariffPeriod
+	}{
+		{
+			name: "working days hours are P3 until 8, P1 on 10-14 and 18-22 and P2 otherwise",
+			zone: pen,
+			datetimes: []string{
+				"2023-10-11T07:00:00+02:00", "2023-10-11T08:00:00+02:00", "2023-10-11T10:00:00+02:00", "2023-10-11T13:00:00+02:00",
+				"2023-10-11T14:00:00+02:00", "2023-10-11T18:00:00+02:00", "2023-10-11T21:00:00+02:00", "2023-10-11T22:00:00+02:00",
+			},
+			expected: []domain.TariffPeriod{
+				domain.TariffPeriodP3, domain.TariffPeriodP2, domain.TariffPeriodP1, domain.TariffPeriodP1,
+				domain.TariffPeriodP2, domain.TariffPeriodP1, domain.TariffPeriodP1, domain.TariffPeriodP2,
+			},
+		},
+		{
+			name:      "weekends are P3 the whole day",
+			zone:      pen,
+			datetimes: []string{"2023-10-14T07:00:00+02:00", "2023-10-14T10:00:00+02:00", "2023-10-14T16:00:00+02:00"},
+			expected:  []domain.TariffPeriod{domain.TariffPeriodP3, domain.TariffPeriodP3, domain.TariffPeriodP3},
+		},
+		{
+			name:      "holidays are P3 the whole day",
+			zone:      bal,
+			datetimes: []string{"2023-10-12T10:00:00+02:00", "2023-10-12T16:00:00+02:00"},
+			expected:  []domain.TariffPeriod{domain.TariffPeriodP3, domain.TariffPeriodP3},
+		},
+		{
+			name: "Ceuta and Melilla periods start one hour later",
+			zone: ceu,
+			datetimes: []string{
+				"2023-10-11T07:00:00+02:00", "2023-10-11T10:00:00+02:00", "2023-10-11T11:00:00+02:00", "2023-10-11T14:00:00+02:00", "2023-10-11T22:00:00+02:00",
+			},
+			expected: []domain.TariffPeriod{
+				domain.TariffPeriodP3, domain.TariffPeriodP2, domain.TariffPeriodP1, domain.TariffPeriodP1, domain.TariffPeriodP1,
+			},
+		},
+		{
+			name: "Canarias hours are classified in its local time",
+			zone: can,
+			datetimes: []string{
+				"2023-10-11T08:00:00+02:00", "2023-10-11T09:00:00+02:00", "2023-10-11T11:00:00+02:00", "2023-10-11T23:00:00+02:00",
+			},
+			expected: []domain.TariffPeriod{
+				domain.TariffPeriodP3, domain.TariffPeriodP2, domain.TariffPeriodP1, domain.TariffPeriodP2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := new(mocks.HolidaysRepository)
+			repository.On("GetAll", mock.Anything).Return([]domain.Holiday{holiday}, nil)
+
+			dto := domain.PricesDto{ID: tt.zone.ID + "-" + tt.datetimes[0][:10], Date: tt.datetimes[0][:10] + "T00:00:00+02:00", Zone: tt.zone}
+			for _, datetime := range tt.datetimes {
+				dto.Values = append(dto.Values, domain.HourlyPriceDto{Datetime: datetime, Value: 0.1})
+			}
+			prices, err := domain.NewPrices(dto)
+			require.NoError(t, err)
+
+			periods, err := NewTariffPeriodsService(repository).ClassifyPrices(context.Background(), []domain.Prices{prices})
+
+			require.NoError(t, err)
+			require.Equal(t, [][]domain.TariffPeriod{tt.expected}, periods)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_TariffPeriodsService_SeedHolidays(t *testing.T) {
+	logger.SetTestLogger(os.Stderr)
+	holidaysRepository := inmemory.NewHolidaysRepository()
+	service := NewTariffPeriodsService(holidaysRepository)
+	christmas2023, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-12-25", Name: "Natividad del Señor"})
+	require.NoError(t, err)
+	christmas2024, err := domain.NewHoliday(domain.HolidayDto{Date: "2024-12-25", Name: "Natividad del Señor"})
+	require.NoError(t, err)
+	renamed, err := domain.NewHoliday(domain.HolidayDto{Date: "2023-12-25", Name: "Navidad"})
+	require.NoError(t, err)
+	require.NoError(t, service.SaveHolidays(context.Background(), []domain.Holiday{renamed}))
+
+	t.Run("adds the holidays of the years the calendar doesn't have, keeping the stored ones", func(t *testing.T) {
+		seeded, err := service.SeedHolidays(context.Background(), []domain.Holiday{christmas2023, christmas2024})
+
+		require.NoError(t, err)
+		require.Equal(t, 1, seeded)
+		holidays, err := service.ListHolidays(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []domain.Holiday{renamed, christmas2024}, holidays)
+	})
+
+	t.Run("the years without holidays are reported as uncovered", func(t *testing.T) {
+		uncovered, err := service.UncoveredYears(context.Background(), 2023, 2024, 2025)
+
+		require.NoError(t, err)
+		require.Equal(t, []int{2025}, uncovered)
+	})
+}
+
+func Test_ReadHolidaysFile(t *testing.T) {
+	t.Run("when the file exists, it returns its holidays", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "holidays.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"date": "2023-12-25", "name": "Natividad del Señor"}]`), 0o600))
+
+		holidays, err := ReadHolidaysFile(path)
+
+		require.NoError(t, err)
+		require.Len(t, holidays, 1)
+		require.Equal(t, domain.HolidayDto{Date: "2023-12-25", Name: "Natividad del Señor"}, holidays[0].Serialize())
+	})
+
+	t.Run("the holidays file shipped in config is valid", func(t *testing.T) {
+		holidays, err := ReadHolidaysFile(filepath.Join("..", "..", "config", "holidays.json"))
+
+		require.NoError(t, err)
+		require.NotEmpty(t, holidays)
+	})
+
+	t.Run("when a date is invalid, it returns an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "holidays.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{"date": "25/12/2023", "name": "Natividad del Señor"}]`), 0o600))
+
+		_, err := ReadHolidaysFile(path)
+
+		require.Equal(t, dErrors.InvalidTime, dErrors.Code(err))
+	})
+
+	t.Run("when the file doesn't exist, it returns an error", func(t *testing.T) {
+		_, err := ReadHolidaysFile(filepath.Join(t.TempDir(), "missing.json"))
+
+		require.Error(t, err)
+	})
+}